@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
+	"task-app/internal/graph"
 	"task-app/internal/handler"
+	"task-app/internal/models"
+	"task-app/internal/notify"
 	"task-app/internal/repository"
 	"task-app/internal/service"
 
@@ -14,19 +22,295 @@ import (
 func main() {
 	taskRepo := repository.NewInMemoryTaskRepository()
 
-	taskService := service.NewTaskService(taskRepo)
+	historyRepo := repository.NewInMemoryHistoryRepository()
+
+	// Every service writes tasks through the recorder so that all mutations are kept in the history
+	recordedTaskRepo := service.NewHistoryRecorder(taskRepo, historyRepo)
+
+	taskService := service.NewTaskService(recordedTaskRepo)
+
+	if value := os.Getenv("CHECKLIST_AUTO_COMPLETE"); value != "" {
+		autoComplete, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Fatalf("Invalid CHECKLIST_AUTO_COMPLETE: %v", err)
+		}
+		taskService.SetChecklistAutoComplete(autoComplete)
+	}
+
+	taskService.SetOperationRepository(repository.NewInMemoryOperationRepository(), models.DefaultOperationLogSize)
+
+	historyHandler := handler.NewHistoryHandler(service.NewHistoryService(historyRepo, taskService))
 
 	taskHandler := handler.NewTaskHandler(taskService)
 
+	userRepo := repository.NewInMemoryUserRepository()
+
+	userService := service.NewUserService(userRepo, recordedTaskRepo)
+
+	taskService.SetUserRepository(userRepo)
+
+	userHandler := handler.NewUserHandler(userService)
+
+	projectRepo := repository.NewInMemoryProjectRepository()
+
+	taskRepo.SetProjectNames(projectRepo)
+
+	taskService.SetProjectRepository(projectRepo)
+
+	projectService := service.NewProjectService(projectRepo, recordedTaskRepo, userRepo)
+	projectService.SetProjectLinks(taskService.ProjectLinks())
+
+	projectHandler := handler.NewProjectHandler(projectService)
+
+	customFieldRepo := repository.NewInMemoryCustomFieldRepository()
+
+	taskService.SetCustomFieldRepository(customFieldRepo)
+
+	customFieldService := service.NewCustomFieldService(customFieldRepo, projectRepo, recordedTaskRepo)
+
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+
+	viewRepo := repository.NewInMemoryViewRepository()
+
+	viewService := service.NewViewService(viewRepo, taskService)
+
+	viewHandler := handler.NewViewHandler(viewService)
+
+	workflowRepo := repository.NewInMemoryWorkflowRepository()
+
+	workflowService := service.NewWorkflowService(workflowRepo, taskService)
+
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
+
+	projectService.OnProjectRenamed(workflowService.RenameCategory)
+	projectService.OnProjectRenamed(recordedTaskRepo.RecordProjectRename)
+
+	sprintService := service.NewSprintService(repository.NewInMemorySprintRepository(), taskService)
+
+	sprintHandler := handler.NewSprintHandler(sprintService)
+
+	milestoneService := service.NewMilestoneService(repository.NewInMemoryMilestoneRepository(), taskService)
+
+	milestoneHandler := handler.NewMilestoneHandler(milestoneService)
+
+	templateService := service.NewTemplateService(repository.NewInMemoryTemplateRepository(), taskService)
+
+	templateHandler := handler.NewTemplateHandler(templateService)
+
+	tagRepo := repository.NewInMemoryTagRepository()
+
+	tagService := service.NewTagService(tagRepo, recordedTaskRepo)
+	tagService.SetTagLinks(taskService.TagLinks())
+	taskService.OnTaskReplay(tagService.DropDeletedTags)
+
+	tagHandler := handler.NewTagHandler(tagService)
+
+	commentRepo := repository.NewInMemoryCommentRepository()
+
+	commentService := service.NewCommentService(commentRepo, taskService)
+
+	commentHandler := handler.NewCommentHandler(commentService)
+
+	blobDir := os.Getenv("BLOB_DIR")
+	if blobDir == "" {
+		blobDir = filepath.Join("data", "blobs")
+	}
+
+	blobStore, err := repository.NewLocalBlobStore(blobDir)
+	if err != nil {
+		log.Fatalf("Failed to open blob store: %v", err)
+	}
+
+	attachmentRepo := repository.NewInMemoryAttachmentRepository()
+
+	attachmentService := service.NewAttachmentService(attachmentRepo, blobStore, taskService)
+
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService)
+
+	worklogRepo := repository.NewInMemoryWorklogRepository()
+
+	worklogService := service.NewWorklogService(worklogRepo, taskService)
+
+	worklogHandler := handler.NewWorklogHandler(worklogService)
+
+	reminderFile := os.Getenv("REMINDER_FILE")
+	if reminderFile == "" {
+		reminderFile = filepath.Join("data", "reminders.json")
+	}
+
+	reminderRepo, err := repository.NewFileReminderRepository(reminderFile)
+	if err != nil {
+		log.Fatalf("Failed to load reminders: %v", err)
+	}
+
+	reminderService := service.NewReminderService(reminderRepo, taskService, reminderNotifier())
+
+	reminderHandler := handler.NewReminderHandler(reminderService)
+
+	// Tasks do not survive a restart, so reminders loaded from the file may belong to tasks that are gone
+	if orphaned, err := reminderService.ListOrphanedReminders(context.Background()); err != nil {
+		log.Printf("Failed to check for orphaned reminders: %v", err)
+	} else if len(orphaned) > 0 {
+		log.Printf("Loaded %d reminders of tasks that no longer exist, see GET /reminders/orphaned", len(orphaned))
+	}
+
+	schema, err := graph.NewSchema(taskService)
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
+	}
+
+	graphQLHandler := handler.NewGraphQLHandler(schema)
+
 	router := mux.NewRouter()
+	router.Use(userHandler.Authenticate)
 
 	router.HandleFunc("/tasks", taskHandler.CreateTask).Methods("POST")
 	router.HandleFunc("/tasks", taskHandler.ListTasks).Methods("GET")
+	router.HandleFunc("/tasks/stats", taskHandler.TaskStats).Methods("GET")
+	router.HandleFunc("/tasks/execution-order", taskHandler.ExecutionOrder).Methods("GET")
 	router.HandleFunc("/tasks/{id}", taskHandler.GetTask).Methods("GET")
 	router.HandleFunc("/tasks/{id}", taskHandler.UpdateTask).Methods("PUT")
 	router.HandleFunc("/tasks/{id}", taskHandler.DeleteTask).Methods("DELETE")
 	router.HandleFunc("/tasks/{id}/duplicate", taskHandler.DuplicateTask).Methods("POST")
+	router.HandleFunc("/tasks/{id}/children", taskHandler.ListChildren).Methods("GET")
+	router.HandleFunc("/tasks/{id}/subtree", taskHandler.GetSubtree).Methods("GET")
+	router.HandleFunc("/tasks/{id}/dependencies", taskHandler.ListDependencies).Methods("GET")
+	router.HandleFunc("/tasks/{id}/dependencies", taskHandler.AddDependency).Methods("POST")
+	router.HandleFunc("/tasks/{id}/dependencies/{blockerId}", taskHandler.RemoveDependency).Methods("DELETE")
+	router.HandleFunc("/tasks/{id}/recurrence", taskHandler.SetRecurrence).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/recurrence", taskHandler.ClearRecurrence).Methods("DELETE")
+	router.HandleFunc("/tasks/{id}/recurrence/next", taskHandler.NextOccurrence).Methods("POST")
+	router.HandleFunc("/tasks/{id}/checklist", taskHandler.AddChecklistItem).Methods("POST")
+	router.HandleFunc("/tasks/{id}/checklist/order", taskHandler.ReorderChecklist).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/checklist/{itemId}/toggle", taskHandler.ToggleChecklistItem).Methods("POST")
+	router.HandleFunc("/tasks/{id}/checklist/{itemId}", taskHandler.RemoveChecklistItem).Methods("DELETE")
+	router.HandleFunc("/tasks/{id}/history", historyHandler.ListHistory).Methods("GET")
+	router.HandleFunc("/tasks/{id}/history/snapshot", historyHandler.GetTaskAt).Methods("GET")
+
+	router.HandleFunc("/views", viewHandler.CreateView).Methods("POST")
+	router.HandleFunc("/views", viewHandler.ListViews).Methods("GET")
+	router.HandleFunc("/views/{id}", viewHandler.GetView).Methods("GET")
+	router.HandleFunc("/views/{id}", viewHandler.UpdateView).Methods("PUT")
+	router.HandleFunc("/views/{id}", viewHandler.DeleteView).Methods("DELETE")
+	router.HandleFunc("/views/{id}/tasks", viewHandler.ListViewTasks).Methods("GET")
+
+	router.HandleFunc("/workflows", workflowHandler.CreateWorkflow).Methods("POST")
+	router.HandleFunc("/workflows", workflowHandler.ListWorkflows).Methods("GET")
+	router.HandleFunc("/workflows/{id}", workflowHandler.GetWorkflow).Methods("GET")
+	router.HandleFunc("/workflows/{id}", workflowHandler.UpdateWorkflow).Methods("PUT")
+	router.HandleFunc("/workflows/{id}", workflowHandler.DeleteWorkflow).Methods("DELETE")
+
+	router.HandleFunc("/projects", projectHandler.CreateProject).Methods("POST")
+	router.HandleFunc("/projects", projectHandler.ListProjects).Methods("GET")
+	router.HandleFunc("/projects/{id}", projectHandler.GetProject).Methods("GET")
+	router.HandleFunc("/projects/{id}", projectHandler.UpdateProject).Methods("PUT")
+	router.HandleFunc("/projects/{id}", projectHandler.DeleteProject).Methods("DELETE")
+	router.HandleFunc("/projects/{id}/fields", customFieldHandler.CreateField).Methods("POST")
+	router.HandleFunc("/projects/{id}/fields", customFieldHandler.ListFields).Methods("GET")
+	router.HandleFunc("/projects/{id}/fields/{fieldId}", customFieldHandler.GetField).Methods("GET")
+	router.HandleFunc("/projects/{id}/fields/{fieldId}", customFieldHandler.UpdateField).Methods("PUT")
+	router.HandleFunc("/projects/{id}/fields/{fieldId}", customFieldHandler.DeleteField).Methods("DELETE")
+
+	router.HandleFunc("/sprints", sprintHandler.CreateSprint).Methods("POST")
+	router.HandleFunc("/sprints", sprintHandler.ListSprints).Methods("GET")
+	router.HandleFunc("/sprints/{id}", sprintHandler.GetSprint).Methods("GET")
+	router.HandleFunc("/sprints/{id}", sprintHandler.UpdateSprint).Methods("PUT")
+	router.HandleFunc("/sprints/{id}", sprintHandler.DeleteSprint).Methods("DELETE")
+	router.HandleFunc("/sprints/{id}/summary", sprintHandler.Summary).Methods("GET")
+	router.HandleFunc("/sprints/{id}/tasks", sprintHandler.ListSprintTasks).Methods("GET")
+	router.HandleFunc("/sprints/{id}/tasks/{taskId}", sprintHandler.AddTask).Methods("PUT")
+	router.HandleFunc("/sprints/{id}/tasks/{taskId}", sprintHandler.RemoveTask).Methods("DELETE")
+
+	router.HandleFunc("/milestones", milestoneHandler.CreateMilestone).Methods("POST")
+	router.HandleFunc("/milestones", milestoneHandler.ListMilestones).Methods("GET")
+	router.HandleFunc("/milestones/{id}", milestoneHandler.GetMilestone).Methods("GET")
+	router.HandleFunc("/milestones/{id}", milestoneHandler.UpdateMilestone).Methods("PUT")
+	router.HandleFunc("/milestones/{id}", milestoneHandler.DeleteMilestone).Methods("DELETE")
+	router.HandleFunc("/milestones/{id}/tasks", milestoneHandler.ListMilestoneTasks).Methods("GET")
+	router.HandleFunc("/milestones/{id}/tasks/{taskId}", milestoneHandler.AddTask).Methods("PUT")
+	router.HandleFunc("/milestones/{id}/tasks/{taskId}", milestoneHandler.RemoveTask).Methods("DELETE")
+
+	router.HandleFunc("/templates", templateHandler.CreateTemplate).Methods("POST")
+	router.HandleFunc("/templates", templateHandler.ListTemplates).Methods("GET")
+	router.HandleFunc("/templates/{id}", templateHandler.GetTemplate).Methods("GET")
+	router.HandleFunc("/templates/{id}", templateHandler.UpdateTemplate).Methods("PUT")
+	router.HandleFunc("/templates/{id}", templateHandler.DeleteTemplate).Methods("DELETE")
+	router.HandleFunc("/templates/{id}/instantiate", templateHandler.InstantiateTemplate).Methods("POST")
+	router.HandleFunc("/tasks/{id}/template", templateHandler.SaveTaskAsTemplate).Methods("POST")
+
+	router.HandleFunc("/tags", tagHandler.CreateTag).Methods("POST")
+	router.HandleFunc("/tags", tagHandler.ListTags).Methods("GET")
+	router.HandleFunc("/tags/{id}", tagHandler.GetTag).Methods("GET")
+	router.HandleFunc("/tags/{id}", tagHandler.UpdateTag).Methods("PUT")
+	router.HandleFunc("/tags/{id}", tagHandler.DeleteTag).Methods("DELETE")
+	router.HandleFunc("/tags/{id}/merge", tagHandler.MergeTag).Methods("POST")
+	router.HandleFunc("/tasks/{id}/tags", tagHandler.ListTaskTags).Methods("GET")
+	router.HandleFunc("/tasks/{id}/tags/{tagId}", tagHandler.AttachTag).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/tags/{tagId}", tagHandler.DetachTag).Methods("DELETE")
+
+	router.HandleFunc("/tasks/{id}/comments", commentHandler.CreateComment).Methods("POST")
+	router.HandleFunc("/tasks/{id}/comments", commentHandler.ListComments).Methods("GET")
+	router.HandleFunc("/tasks/{id}/comments/{commentId}", commentHandler.GetComment).Methods("GET")
+	router.HandleFunc("/tasks/{id}/comments/{commentId}", commentHandler.UpdateComment).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/comments/{commentId}", commentHandler.DeleteComment).Methods("DELETE")
+
+	router.HandleFunc("/tasks/{id}/attachments", attachmentHandler.UploadAttachment).Methods("POST")
+	router.HandleFunc("/tasks/{id}/attachments", attachmentHandler.ListAttachments).Methods("GET")
+	router.HandleFunc("/tasks/{id}/attachments/{attachmentId}", attachmentHandler.GetAttachment).Methods("GET")
+	router.HandleFunc("/tasks/{id}/attachments/{attachmentId}/content", attachmentHandler.DownloadAttachment).Methods("GET")
+	router.HandleFunc("/tasks/{id}/attachments/{attachmentId}", attachmentHandler.DeleteAttachment).Methods("DELETE")
+	router.HandleFunc("/attachments/gc", attachmentHandler.CollectGarbage).Methods("POST")
+
+	router.HandleFunc("/tasks/{id}/reminders", reminderHandler.CreateReminder).Methods("POST")
+	router.HandleFunc("/tasks/{id}/reminders", reminderHandler.ListReminders).Methods("GET")
+	router.HandleFunc("/tasks/{id}/reminders/{reminderId}", reminderHandler.GetReminder).Methods("GET")
+	router.HandleFunc("/tasks/{id}/reminders/{reminderId}", reminderHandler.DeleteReminder).Methods("DELETE")
+	router.HandleFunc("/reminders/orphaned", reminderHandler.ListOrphanedReminders).Methods("GET")
+	router.HandleFunc("/reminders/orphaned/{reminderId}", reminderHandler.DeleteOrphanedReminder).Methods("DELETE")
+
+	router.HandleFunc("/tasks/{id}/timer/start", worklogHandler.StartTimer).Methods("POST")
+	router.HandleFunc("/tasks/{id}/timer/stop", worklogHandler.StopTimer).Methods("POST")
+	router.HandleFunc("/tasks/{id}/worklogs", worklogHandler.CreateWorklog).Methods("POST")
+	router.HandleFunc("/tasks/{id}/worklogs", worklogHandler.ListWorklogs).Methods("GET")
+	router.HandleFunc("/tasks/{id}/worklogs/{worklogId}", worklogHandler.DeleteWorklog).Methods("DELETE")
+	router.HandleFunc("/tasks/{id}/time", worklogHandler.TaskTime).Methods("GET")
+	router.HandleFunc("/time/report", worklogHandler.TimeReport).Methods("GET")
+
+	router.HandleFunc("/users", userHandler.CreateUser).Methods("POST")
+	router.HandleFunc("/users", userHandler.ListUsers).Methods("GET")
+	router.HandleFunc("/users/{id}", userHandler.GetUser).Methods("GET")
+	router.HandleFunc("/users/{id}", userHandler.UpdateUser).Methods("PUT")
+	router.HandleFunc("/users/{id}", userHandler.DeleteUser).Methods("DELETE")
+	router.HandleFunc("/me", userHandler.Me).Methods("GET")
+	router.HandleFunc("/me/tasks", taskHandler.MyTasks).Methods("GET")
+	router.HandleFunc("/me/operations", taskHandler.ListOperations).Methods("GET")
+	router.HandleFunc("/me/undo", taskHandler.Undo).Methods("POST")
+	router.HandleFunc("/me/redo", taskHandler.Redo).Methods("POST")
+
+	router.HandleFunc("/graphql", graphQLHandler.Serve).Methods("GET", "POST")
+
+	taskService.StartRecurrenceScheduler(context.Background(), time.Minute)
+	reminderService.StartScheduler(context.Background(), 15*time.Second)
 
 	log.Println("Server starting on :8080")
 	log.Fatal(http.ListenAndServe(":8080", router))
 }
+
+// reminderNotifier writes reminders to REMINDER_LOG, or the server log when it is not set,
+// and also posts them to REMINDER_WEBHOOK_URL when that is set
+func reminderNotifier() notify.Notifier {
+	var logSink notify.Notifier = notify.NewLogNotifier(log.Writer())
+	if path := os.Getenv("REMINDER_LOG"); path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			log.Fatalf("Failed to open reminder log: %v", err)
+		}
+		logSink = notify.NewLogNotifier(file)
+	}
+
+	if url := os.Getenv("REMINDER_WEBHOOK_URL"); url != "" {
+		return notify.Multi{logSink, notify.NewWebhookNotifier(url)}
+	}
+
+	return logSink
+}
@@ -0,0 +1,604 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var priorityEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Priority",
+	Values: graphql.EnumValueConfigMap{
+		string(models.PriorityLow):    &graphql.EnumValueConfig{Value: models.PriorityLow},
+		string(models.PriorityMedium): &graphql.EnumValueConfig{Value: models.PriorityMedium},
+		string(models.PriorityHigh):   &graphql.EnumValueConfig{Value: models.PriorityHigh},
+	},
+})
+
+// Status is a scalar rather than an enum because categories can define their own statuses.
+// Literals may be written bare (TODO) or quoted ("IN_REVIEW").
+var statusScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Status",
+	Description: "A task status such as TODO, IN_PROGRESS, DONE or BLOCKED, or a status of the category's workflow",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case models.Status:
+			return string(value)
+		case string:
+			return value
+		default:
+			return nil
+		}
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			return models.Status(s)
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.EnumValue:
+			return models.Status(valueAST.Value)
+		case *ast.StringValue:
+			return models.Status(valueAST.Value)
+		default:
+			return nil
+		}
+	},
+})
+
+// CustomFields is an object of custom field values keyed by field key, as in the REST API. Literals may
+// hold strings, numbers and booleans; clearing a field with null needs a variable.
+var customFieldsScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "CustomFields",
+	Description: "Custom field values keyed by the field's key; null clears a field",
+	Serialize: func(value interface{}) interface{} {
+		if values, ok := value.(map[string]interface{}); ok {
+			return values
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if values, ok := value.(map[string]interface{}); ok {
+			return values
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		object, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			return nil
+		}
+
+		values := make(map[string]interface{}, len(object.Fields))
+		for _, field := range object.Fields {
+			switch value := field.Value.(type) {
+			case *ast.StringValue:
+				values[field.Name.Value] = value.Value
+			case *ast.EnumValue:
+				values[field.Name.Value] = value.Value
+			case *ast.IntValue:
+				number, err := strconv.ParseFloat(value.Value, 64)
+				if err != nil {
+					return nil
+				}
+				values[field.Name.Value] = number
+			case *ast.FloatValue:
+				number, err := strconv.ParseFloat(value.Value, 64)
+				if err != nil {
+					return nil
+				}
+				values[field.Name.Value] = number
+			case *ast.BooleanValue:
+				values[field.Name.Value] = value.Value
+			default:
+				return nil
+			}
+		}
+
+		return values
+	},
+})
+
+var checklistItemType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ChecklistItem",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.ChecklistItem).ID.String(), nil
+			},
+		},
+		"text":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"done":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"position": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+	},
+})
+
+// newTaskType builds the Task object type. Each schema gets its own, since the children, blocker and
+// transition fields resolve through the schema's service.
+func newTaskType(r *resolver) *graphql.Object {
+	var taskType *graphql.Object
+	taskType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.ID),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*models.Task).ID.String(), nil
+					},
+				},
+				"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"description": &graphql.Field{Type: graphql.String},
+				"category":    &graphql.Field{Type: graphql.String},
+				"projectId": &graphql.Field{
+					Type: graphql.ID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if projectID := p.Source.(*models.Task).ProjectID; projectID != nil {
+							return projectID.String(), nil
+						}
+						return nil, nil
+					},
+				},
+				"sprintId": &graphql.Field{
+					Type: graphql.ID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if sprintID := p.Source.(*models.Task).SprintID; sprintID != nil {
+							return sprintID.String(), nil
+						}
+						return nil, nil
+					},
+				},
+				"milestoneId": &graphql.Field{
+					Type: graphql.ID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if milestoneID := p.Source.(*models.Task).MilestoneID; milestoneID != nil {
+							return milestoneID.String(), nil
+						}
+						return nil, nil
+					},
+				},
+				"dueDate":  &graphql.Field{Type: graphql.DateTime},
+				"priority": &graphql.Field{Type: graphql.NewNonNull(priorityEnum)},
+				"status":   &graphql.Field{Type: graphql.NewNonNull(statusScalar)},
+				"parentId": &graphql.Field{
+					Type: graphql.ID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if parentID := p.Source.(*models.Task).ParentID; parentID != nil {
+							return parentID.String(), nil
+						}
+						return nil, nil
+					},
+				},
+				"assignees": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.ID))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						assignees := p.Source.(*models.Task).Assignees
+						ids := make([]string, len(assignees))
+						for i, id := range assignees {
+							ids[i] = id.String()
+						}
+						return ids, nil
+					},
+				},
+				"reporterId": &graphql.Field{
+					Type: graphql.ID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if reporterID := p.Source.(*models.Task).ReporterID; reporterID != nil {
+							return reporterID.String(), nil
+						}
+						return nil, nil
+					},
+				},
+				"progress": &graphql.Field{Type: graphql.Float},
+				"checklist": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(checklistItemType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if checklist := p.Source.(*models.Task).Checklist; checklist != nil {
+							return checklist, nil
+						}
+						return []models.ChecklistItem{}, nil
+					},
+				},
+				"checklistCompletion": &graphql.Field{Type: graphql.Float},
+				"customFields":        &graphql.Field{Type: customFieldsScalar},
+				"originalEstimate":    &graphql.Field{Type: graphql.Int},
+				"remainingEstimate":   &graphql.Field{Type: graphql.Int},
+				"storyPoints":         &graphql.Field{Type: graphql.Float},
+				"children": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taskType))),
+					Resolve: r.children,
+				},
+				"allowedTransitions": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(statusScalar))),
+					Resolve: r.allowedTransitions,
+				},
+				"blockedBy": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taskType))),
+					Resolve: r.blockedBy,
+				},
+				"createdAt": &graphql.Field{Type: graphql.DateTime},
+				"updatedAt": &graphql.Field{Type: graphql.DateTime},
+			}
+		}),
+	})
+
+	return taskType
+}
+
+var taskInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "TaskInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"category":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"projectId":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+		"dueDate":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.DateTime)},
+		"priority":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(priorityEnum)},
+		"status":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(statusScalar)},
+		"parentId":    &graphql.InputObjectFieldConfig{Type: graphql.ID},
+		"assignees":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+		"reporterId":  &graphql.InputObjectFieldConfig{Type: graphql.ID},
+
+		"originalEstimate":  &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"remainingEstimate": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"storyPoints":       &graphql.InputObjectFieldConfig{Type: graphql.Float},
+		"customFields":      &graphql.InputObjectFieldConfig{Type: customFieldsScalar},
+	},
+})
+
+var duplicateOverridesType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DuplicateOverrides",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"priority":    &graphql.InputObjectFieldConfig{Type: priorityEnum},
+	},
+})
+
+// NewSchema builds the GraphQL schema whose queries and mutations delegate to the task service.
+func NewSchema(taskService *service.TaskService) (graphql.Schema, error) {
+	r := &resolver{service: taskService}
+
+	taskType := newTaskType(r)
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"task": &graphql.Field{
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.task,
+			},
+			"tasks": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taskType))),
+				Args: graphql.FieldConfigArgument{
+					"title":      &graphql.ArgumentConfig{Type: graphql.String},
+					"category":   &graphql.ArgumentConfig{Type: graphql.String},
+					"status":     &graphql.ArgumentConfig{Type: statusScalar},
+					"priority":   &graphql.ArgumentConfig{Type: priorityEnum},
+					"dueDate":    &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"assignee":   &graphql.ArgumentConfig{Type: graphql.ID},
+					"unassigned": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"overdue":    &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"dueWithin":  &graphql.ArgumentConfig{Type: graphql.String},
+					"stale":      &graphql.ArgumentConfig{Type: graphql.String},
+					"sort":       &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.tasks,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTask": &graphql.Field{
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(taskInputType)},
+				},
+				Resolve: r.createTask,
+			},
+			"updateTask": &graphql.Field{
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(taskInputType)},
+				},
+				Resolve: r.updateTask,
+			},
+			"deleteTask": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"cascade": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.deleteTask,
+			},
+			"duplicateTask": &graphql.Field{
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{
+					"id":            &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"children":      &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"keepAssignees": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"keepStatus":    &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"titlePattern":  &graphql.ArgumentConfig{Type: graphql.String},
+					"dueDate":       &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"shiftDueDate":  &graphql.ArgumentConfig{Type: graphql.String},
+					"deepCopy":      &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"overrides":     &graphql.ArgumentConfig{Type: duplicateOverridesType},
+				},
+				Resolve: r.duplicateTask,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+type resolver struct {
+	service *service.TaskService
+}
+
+func (r *resolver) task(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.service.GetTask(p.Context, id)
+}
+
+func (r *resolver) tasks(p graphql.ResolveParams) (interface{}, error) {
+	params := make(map[string]string)
+	for _, key := range []string{"title", "category", "status", "priority", "assignee"} {
+		if value, ok := p.Args[key]; ok && value != nil {
+			params[key] = fmt.Sprint(value)
+		}
+	}
+	if dueDate, ok := p.Args["dueDate"].(time.Time); ok {
+		params["due_date"] = dueDate.Format(time.RFC3339)
+	}
+	if unassigned, ok := p.Args["unassigned"].(bool); ok {
+		params["unassigned"] = strconv.FormatBool(unassigned)
+	}
+	if overdue, ok := p.Args["overdue"].(bool); ok {
+		params["overdue"] = strconv.FormatBool(overdue)
+	}
+	if dueWithin, ok := p.Args["dueWithin"].(string); ok {
+		params["due_within"] = dueWithin
+	}
+	if stale, ok := p.Args["stale"].(string); ok {
+		params["stale"] = stale
+	}
+
+	filters, err := service.ParseTaskFilters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := r.service.ListTasks(p.Context, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy, _ := p.Args["sort"].(string)
+	if err := service.SortTasks(tasks, sortBy); err != nil {
+		return nil, err
+	}
+
+	return taskPointers(tasks), nil
+}
+
+func (r *resolver) createTask(p graphql.ResolveParams) (interface{}, error) {
+	req, err := createRequest(p.Args["input"])
+	if err != nil {
+		return nil, err
+	}
+
+	return r.service.CreateTask(p.Context, req)
+}
+
+func (r *resolver) updateTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := createRequest(p.Args["input"])
+	if err != nil {
+		return nil, err
+	}
+
+	task := &models.Task{
+		ID:          id,
+		Title:       req.Title,
+		Description: req.Description,
+		Category:    req.Category,
+		ProjectID:   req.ProjectID,
+		DueDate:     req.DueDate,
+		Priority:    req.Priority,
+		Status:      req.Status,
+		ParentID:    req.ParentID,
+		Assignees:   req.Assignees,
+		ReporterID:  req.ReporterID,
+
+		OriginalEstimate:  req.OriginalEstimate,
+		RemainingEstimate: req.RemainingEstimate,
+		StoryPoints:       req.StoryPoints,
+		CustomFields:      req.CustomFields,
+	}
+
+	if err := r.service.UpdateTask(p.Context, task); err != nil {
+		return nil, err
+	}
+
+	return r.service.GetTask(p.Context, id)
+}
+
+func (r *resolver) deleteTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if cascade, ok := p.Args["cascade"].(string); ok {
+		err = r.service.DeleteTaskWithMode(p.Context, id, models.DeleteMode(cascade))
+	} else {
+		err = r.service.DeleteTask(p.Context, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+func (r *resolver) duplicateTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var req models.DuplicateTaskRequest
+	if children, ok := p.Args["children"].(bool); ok {
+		req.Children = &children
+	}
+	if keepAssignees, ok := p.Args["keepAssignees"].(bool); ok {
+		req.KeepAssignees = &keepAssignees
+	}
+	req.KeepStatus, _ = p.Args["keepStatus"].(bool)
+	req.TitlePattern, _ = p.Args["titlePattern"].(string)
+	if dueDate, ok := p.Args["dueDate"].(time.Time); ok {
+		req.DueDate = &dueDate
+	}
+	req.ShiftDueDate, _ = p.Args["shiftDueDate"].(string)
+	req.DeepCopy, _ = p.Args["deepCopy"].(bool)
+	overrides, _ := p.Args["overrides"].(map[string]interface{})
+	if description, ok := overrides["description"].(string); ok {
+		req.Overrides.Description = &description
+	}
+	if priority, ok := overrides["priority"].(models.Priority); ok {
+		req.Overrides.Priority = &priority
+	}
+
+	opts, err := r.service.DuplicateOptionsFor(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.service.DuplicateTaskWithOptions(p.Context, id, opts)
+}
+
+func (r *resolver) allowedTransitions(p graphql.ResolveParams) (interface{}, error) {
+	return r.service.AllowedTransitions(p.Source.(*models.Task)), nil
+}
+
+func (r *resolver) children(p graphql.ResolveParams) (interface{}, error) {
+	children, err := r.service.ListChildren(p.Context, p.Source.(*models.Task).ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskPointers(children), nil
+}
+
+func (r *resolver) blockedBy(p graphql.ResolveParams) (interface{}, error) {
+	blockers, err := r.service.ListDependencies(p.Context, p.Source.(*models.Task).ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskPointers(blockers), nil
+}
+
+// taskPointers adapts a task slice to the pointer sources the Task type resolvers expect
+func taskPointers(tasks []models.Task) []*models.Task {
+	result := make([]*models.Task, len(tasks))
+	for i := range tasks {
+		result[i] = &tasks[i]
+	}
+
+	return result
+}
+
+// idArg parses the "id" argument of a field as a task UUID
+func idArg(p graphql.ResolveParams) (uuid.UUID, error) {
+	raw, _ := p.Args["id"].(string)
+
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid task ID: %s", raw)
+	}
+
+	return id, nil
+}
+
+// createRequest maps a TaskInput argument onto a CreateTaskRequest
+func createRequest(input interface{}) (models.CreateTaskRequest, error) {
+	fields, _ := input.(map[string]interface{})
+
+	req := models.CreateTaskRequest{}
+	req.Title, _ = fields["title"].(string)
+	req.Description, _ = fields["description"].(string)
+	req.Category, _ = fields["category"].(string)
+	if projectID, ok := fields["projectId"].(string); ok {
+		id, err := uuid.Parse(projectID)
+		if err != nil {
+			return req, fmt.Errorf("invalid project ID: %s", projectID)
+		}
+		req.ProjectID = &id
+	}
+	req.DueDate, _ = fields["dueDate"].(time.Time)
+	req.Priority, _ = fields["priority"].(models.Priority)
+	req.Status, _ = fields["status"].(models.Status)
+	if parentID, ok := fields["parentId"].(string); ok {
+		id, err := uuid.Parse(parentID)
+		if err != nil {
+			return req, fmt.Errorf("invalid parent ID: %s", parentID)
+		}
+		req.ParentID = &id
+	}
+	assignees, _ := fields["assignees"].([]interface{})
+	for _, raw := range assignees {
+		userID, _ := raw.(string)
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			return req, fmt.Errorf("invalid assignee ID: %s", userID)
+		}
+		req.Assignees = append(req.Assignees, id)
+	}
+	if reporterID, ok := fields["reporterId"].(string); ok {
+		id, err := uuid.Parse(reporterID)
+		if err != nil {
+			return req, fmt.Errorf("invalid reporter ID: %s", reporterID)
+		}
+		req.ReporterID = &id
+	}
+	if estimate, ok := fields["originalEstimate"].(int); ok {
+		req.OriginalEstimate = &estimate
+	}
+	if estimate, ok := fields["remainingEstimate"].(int); ok {
+		req.RemainingEstimate = &estimate
+	}
+	if points, ok := fields["storyPoints"].(float64); ok {
+		req.StoryPoints = &points
+	}
+	req.CustomFields, _ = fields["customFields"].(map[string]interface{})
+
+	return req, nil
+}
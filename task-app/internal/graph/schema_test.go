@@ -0,0 +1,221 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	schema, err := NewSchema(service.NewTaskService(repository.NewInMemoryTaskRepository()))
+	if err != nil {
+		t.Fatalf("Failed to build schema: %v", err)
+	}
+
+	run := func(query string, variables map[string]interface{}) *graphql.Result {
+		return graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: variables,
+			Context:        context.Background(),
+		})
+	}
+
+	created := run(`mutation($input: TaskInput!) { createTask(input: $input) { id title priority } }`, map[string]interface{}{
+		"input": map[string]interface{}{
+			"title":    "GraphQL Task",
+			"dueDate":  time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+			"priority": "HIGH",
+			"status":   "TODO",
+		},
+	})
+	assert.Empty(t, created.Errors)
+
+	task := created.Data.(map[string]interface{})["createTask"].(map[string]interface{})
+	assert.Equal(t, "GraphQL Task", task["title"])
+	assert.Equal(t, "HIGH", task["priority"])
+
+	tests := []struct {
+		name      string
+		query     string
+		expectErr bool
+	}{
+		{
+			name:      "Get Task",
+			query:     `{ task(id: "` + task["id"].(string) + `") { title status } }`,
+			expectErr: false,
+		},
+		{
+			name:      "List Tasks With Filters",
+			query:     `{ tasks(priority: HIGH, status: TODO, sort: "-due_date") { id title } }`,
+			expectErr: false,
+		},
+		{
+			name:      "Duplicate Task",
+			query:     `mutation { duplicateTask(id: "` + task["id"].(string) + `") { title } }`,
+			expectErr: false,
+		},
+		{
+			name:      "Task People And Checklist",
+			query:     `{ task(id: "` + task["id"].(string) + `") { assignees reporterId checklist { text done } checklistCompletion } }`,
+			expectErr: false,
+		},
+		{
+			name:      "Invalid ID",
+			query:     `{ task(id: "not-a-uuid") { title } }`,
+			expectErr: true,
+		},
+		{
+			name:      "Create Invalid Task",
+			query:     `mutation { createTask(input: {title: "", dueDate: "2000-01-01T00:00:00Z", priority: LOW, status: TODO}) { id } }`,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := run(test.query, nil)
+			if test.expectErr {
+				assert.NotEmpty(t, result.Errors)
+			} else {
+				assert.Empty(t, result.Errors)
+			}
+		})
+	}
+
+	deleted := run(`mutation { deleteTask(id: "`+task["id"].(string)+`") }`, nil)
+	assert.Empty(t, deleted.Errors)
+	assert.Equal(t, true, deleted.Data.(map[string]interface{})["deleteTask"])
+}
+
+func TestSchemasAreIndependent(t *testing.T) {
+	ctx := context.Background()
+	taskService := service.NewTaskService(repository.NewInMemoryTaskRepository())
+	parent, err := taskService.CreateTask(ctx, models.CreateTaskRequest{
+		Title:    "Parent",
+		DueDate:  time.Now().Add(24 * time.Hour),
+		Priority: models.PriorityMedium,
+		Status:   models.StatusToDo,
+	})
+	assert.NoError(t, err)
+	_, err = taskService.CreateTask(ctx, models.CreateTaskRequest{
+		Title:    "Child",
+		DueDate:  time.Now().Add(24 * time.Hour),
+		Priority: models.PriorityMedium,
+		Status:   models.StatusToDo,
+		ParentID: &parent.ID,
+	})
+	assert.NoError(t, err)
+
+	schema, err := NewSchema(taskService)
+	assert.NoError(t, err)
+
+	// A second schema over another service must not take over the resolvers of the first
+	_, err = NewSchema(service.NewTaskService(repository.NewInMemoryTaskRepository()))
+	assert.NoError(t, err)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ task(id: "` + parent.ID.String() + `") { children { title } blockedBy { id } allowedTransitions } }`,
+		Context:       ctx,
+	})
+	assert.Empty(t, result.Errors)
+
+	children := result.Data.(map[string]interface{})["task"].(map[string]interface{})["children"].([]interface{})
+	assert.Len(t, children, 1)
+}
+
+func TestTaskCustomFields(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := repository.NewInMemoryProjectRepository()
+	taskRepo := repository.NewInMemoryTaskRepository()
+	taskRepo.SetProjectNames(projectRepo)
+	customFieldRepo := repository.NewInMemoryCustomFieldRepository()
+
+	taskService := service.NewTaskService(taskRepo)
+	taskService.SetProjectRepository(projectRepo)
+	taskService.SetCustomFieldRepository(customFieldRepo)
+
+	project, err := service.NewProjectService(projectRepo, taskRepo, nil).CreateProject(ctx, models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+	fields := service.NewCustomFieldService(customFieldRepo, projectRepo, taskRepo)
+	_, err = fields.CreateField(ctx, project.ID, models.CreateCustomFieldRequest{Key: "severity", Name: "Severity", Type: models.CustomFieldEnum, Options: []string{"Low", "High"}})
+	assert.NoError(t, err)
+	_, err = fields.CreateField(ctx, project.ID, models.CreateCustomFieldRequest{Key: "impact", Name: "Impact", Type: models.CustomFieldNumber})
+	assert.NoError(t, err)
+
+	schema, err := NewSchema(taskService)
+	assert.NoError(t, err)
+	run := func(query string, variables map[string]interface{}) map[string]interface{} {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, VariableValues: variables, Context: ctx})
+		assert.Empty(t, result.Errors)
+		data, _ := result.Data.(map[string]interface{})
+		return data
+	}
+
+	dueDate := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+	created := run(`mutation($input: TaskInput!) { createTask(input: $input) { id customFields } }`, map[string]interface{}{
+		"input": map[string]interface{}{
+			"title": "Outage", "category": "Ops", "dueDate": dueDate, "priority": "HIGH", "status": "TODO",
+			"customFields": map[string]interface{}{"severity": "high"},
+		},
+	})["createTask"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"severity": "High"}, created["customFields"])
+
+	// Updates return the stored task, with the fields they leave out unchanged
+	updated := run(`mutation { updateTask(id: "`+created["id"].(string)+`", input: {
+		title: "Outage", category: "Ops", dueDate: "`+dueDate+`", priority: HIGH, status: TODO, customFields: {impact: 3}
+	}) { customFields } }`, nil)["updateTask"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"severity": "High", "impact": 3.0}, updated["customFields"])
+
+	updated = run(`mutation($id: ID!, $input: TaskInput!) { updateTask(id: $id, input: $input) { customFields } }`, map[string]interface{}{
+		"id": created["id"],
+		"input": map[string]interface{}{
+			"title": "Outage", "category": "Ops", "dueDate": dueDate, "priority": "HIGH", "status": "TODO",
+			"customFields": map[string]interface{}{"severity": nil},
+		},
+	})["updateTask"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"impact": 3.0}, updated["customFields"])
+}
+
+func TestDuplicateTaskOptions(t *testing.T) {
+	ctx := context.Background()
+	taskService := service.NewTaskService(repository.NewInMemoryTaskRepository())
+	task, err := taskService.CreateTask(ctx, models.CreateTaskRequest{
+		Title:       "Weekly report",
+		Description: "Summarize the week",
+		DueDate:     time.Now().Add(24 * time.Hour),
+		Priority:    models.PriorityMedium,
+		Status:      models.StatusToDo,
+	})
+	assert.NoError(t, err)
+
+	schema, err := NewSchema(taskService)
+	assert.NoError(t, err)
+	run := func(args string) *graphql.Result {
+		return graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `mutation { duplicateTask(id: "` + task.ID.String() + `"` + args + `) { dueDate description priority } }`,
+			Context:       ctx,
+		})
+	}
+
+	dueDate := task.DueDate.Add(7 * 24 * time.Hour).UTC().Truncate(time.Second)
+	result := run(`, dueDate: "` + dueDate.Format(time.RFC3339) + `", overrides: {description: "Summarize the month", priority: HIGH}`)
+	assert.Empty(t, result.Errors)
+
+	copied := result.Data.(map[string]interface{})["duplicateTask"].(map[string]interface{})
+	assert.Equal(t, dueDate.Format(time.RFC3339), copied["dueDate"])
+	assert.Equal(t, "Summarize the month", copied["description"])
+	assert.Equal(t, "HIGH", copied["priority"])
+
+	result = run(`, dueDate: "` + dueDate.Format(time.RFC3339) + `", shiftDueDate: "+7d"`)
+	assert.NotEmpty(t, result.Errors)
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// multipartOverhead allows for the boundaries and part headers around an uploaded file
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	service *service.AttachmentService
+}
+
+func NewAttachmentHandler(service *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{service: service}
+}
+
+// UploadAttachment accepts a multipart/form-data request with the file in the "file" field.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to upload an attachment")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.service.Limits().MaxSize+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		log.Printf("Error reading multipart body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			http.Error(w, "Missing file field", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Printf("Error reading multipart body: %v\n", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		attachment, err := h.service.Upload(r.Context(), taskID, part.FileName(), part)
+		if err != nil {
+			log.Printf("Error uploading attachment to task %v: %v\n", taskID, err)
+			http.Error(w, err.Error(), attachmentErrorStatus(err))
+			return
+		}
+
+		log.Printf("Attachment uploaded successfully: %v\n", attachment.ID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(attachment)
+		return
+	}
+}
+
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list attachments")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	attachments, err := h.service.ListAttachments(r.Context(), taskID)
+	if err != nil {
+		log.Printf("Error listing attachments of task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachments)
+}
+
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get an attachment")
+
+	taskID, attachmentID, ok := parseTaskAttachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.GetAttachment(r.Context(), taskID, attachmentID)
+	if err != nil {
+		log.Printf("Attachment not found with ID: %v\n", attachmentID)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachment)
+}
+
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to download an attachment")
+
+	taskID, attachmentID, ok := parseTaskAttachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.service.Open(r.Context(), taskID, attachmentID)
+	if err != nil {
+		log.Printf("Error opening attachment with ID %v: %v\n", attachmentID, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("Error streaming attachment with ID %v: %v\n", attachmentID, err)
+	}
+}
+
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete an attachment")
+
+	taskID, attachmentID, ok := parseTaskAttachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAttachment(r.Context(), taskID, attachmentID); err != nil {
+		log.Printf("Error deleting attachment with ID %v: %v\n", attachmentID, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Attachment deleted successfully: %v\n", attachmentID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CollectGarbage removes stored blobs that no attachment references.
+func (h *AttachmentHandler) CollectGarbage(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to collect unreferenced blobs")
+
+	removed, err := h.service.CollectGarbage(r.Context())
+	if err != nil {
+		log.Printf("Error collecting blobs: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"removed": removed})
+}
+
+// parseTaskAttachmentIDs reads the task and attachment IDs from the route, writing a 400 response when either is invalid
+func parseTaskAttachmentIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	taskID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	attachmentID, err := uuid.Parse(vars["attachmentId"])
+	if err != nil {
+		log.Printf("Invalid attachment ID: %v\n", vars["attachmentId"])
+		http.Error(w, "Invalid attachment ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return taskID, attachmentID, true
+}
+
+// attachmentErrorStatus maps oversized uploads to 413, rejected types to 415, missing tasks to 404 and other failures to 400
+func attachmentErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, service.ErrAttachmentTooLarge), errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrAttachmentTypeDenied):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func (h *TaskHandler) AddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to add a checklist item")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.AddChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.AddChecklistItem(r.Context(), id, req)
+	if err != nil {
+		log.Printf("Error adding checklist item to task %v: %v\n", id, err)
+		http.Error(w, err.Error(), checklistErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *TaskHandler) ReorderChecklist(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to reorder a checklist")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ReorderChecklistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.ReorderChecklist(r.Context(), id, req.ItemIDs)
+	if err != nil {
+		log.Printf("Error reordering checklist of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), checklistErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *TaskHandler) ToggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to toggle a checklist item")
+
+	id, itemID, ok := parseTaskChecklistIDs(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.ToggleChecklistItem(r.Context(), id, itemID)
+	if err != nil {
+		log.Printf("Error toggling checklist item %v of task %v: %v\n", itemID, id, err)
+		http.Error(w, err.Error(), checklistErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *TaskHandler) RemoveChecklistItem(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to remove a checklist item")
+
+	id, itemID, ok := parseTaskChecklistIDs(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.RemoveChecklistItem(r.Context(), id, itemID)
+	if err != nil {
+		log.Printf("Error removing checklist item %v of task %v: %v\n", itemID, id, err)
+		http.Error(w, err.Error(), checklistErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+// parseTaskChecklistIDs reads the task and checklist item IDs from the route, writing a 400 response when either is invalid
+func parseTaskChecklistIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	itemID, err := uuid.Parse(vars["itemId"])
+	if err != nil {
+		log.Printf("Invalid checklist item ID: %v\n", vars["itemId"])
+		http.Error(w, "Invalid checklist item ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, itemID, true
+}
+
+// checklistErrorStatus maps missing tasks or items to 404 and other failures to 400
+func checklistErrorStatus(err error) int {
+	if errors.Is(err, service.ErrChecklistItemNotFound) || errors.Is(err, repository.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type CommentHandler struct {
+	service *service.CommentService
+}
+
+func NewCommentHandler(service *service.CommentService) *CommentHandler {
+	return &CommentHandler{service: service}
+}
+
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a comment")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	comment, err := h.service.CreateComment(r.Context(), taskID, req)
+	if err != nil {
+		log.Printf("Error creating comment on task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), commentErrorStatus(err))
+		return
+	}
+
+	log.Printf("Comment created successfully: %v\n", comment.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list comments")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	offset, limit, err := pagination(r, service.DefaultCommentPageSize)
+	if err != nil {
+		log.Printf("Invalid pagination parameters: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListComments(r.Context(), taskID, offset, limit)
+	if err != nil {
+		log.Printf("Error listing comments of task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), commentErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *CommentHandler) GetComment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a comment")
+
+	taskID, commentID, ok := parseTaskCommentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := h.service.GetComment(r.Context(), taskID, commentID)
+	if err != nil {
+		log.Printf("Comment not found with ID: %v\n", commentID)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comment)
+}
+
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a comment")
+
+	taskID, commentID, ok := parseTaskCommentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	comment, err := h.service.UpdateComment(r.Context(), taskID, commentID, req)
+	if err != nil {
+		log.Printf("Error updating comment with ID %v: %v\n", commentID, err)
+		http.Error(w, err.Error(), commentErrorStatus(err))
+		return
+	}
+
+	log.Printf("Comment updated successfully: %v\n", commentID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comment)
+}
+
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a comment")
+
+	taskID, commentID, ok := parseTaskCommentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteComment(r.Context(), taskID, commentID); err != nil {
+		log.Printf("Error deleting comment with ID %v: %v\n", commentID, err)
+		http.Error(w, err.Error(), commentErrorStatus(err))
+		return
+	}
+
+	log.Printf("Comment deleted successfully: %v\n", commentID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTaskCommentIDs reads the task and comment IDs from the route, writing a 400 response when either is invalid
+func parseTaskCommentIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	taskID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	commentID, err := uuid.Parse(vars["commentId"])
+	if err != nil {
+		log.Printf("Invalid comment ID: %v\n", vars["commentId"])
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return taskID, commentID, true
+}
+
+// pagination reads the offset and limit query parameters, defaulting to the first page of the given size
+func pagination(r *http.Request, defaultLimit int) (int, int, error) {
+	offset, limit := 0, defaultLimit
+
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, errors.New("offset must be a number")
+		}
+		offset = parsed
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, errors.New("limit must be a number")
+		}
+		limit = parsed
+	}
+
+	return offset, limit, nil
+}
+
+// commentErrorStatus maps anonymous changes to 401, authorship failures to 403, missing comments or tasks to 404 and other failures to 400
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCommentAuthRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrNotCommentAuthor):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrCommentNotFound), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type CustomFieldHandler struct {
+	service *service.CustomFieldService
+}
+
+func NewCustomFieldHandler(service *service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{service: service}
+}
+
+func (h *CustomFieldHandler) CreateField(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a custom field")
+
+	projectID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid project ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateCustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	def, err := h.service.CreateField(r.Context(), projectID, req)
+	if err != nil {
+		log.Printf("Error creating custom field: %v\n", err)
+		http.Error(w, err.Error(), customFieldErrorStatus(err))
+		return
+	}
+
+	log.Printf("Custom field created successfully: %v\n", def.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(def)
+}
+
+func (h *CustomFieldHandler) ListFields(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list custom fields")
+
+	projectID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid project ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	defs, err := h.service.ListFields(r.Context(), projectID)
+	if err != nil {
+		log.Printf("Error listing custom fields of project %v: %v\n", projectID, err)
+		http.Error(w, err.Error(), customFieldErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(defs)
+}
+
+func (h *CustomFieldHandler) GetField(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a custom field")
+
+	projectID, fieldID, ok := parseProjectFieldIDs(w, r)
+	if !ok {
+		return
+	}
+
+	def, err := h.service.GetField(r.Context(), projectID, fieldID)
+	if err != nil {
+		log.Printf("Custom field not found with ID: %v\n", fieldID)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(def)
+}
+
+func (h *CustomFieldHandler) UpdateField(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a custom field")
+
+	projectID, fieldID, ok := parseProjectFieldIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateCustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	def := &models.CustomFieldDefinition{
+		ID:        fieldID,
+		ProjectID: projectID,
+		Key:       req.Key,
+		Name:      req.Name,
+		Type:      req.Type,
+		Options:   req.Options,
+		Required:  req.Required,
+	}
+
+	if err := h.service.UpdateField(r.Context(), def); err != nil {
+		log.Printf("Error updating custom field with ID %v: %v\n", fieldID, err)
+		http.Error(w, err.Error(), customFieldErrorStatus(err))
+		return
+	}
+
+	log.Printf("Custom field updated successfully: %v\n", fieldID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(def)
+}
+
+func (h *CustomFieldHandler) DeleteField(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a custom field")
+
+	projectID, fieldID, ok := parseProjectFieldIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteField(r.Context(), projectID, fieldID); err != nil {
+		log.Printf("Error deleting custom field with ID %v: %v\n", fieldID, err)
+		http.Error(w, err.Error(), customFieldErrorStatus(err))
+		return
+	}
+
+	log.Printf("Custom field deleted successfully: %v\n", fieldID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseProjectFieldIDs reads the project and custom field IDs from the route, writing a 400 response when either is invalid
+func parseProjectFieldIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	projectID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid project ID: %v\n", vars["id"])
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	fieldID, err := uuid.Parse(vars["fieldId"])
+	if err != nil {
+		log.Printf("Invalid custom field ID: %v\n", vars["fieldId"])
+		http.Error(w, "Invalid custom field ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, fieldID, true
+}
+
+// customFieldErrorStatus maps key clashes and changes to a field's key or type to 409, missing projects or fields to 404 and other failures to 400
+func customFieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCustomFieldKeyTaken), errors.Is(err, service.ErrCustomFieldImmutable):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrCustomFieldNotFound), errors.Is(err, service.ErrProjectNotFound), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+type GraphQLHandler struct {
+	schema graphql.Schema
+}
+
+func NewGraphQLHandler(schema graphql.Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (h *GraphQLHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received GraphQL request")
+
+	var req graphQLRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				log.Printf("Error decoding GraphQL variables: %v\n", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// GET requests must not change data, so mutations are only accepted over POST
+	if r.Method == http.MethodGet && isMutation(req.Query, req.OperationName) {
+		log.Println("Rejected GraphQL mutation sent with GET")
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "mutations must be sent with POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        r.Context(),
+	})
+
+	if result.HasErrors() {
+		log.Printf("GraphQL request completed with errors: %v\n", result.Errors)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// isMutation reports whether the operation a query selects is a mutation. Queries that do not parse are left to
+// graphql.Do to report.
+func isMutation(query, operationName string) bool {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return false
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" && (operation.Name == nil || operation.Name.Value != operationName) {
+			continue
+		}
+		if operation.Operation == ast.OperationTypeMutation {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"task-app/internal/repository"
+	"task-app/internal/service"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type HistoryHandler struct {
+	service *service.HistoryService
+}
+
+func NewHistoryHandler(service *service.HistoryService) *HistoryHandler {
+	return &HistoryHandler{service: service}
+}
+
+// ListHistory returns the change history of a task, optionally limited to one field with the field query parameter.
+func (h *HistoryHandler) ListHistory(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list the history of a task")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.service.ListHistory(r.Context(), taskID, r.URL.Query().Get("field"))
+	if err != nil {
+		log.Printf("Error listing history of task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), historyErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// GetTaskAt reconstructs a task as it was at the RFC 3339 time given by the at query parameter.
+func (h *HistoryHandler) GetTaskAt(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to reconstruct a task")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, r.URL.Query().Get("at"))
+	if err != nil {
+		log.Printf("Invalid time: %v\n", r.URL.Query().Get("at"))
+		http.Error(w, "at must be an RFC 3339 time", http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.TaskAt(r.Context(), taskID, at)
+	if err != nil {
+		log.Printf("Error reconstructing task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), historyErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+// historyErrorStatus maps unknown fields to 400, missing tasks to 404 and other failures to 500
+func historyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrUnknownField):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrTaskNotFoundAt), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
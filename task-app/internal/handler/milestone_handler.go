@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type MilestoneHandler struct {
+	service *service.MilestoneService
+}
+
+func NewMilestoneHandler(service *service.MilestoneService) *MilestoneHandler {
+	return &MilestoneHandler{service: service}
+}
+
+func (h *MilestoneHandler) CreateMilestone(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a milestone")
+
+	var req models.CreateMilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	milestone, err := h.service.CreateMilestone(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating milestone: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("Milestone created successfully: %v\n", milestone.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(milestone)
+}
+
+func (h *MilestoneHandler) GetMilestone(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a milestone")
+
+	id, ok := parseMilestoneID(w, r)
+	if !ok {
+		return
+	}
+
+	milestone, err := h.service.GetMilestone(r.Context(), id)
+	if err != nil {
+		log.Printf("Milestone not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(milestone)
+}
+
+func (h *MilestoneHandler) ListMilestones(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list milestones")
+
+	milestones, err := h.service.ListMilestones(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving milestones: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(milestones)
+}
+
+func (h *MilestoneHandler) UpdateMilestone(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a milestone")
+
+	id, ok := parseMilestoneID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateMilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	milestone := &models.Milestone{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+	}
+
+	if err := h.service.UpdateMilestone(r.Context(), milestone); err != nil {
+		log.Printf("Error updating milestone with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), milestoneErrorStatus(err))
+		return
+	}
+
+	log.Printf("Milestone updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(milestone)
+}
+
+func (h *MilestoneHandler) DeleteMilestone(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a milestone")
+
+	id, ok := parseMilestoneID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteMilestone(r.Context(), id); err != nil {
+		log.Printf("Error deleting milestone with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), milestoneErrorStatus(err))
+		return
+	}
+
+	log.Printf("Milestone deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MilestoneHandler) ListMilestoneTasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list the tasks of a milestone")
+
+	id, ok := parseMilestoneID(w, r)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.service.ListMilestoneTasks(r.Context(), id)
+	if err != nil {
+		log.Printf("Error listing tasks of milestone %v: %v\n", id, err)
+		http.Error(w, err.Error(), milestoneErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tasks)
+}
+
+func (h *MilestoneHandler) AddTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to add a task to a milestone")
+
+	milestoneID, taskID, ok := parseMilestoneTaskIDs(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.AddTask(r.Context(), milestoneID, taskID)
+	if err != nil {
+		log.Printf("Error adding task %v to milestone %v: %v\n", taskID, milestoneID, err)
+		http.Error(w, err.Error(), milestoneErrorStatus(err))
+		return
+	}
+
+	log.Printf("Task added to milestone successfully: %v to %v\n", taskID, milestoneID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *MilestoneHandler) RemoveTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to remove a task from a milestone")
+
+	milestoneID, taskID, ok := parseMilestoneTaskIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.RemoveTask(r.Context(), milestoneID, taskID); err != nil {
+		log.Printf("Error removing task %v from milestone %v: %v\n", taskID, milestoneID, err)
+		http.Error(w, err.Error(), milestoneErrorStatus(err))
+		return
+	}
+
+	log.Printf("Task removed from milestone successfully: %v from %v\n", taskID, milestoneID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseMilestoneID reads the milestone ID from the route, writing a 400 response when it is invalid
+func parseMilestoneID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid milestone ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid milestone ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// parseMilestoneTaskIDs reads the milestone and task IDs from the route, writing a 400 response when either is invalid
+func parseMilestoneTaskIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	milestoneID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid milestone ID: %v\n", vars["id"])
+		http.Error(w, "Invalid milestone ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	taskID, err := uuid.Parse(vars["taskId"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["taskId"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return milestoneID, taskID, true
+}
+
+// milestoneErrorStatus maps tasks already in the milestone to 409, missing milestones or tasks to 404 and other failures to 400
+func milestoneErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTaskAlreadyInMilestone):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrTaskNotInMilestone), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
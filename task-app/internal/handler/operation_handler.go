@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/service"
+)
+
+// ListOperations returns the operation log of the authenticated user, newest first.
+func (h *TaskHandler) ListOperations(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list the current user's operations")
+
+	operations, err := h.service.ListOperations(r.Context())
+	if err != nil {
+		log.Printf("Error listing operations: %v\n", err)
+		http.Error(w, err.Error(), operationErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(operations)
+}
+
+func (h *TaskHandler) Undo(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to undo an operation")
+
+	operation, err := h.service.Undo(r.Context())
+	if err != nil {
+		log.Printf("Error undoing operation: %v\n", err)
+		http.Error(w, err.Error(), operationErrorStatus(err))
+		return
+	}
+
+	log.Printf("Operation undone successfully: %v\n", operation.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(operation)
+}
+
+func (h *TaskHandler) Redo(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to redo an operation")
+
+	operation, err := h.service.Redo(r.Context())
+	if err != nil {
+		log.Printf("Error redoing operation: %v\n", err)
+		http.Error(w, err.Error(), operationErrorStatus(err))
+		return
+	}
+
+	log.Printf("Operation redone successfully: %v\n", operation.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(operation)
+}
+
+// operationErrorStatus maps anonymous requests to 401, an empty log to 404, changed tasks to 409 and other failures to 500
+func operationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrOperationUserRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrNothingToUndo), errors.Is(err, service.ErrNothingToRedo):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrOperationConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
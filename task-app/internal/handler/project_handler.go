@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ProjectHandler struct {
+	service *service.ProjectService
+}
+
+func NewProjectHandler(service *service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{service: service}
+}
+
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a project")
+
+	var req models.CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	project, err := h.service.CreateProject(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating project: %v\n", err)
+		http.Error(w, err.Error(), projectErrorStatus(err))
+		return
+	}
+
+	log.Printf("Project created successfully: %v\n", project.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(project)
+}
+
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a project")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid project ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	project, err := h.service.GetProject(r.Context(), id)
+	if err != nil {
+		log.Printf("Project not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+}
+
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list projects")
+
+	projects, err := h.service.ListProjects(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving projects: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(projects)
+}
+
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a project")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid project ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	project := &models.Project{
+		ID:              id,
+		Name:            req.Name,
+		Description:     req.Description,
+		OwnerID:         req.OwnerID,
+		DefaultPriority: req.DefaultPriority,
+	}
+
+	if err := h.service.UpdateProject(r.Context(), project); err != nil {
+		log.Printf("Error updating project with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), projectErrorStatus(err))
+		return
+	}
+
+	log.Printf("Project updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+}
+
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a project")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid project ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteProject(r.Context(), id); err != nil {
+		log.Printf("Error deleting project with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), projectErrorStatus(err))
+		return
+	}
+
+	log.Printf("Project deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// projectErrorStatus maps name clashes and projects still in use to 409, unknown owners to 400, missing projects to 404 and other failures to 400
+func projectErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrProjectNameTaken), errors.Is(err, service.ErrProjectInUse):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func (h *TaskHandler) SetRecurrence(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to set a recurrence rule")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetRecurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.SetRecurrence(r.Context(), id, req)
+	if err != nil {
+		log.Printf("Error setting recurrence of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), recurrenceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *TaskHandler) ClearRecurrence(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to clear a recurrence rule")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.service.ClearRecurrence(r.Context(), id); err != nil {
+		log.Printf("Error clearing recurrence of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), recurrenceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NextOccurrence generates the next occurrence of a recurring task right away.
+func (h *TaskHandler) NextOccurrence(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to generate the next occurrence")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.GenerateNextOccurrence(r.Context(), id)
+	if err != nil {
+		log.Printf("Error generating next occurrence of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), recurrenceErrorStatus(err))
+		return
+	}
+
+	log.Printf("Next occurrence generated successfully: %v\n", task.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(task)
+}
+
+// recurrenceErrorStatus maps a finished or already continued series to 409, missing tasks or rules to 404 and other failures to 400
+func recurrenceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrOccurrenceExists), errors.Is(err, service.ErrRecurrenceEnded):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrNotRecurring), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ReminderHandler struct {
+	service *service.ReminderService
+}
+
+func NewReminderHandler(service *service.ReminderService) *ReminderHandler {
+	return &ReminderHandler{service: service}
+}
+
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a reminder")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reminder, err := h.service.CreateReminder(r.Context(), taskID, req)
+	if err != nil {
+		log.Printf("Error creating reminder on task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), reminderErrorStatus(err))
+		return
+	}
+
+	log.Printf("Reminder created successfully: %v\n", reminder.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reminder)
+}
+
+func (h *ReminderHandler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list reminders")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	reminders, err := h.service.ListReminders(r.Context(), taskID)
+	if err != nil {
+		log.Printf("Error listing reminders of task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), reminderErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reminders)
+}
+
+func (h *ReminderHandler) GetReminder(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a reminder")
+
+	taskID, reminderID, ok := parseTaskReminderIDs(w, r)
+	if !ok {
+		return
+	}
+
+	reminder, err := h.service.GetReminder(r.Context(), taskID, reminderID)
+	if err != nil {
+		log.Printf("Reminder not found with ID: %v\n", reminderID)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reminder)
+}
+
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a reminder")
+
+	taskID, reminderID, ok := parseTaskReminderIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteReminder(r.Context(), taskID, reminderID); err != nil {
+		log.Printf("Error deleting reminder with ID %v: %v\n", reminderID, err)
+		http.Error(w, err.Error(), reminderErrorStatus(err))
+		return
+	}
+
+	log.Printf("Reminder deleted successfully: %v\n", reminderID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListOrphanedReminders lists the reminders whose task no longer exists.
+func (h *ReminderHandler) ListOrphanedReminders(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list orphaned reminders")
+
+	reminders, err := h.service.ListOrphanedReminders(r.Context())
+	if err != nil {
+		log.Printf("Error listing orphaned reminders: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reminders)
+}
+
+func (h *ReminderHandler) DeleteOrphanedReminder(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete an orphaned reminder")
+
+	reminderID, err := uuid.Parse(mux.Vars(r)["reminderId"])
+	if err != nil {
+		log.Printf("Invalid reminder ID: %v\n", mux.Vars(r)["reminderId"])
+		http.Error(w, "Invalid reminder ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteOrphanedReminder(r.Context(), reminderID); err != nil {
+		log.Printf("Error deleting orphaned reminder with ID %v: %v\n", reminderID, err)
+		http.Error(w, err.Error(), reminderErrorStatus(err))
+		return
+	}
+
+	log.Printf("Orphaned reminder deleted successfully: %v\n", reminderID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTaskReminderIDs reads the task and reminder IDs from the route, writing a 400 response when either is invalid
+func parseTaskReminderIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	taskID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	reminderID, err := uuid.Parse(vars["reminderId"])
+	if err != nil {
+		log.Printf("Invalid reminder ID: %v\n", vars["reminderId"])
+		http.Error(w, "Invalid reminder ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return taskID, reminderID, true
+}
+
+// reminderErrorStatus maps missing reminders or tasks to 404 and other failures to 400
+func reminderErrorStatus(err error) int {
+	if errors.Is(err, service.ErrReminderNotFound) || errors.Is(err, repository.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type SprintHandler struct {
+	service *service.SprintService
+}
+
+func NewSprintHandler(service *service.SprintService) *SprintHandler {
+	return &SprintHandler{service: service}
+}
+
+func (h *SprintHandler) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a sprint")
+
+	var req models.CreateSprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sprint, err := h.service.CreateSprint(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating sprint: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("Sprint created successfully: %v\n", sprint.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sprint)
+}
+
+func (h *SprintHandler) GetSprint(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a sprint")
+
+	id, ok := parseSprintID(w, r)
+	if !ok {
+		return
+	}
+
+	sprint, err := h.service.GetSprint(r.Context(), id)
+	if err != nil {
+		log.Printf("Sprint not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sprint)
+}
+
+func (h *SprintHandler) ListSprints(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list sprints")
+
+	sprints, err := h.service.ListSprints(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving sprints: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sprints)
+}
+
+func (h *SprintHandler) UpdateSprint(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a sprint")
+
+	id, ok := parseSprintID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateSprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sprint := &models.Sprint{
+		ID:        id,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+
+	if err := h.service.UpdateSprint(r.Context(), sprint); err != nil {
+		log.Printf("Error updating sprint with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), sprintErrorStatus(err))
+		return
+	}
+
+	log.Printf("Sprint updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sprint)
+}
+
+func (h *SprintHandler) DeleteSprint(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a sprint")
+
+	id, ok := parseSprintID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSprint(r.Context(), id); err != nil {
+		log.Printf("Error deleting sprint with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), sprintErrorStatus(err))
+		return
+	}
+
+	log.Printf("Sprint deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SprintHandler) ListSprintTasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list the tasks of a sprint")
+
+	id, ok := parseSprintID(w, r)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.service.ListSprintTasks(r.Context(), id)
+	if err != nil {
+		log.Printf("Error listing tasks of sprint %v: %v\n", id, err)
+		http.Error(w, err.Error(), sprintErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tasks)
+}
+
+func (h *SprintHandler) AddTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to add a task to a sprint")
+
+	sprintID, taskID, ok := parseSprintTaskIDs(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.AddTask(r.Context(), sprintID, taskID)
+	if err != nil {
+		log.Printf("Error adding task %v to sprint %v: %v\n", taskID, sprintID, err)
+		http.Error(w, err.Error(), sprintErrorStatus(err))
+		return
+	}
+
+	log.Printf("Task added to sprint successfully: %v to %v\n", taskID, sprintID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *SprintHandler) RemoveTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to remove a task from a sprint")
+
+	sprintID, taskID, ok := parseSprintTaskIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.RemoveTask(r.Context(), sprintID, taskID); err != nil {
+		log.Printf("Error removing task %v from sprint %v: %v\n", taskID, sprintID, err)
+		http.Error(w, err.Error(), sprintErrorStatus(err))
+		return
+	}
+
+	log.Printf("Task removed from sprint successfully: %v from %v\n", taskID, sprintID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Summary reports the committed, added, removed and completed work of a sprint.
+func (h *SprintHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request for a sprint summary")
+
+	id, ok := parseSprintID(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.service.Summary(r.Context(), id)
+	if err != nil {
+		log.Printf("Error summarizing sprint %v: %v\n", id, err)
+		http.Error(w, err.Error(), sprintErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+// parseSprintID reads the sprint ID from the route, writing a 400 response when it is invalid
+func parseSprintID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid sprint ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid sprint ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// parseSprintTaskIDs reads the sprint and task IDs from the route, writing a 400 response when either is invalid
+func parseSprintTaskIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	sprintID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid sprint ID: %v\n", vars["id"])
+		http.Error(w, "Invalid sprint ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	taskID, err := uuid.Parse(vars["taskId"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["taskId"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return sprintID, taskID, true
+}
+
+// sprintErrorStatus maps changes to ended sprints, date changes of started sprints and tasks already planned to 409, missing sprints or tasks to 404 and other failures to 400
+func sprintErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSprintClosed), errors.Is(err, service.ErrSprintStarted),
+		errors.Is(err, service.ErrTaskAlreadyInSprint):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrTaskNotInSprint), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type TagHandler struct {
+	service *service.TagService
+}
+
+func NewTagHandler(service *service.TagService) *TagHandler {
+	return &TagHandler{service: service}
+}
+
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a tag")
+
+	var req models.CreateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tag, err := h.service.CreateTag(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating tag: %v\n", err)
+		http.Error(w, err.Error(), tagErrorStatus(err))
+		return
+	}
+
+	log.Printf("Tag created successfully: %v\n", tag.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *TagHandler) GetTag(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a tag")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid tag ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return
+	}
+
+	tag, err := h.service.GetTag(r.Context(), id)
+	if err != nil {
+		log.Printf("Tag not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *TagHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a tag")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid tag ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tag := &models.Tag{
+		ID:          id,
+		Name:        req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+	}
+
+	if err := h.service.UpdateTag(r.Context(), tag); err != nil {
+		log.Printf("Error updating tag with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), tagErrorStatus(err))
+		return
+	}
+
+	log.Printf("Tag updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a tag")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid tag ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTag(r.Context(), id); err != nil {
+		log.Printf("Error deleting tag with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Tag deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TagHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list tags")
+
+	tags, err := h.service.ListTags(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving tags: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+}
+
+func (h *TagHandler) MergeTag(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to merge a tag")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid tag ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MergeTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tag, err := h.service.MergeTag(r.Context(), id, req.Into)
+	if err != nil {
+		log.Printf("Error merging tag %v into %v: %v\n", id, req.Into, err)
+		http.Error(w, err.Error(), tagErrorStatus(err))
+		return
+	}
+
+	log.Printf("Tag merged successfully: %v into %v\n", id, req.Into)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *TagHandler) ListTaskTags(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list the tags of a task")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := h.service.ListTaskTags(r.Context(), id)
+	if err != nil {
+		log.Printf("Error listing tags of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+}
+
+func (h *TagHandler) AttachTag(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to attach a tag to a task")
+
+	taskID, tagID, ok := parseTaskTagIDs(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.AttachTag(r.Context(), taskID, tagID)
+	if err != nil {
+		log.Printf("Error attaching tag %v to task %v: %v\n", tagID, taskID, err)
+		http.Error(w, err.Error(), tagErrorStatus(err))
+		return
+	}
+
+	log.Printf("Tag attached successfully: %v to %v\n", tagID, taskID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *TagHandler) DetachTag(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to detach a tag from a task")
+
+	taskID, tagID, ok := parseTaskTagIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.service.DetachTag(r.Context(), taskID, tagID); err != nil {
+		log.Printf("Error detaching tag %v from task %v: %v\n", tagID, taskID, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Tag detached successfully: %v from %v\n", tagID, taskID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTaskTagIDs reads the task and tag IDs from the route, writing a 400 response when either is invalid
+func parseTaskTagIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	taskID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	tagID, err := uuid.Parse(vars["tagId"])
+	if err != nil {
+		log.Printf("Invalid tag ID: %v\n", vars["tagId"])
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return taskID, tagID, true
+}
+
+// tagErrorStatus maps tag conflicts to 409, missing tags or tasks to 404 and other failures to 400
+func tagErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTagNameTaken), errors.Is(err, service.ErrTagAlreadySet):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"net/http"
-	"time"
+	"strings"
 
+	"task-app/internal/auth"
 	"task-app/internal/models"
+	"task-app/internal/repository"
 	"task-app/internal/service"
 	"task-app/pkg/utils"
 
@@ -34,6 +38,12 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	task, err := h.service.CreateTask(r.Context(), req)
+	if isReferenceError(err) {
+		log.Printf("Error creating task: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
 	if err != nil {
 		errorMsg := utils.FilterValidationError(err)
 		log.Printf("Error creating task: %v\n", err)
@@ -61,6 +71,13 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fields, err := utils.ParseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		log.Printf("Invalid fields parameter: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	task, err := h.service.GetTask(r.Context(), id)
 	if err != nil {
 		log.Printf("Task not found with ID: %v\n", id)
@@ -70,6 +87,19 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Task retrieved successfully: %v\n", task.ID)
 
+	if len(fields) > 0 {
+		projected, err := utils.ProjectTask(task, fields)
+		if err != nil {
+			log.Printf("Error projecting task fields: %v\n", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(projected)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(task)
 }
@@ -93,7 +123,18 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	task.ID = id
-	if err := h.service.UpdateTask(r.Context(), &task); err != nil {
+	err = h.service.UpdateTask(r.Context(), &task)
+	if isReferenceError(err) {
+		log.Printf("Error updating task with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, utils.ErrTransitionNotAllowed) || errors.Is(err, utils.ErrTransitionGuard) {
+		log.Printf("Status transition rejected for task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
 		log.Printf("Error updating task with ID %v: %v\n", id, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -115,7 +156,19 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.DeleteTask(r.Context(), id); err != nil {
+	mode := models.DeleteMode(r.URL.Query().Get("cascade"))
+	if mode == "" {
+		err = h.service.DeleteTask(r.Context(), id)
+	} else {
+		err = h.service.DeleteTaskWithMode(r.Context(), id, mode)
+	}
+
+	if errors.Is(err, service.ErrHasSubtasks) {
+		log.Printf("Refusing to delete task with subtasks %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
 		log.Printf("Error deleting task with ID %v: %v\n", id, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -128,45 +181,79 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	log.Println("Received request to list tasks")
 
-	filters := make(map[string]interface{})
-
 	// Parse query parameters for filtering
-	query := r.URL.Query()
-	if title := query.Get("title"); title != "" {
-		filters["title"] = title
+	filters, err := service.ParseTaskFilters(filterParams(r))
+	if err != nil {
+		log.Printf("Error parsing filters: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	if category := query.Get("category"); category != "" {
-		filters["category"] = category
+	fields, err := utils.ParseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		log.Printf("Invalid fields parameter: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	if status := query.Get("status"); status != "" {
-		filters["status"] = models.Status(status)
+	tasks, err := h.service.ListTasks(r.Context(), filters)
+	if err != nil {
+		log.Printf("Error retrieving tasks: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	if priority := query.Get("priority"); priority != "" {
-		filters["priority"] = models.Priority(priority)
+	if err := service.SortTasks(tasks, r.URL.Query().Get("sort")); err != nil {
+		log.Printf("Error sorting tasks: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	if dueDate := query.Get("due_date"); dueDate != "" {
-		if parsedDate, err := time.Parse(time.RFC3339, dueDate); err == nil {
-			filters["due_date"] = parsedDate
-		} else {
-			log.Printf("Error parsing due_date: %v\n", err)
-		}
+	log.Printf("Tasks retrieved successfully: %d tasks found\n", len(tasks))
+
+	writeTasks(w, tasks, fields)
+}
+
+// MyTasks lists the tasks assigned to the authenticated user, accepting the same parameters as ListTasks.
+func (h *TaskHandler) MyTasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list the current user's tasks")
+
+	userID, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
 	}
 
-	tasks, err := h.service.ListTasks(r.Context(), filters)
+	filters, err := service.ParseTaskFilters(filterParams(r))
 	if err != nil {
-		log.Printf("Error retrieving tasks: %v\n", err)
+		log.Printf("Error parsing filters: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fields, err := utils.ParseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		log.Printf("Invalid fields parameter: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tasks, err := h.service.ListAssignedTasks(r.Context(), userID, filters)
+	if err != nil {
+		log.Printf("Error retrieving tasks of user %v: %v\n", userID, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("Tasks retrieved successfully: %d tasks found\n", len(tasks))
+	if err := service.SortTasks(tasks, r.URL.Query().Get("sort")); err != nil {
+		log.Printf("Error sorting tasks: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tasks)
+	log.Printf("Tasks of user %v retrieved successfully: %d tasks found\n", userID, len(tasks))
+
+	writeTasks(w, tasks, fields)
 }
 
 func (h *TaskHandler) DuplicateTask(w http.ResponseWriter, r *http.Request) {
@@ -181,10 +268,49 @@ func (h *TaskHandler) DuplicateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	task, err := h.service.DuplicateTask(r.Context(), id)
+	var req models.DuplicateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := h.service.DuplicateOptionsFor(req)
+	if err != nil {
+		log.Printf("Invalid duplicate options: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The query parameters set the same options as children and keep_assignees in the body, so both may
+	// only be given when they agree
+	if children := r.URL.Query().Get("children"); children != "" {
+		opts.Children = children == "true"
+		if req.Children != nil && *req.Children != opts.Children {
+			log.Printf("Conflicting children options: %v\n", children)
+			http.Error(w, "children query parameter conflicts with the request body", http.StatusBadRequest)
+			return
+		}
+	}
+	switch assignees := r.URL.Query().Get("assignees"); assignees {
+	case "":
+	case "keep", "clear":
+		opts.KeepAssignees = assignees == "keep"
+		if req.KeepAssignees != nil && *req.KeepAssignees != opts.KeepAssignees {
+			log.Printf("Conflicting assignees options: %v\n", assignees)
+			http.Error(w, "assignees query parameter conflicts with keep_assignees in the request body", http.StatusBadRequest)
+			return
+		}
+	default:
+		log.Printf("Invalid assignees parameter: %v\n", assignees)
+		http.Error(w, "assignees must be keep or clear", http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.DuplicateTaskWithOptions(r.Context(), id, opts)
 	if err != nil {
 		log.Printf("Error duplicating task with ID %v: %v\n", id, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), duplicateErrorStatus(err))
 		return
 	}
 
@@ -194,3 +320,257 @@ func (h *TaskHandler) DuplicateTask(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(task)
 }
+
+func (h *TaskHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list subtasks")
+
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	children, err := h.service.ListChildren(r.Context(), id)
+	if err != nil {
+		log.Printf("Error listing subtasks of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Subtasks retrieved successfully: %d subtasks found\n", len(children))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(children)
+}
+
+func (h *TaskHandler) GetSubtree(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a task subtree")
+
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	tree, err := h.service.GetSubtree(r.Context(), id)
+	if err != nil {
+		log.Printf("Error retrieving subtree of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Subtree retrieved successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tree)
+}
+
+func (h *TaskHandler) ListDependencies(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list task dependencies")
+
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	blockers, err := h.service.ListDependencies(r.Context(), id)
+	if err != nil {
+		log.Printf("Error listing dependencies of task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(blockers)
+}
+
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to add a task dependency")
+
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.AddDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.AddDependency(r.Context(), id, req.BlockedBy)
+	if errors.Is(err, service.ErrSelfDependency) || errors.Is(err, service.ErrDependencyCycle) || errors.Is(err, service.ErrDependencyExists) {
+		log.Printf("Rejected dependency for task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("Error adding dependency to task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Dependency added successfully: %v blocked by %v\n", id, req.BlockedBy)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(task)
+}
+
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to remove a task dependency")
+
+	vars := mux.Vars(r)
+
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	blockerID, err := uuid.Parse(vars["blockerId"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["blockerId"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.service.RemoveDependency(r.Context(), id, blockerID); err != nil {
+		log.Printf("Error removing dependency from task %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Dependency removed successfully: %v no longer blocked by %v\n", id, blockerID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TaskHandler) ExecutionOrder(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request for task execution order")
+
+	filters, err := service.ParseTaskFilters(filterParams(r))
+	if err != nil {
+		log.Printf("Error parsing filters: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.service.PlanExecution(r.Context(), filters)
+	if err != nil {
+		log.Printf("Error planning execution order: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Execution order computed successfully: %d tasks\n", len(plan.Order))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plan)
+}
+
+// isReferenceError reports whether err is caused by a task referencing a parent, user, project or custom field
+// that cannot be used
+func isReferenceError(err error) bool {
+	return errors.Is(err, service.ErrParentNotFound) || errors.Is(err, service.ErrHierarchyCycle) ||
+		errors.Is(err, service.ErrUserNotFound) || errors.Is(err, service.ErrDuplicateAssignee) ||
+		errors.Is(err, service.ErrProjectNotFound) || errors.Is(err, service.ErrProjectMismatch) ||
+		errors.Is(err, utils.ErrUnknownCustomField) || errors.Is(err, utils.ErrCustomFieldRequired) ||
+		errors.Is(err, utils.ErrInvalidCustomFieldValue)
+}
+
+// duplicateErrorStatus maps invalid duplicates to 400, missing tasks to 404 and other failures to 500
+func duplicateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidDuplicate), errors.Is(err, service.ErrInvalidTitlePattern):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeTasks encodes a task list, projected onto fields when any are given
+func writeTasks(w http.ResponseWriter, tasks []models.Task, fields []string) {
+	if len(fields) > 0 {
+		projected, err := utils.ProjectTasks(tasks, fields)
+		if err != nil {
+			log.Printf("Error projecting task fields: %v\n", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(projected)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tasks)
+}
+
+// filterParams collects the supported filter parameters, including cf.<key> custom field filters, from the query string
+func filterParams(r *http.Request) map[string]string {
+	query := r.URL.Query()
+	params := make(map[string]string)
+
+	for _, key := range service.FilterKeys {
+		if value := query.Get(key); value != "" {
+			params[key] = value
+		}
+	}
+
+	for key := range query {
+		if value := query.Get(key); strings.HasPrefix(key, models.CustomFieldPrefix) && value != "" {
+			params[key] = value
+		}
+	}
+
+	return params
+}
+
+func (h *TaskHandler) TaskStats(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request for task statistics")
+
+	filters, err := service.ParseTaskFilters(filterParams(r))
+	if err != nil {
+		log.Printf("Error parsing filters: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := models.AggregationOptions{
+		Interval: r.URL.Query().Get("interval"),
+	}
+	if groupBy := r.URL.Query().Get("group_by"); groupBy != "" {
+		opts.GroupBy = strings.Split(groupBy, ",")
+	}
+
+	stats, err := h.service.AggregateTasks(r.Context(), filters, opts)
+	if err != nil {
+		log.Printf("Error aggregating tasks: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("Task statistics computed successfully: %d tasks\n", stats.Total)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
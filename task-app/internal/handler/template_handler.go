@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type TemplateHandler struct {
+	service *service.TemplateService
+}
+
+func NewTemplateHandler(service *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a template")
+
+	var req models.CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.CreateTemplate(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating template: %v\n", err)
+		http.Error(w, err.Error(), templateErrorStatus(err))
+		return
+	}
+
+	log.Printf("Template created successfully: %v\n", template.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a template")
+
+	id, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := h.service.GetTemplate(r.Context(), id)
+	if err != nil {
+		log.Printf("Template not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list templates")
+
+	templates, err := h.service.ListTemplates(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving templates: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a template")
+
+	id, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	template := &models.TaskTemplate{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		Task:        req.Task,
+	}
+
+	if err := h.service.UpdateTemplate(r.Context(), template); err != nil {
+		log.Printf("Error updating template with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), templateErrorStatus(err))
+		return
+	}
+
+	log.Printf("Template updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a template")
+
+	id, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(r.Context(), id); err != nil {
+		log.Printf("Error deleting template with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), templateErrorStatus(err))
+		return
+	}
+
+	log.Printf("Template deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TemplateHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to instantiate a template")
+
+	id, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.InstantiateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tree, err := h.service.Instantiate(r.Context(), id, req)
+	if err != nil {
+		log.Printf("Error instantiating template with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), templateErrorStatus(err))
+		return
+	}
+
+	log.Printf("Template instantiated successfully: %v as %v\n", id, tree.Task.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tree)
+}
+
+func (h *TemplateHandler) SaveTaskAsTemplate(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to save a task as a template")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SaveAsTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.SaveTaskAsTemplate(r.Context(), taskID, req)
+	if err != nil {
+		log.Printf("Error saving task %v as template: %v\n", taskID, err)
+		http.Error(w, err.Error(), templateErrorStatus(err))
+		return
+	}
+
+	log.Printf("Task saved as template successfully: %v as %v\n", taskID, template.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+// parseTemplateID reads the template ID from the route, writing a 400 response when it is invalid
+func parseTemplateID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid template ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid template ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// templateErrorStatus maps duplicate template names to 409, missing templates or tasks to 404 and other failures to 400
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTemplateNameTaken):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
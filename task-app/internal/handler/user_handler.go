@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// UserHeader is the request header carrying the ID of the authenticated user.
+const UserHeader = "X-User-ID"
+
+type UserHandler struct {
+	service *service.UserService
+}
+
+func NewUserHandler(service *service.UserService) *UserHandler {
+	return &UserHandler{service: service}
+}
+
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a user")
+
+	var req models.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.CreateUser(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating user: %v\n", err)
+		http.Error(w, err.Error(), userErrorStatus(err))
+		return
+	}
+
+	log.Printf("User created successfully: %v\n", user.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a user")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid user ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.GetUser(r.Context(), id)
+	if err != nil {
+		log.Printf("User not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a user")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid user ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	user := &models.User{
+		ID:    id,
+		Name:  req.Name,
+		Email: req.Email,
+	}
+
+	if err := h.service.UpdateUser(r.Context(), user); err != nil {
+		log.Printf("Error updating user with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), userErrorStatus(err))
+		return
+	}
+
+	log.Printf("User updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a user")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid user ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteUser(r.Context(), id); err != nil {
+		log.Printf("Error deleting user with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("User deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list users")
+
+	users, err := h.service.ListUsers(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving users: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(users)
+}
+
+// Me returns the authenticated user.
+func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request for the current user")
+
+	id, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.service.GetUser(r.Context(), id)
+	if err != nil {
+		log.Printf("User not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// Authenticate resolves the user named by the X-User-ID header and stores it in the request context.
+// Requests without the header pass through anonymously; an unknown or malformed ID is rejected.
+func (h *UserHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(UserHeader)
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(header)
+		if err != nil {
+			log.Printf("Invalid %s header: %v\n", UserHeader, header)
+			http.Error(w, "Invalid user ID", http.StatusUnauthorized)
+			return
+		}
+
+		if _, err := h.service.GetUser(r.Context(), id); err != nil {
+			log.Printf("Unknown user in %s header: %v\n", UserHeader, id)
+			http.Error(w, "Unknown user", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), id)))
+	})
+}
+
+// userErrorStatus maps a taken email to 409, a missing user to 404 and other failures to 400
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrEmailTaken):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
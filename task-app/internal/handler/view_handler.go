@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ViewHandler struct {
+	service *service.ViewService
+}
+
+func NewViewHandler(service *service.ViewService) *ViewHandler {
+	return &ViewHandler{service: service}
+}
+
+func (h *ViewHandler) CreateView(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a view")
+
+	var req models.CreateViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	view, err := h.service.CreateView(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating view: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("View created successfully: %v\n", view.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(view)
+}
+
+func (h *ViewHandler) GetView(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a view")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid view ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	view, err := h.service.GetView(r.Context(), id)
+	if err != nil {
+		log.Printf("View not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view)
+}
+
+func (h *ViewHandler) UpdateView(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a view")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid view ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	view := &models.View{
+		ID:      id,
+		Name:    req.Name,
+		Filters: req.Filters,
+		Sort:    req.Sort,
+		Columns: req.Columns,
+	}
+
+	if err := h.service.UpdateView(r.Context(), view); err != nil {
+		log.Printf("Error updating view with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("View updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view)
+}
+
+func (h *ViewHandler) DeleteView(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a view")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid view ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteView(r.Context(), id); err != nil {
+		log.Printf("Error deleting view with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	log.Printf("View deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ViewHandler) ListViews(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list views")
+
+	views, err := h.service.ListViews(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving views: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
+
+func (h *ViewHandler) ListViewTasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list tasks of a view")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid view ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	view, tasks, err := h.service.ExecuteView(r.Context(), id)
+	if err != nil {
+		log.Printf("Error executing view with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), viewErrorStatus(err))
+		return
+	}
+
+	log.Printf("View tasks retrieved successfully: %d tasks found\n", len(tasks))
+
+	writeTasks(w, tasks, view.Columns)
+}
+
+// viewErrorStatus maps a missing view to 404 and saved filters or sort keys that no longer apply to 400
+func viewErrorStatus(err error) int {
+	if errors.Is(err, repository.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type WorkflowHandler struct {
+	service *service.WorkflowService
+}
+
+func NewWorkflowHandler(service *service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{service: service}
+}
+
+func (h *WorkflowHandler) CreateWorkflow(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a workflow")
+
+	var req models.CreateWorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.service.CreateWorkflow(r.Context(), req)
+	if err != nil {
+		log.Printf("Error creating workflow: %v\n", err)
+		http.Error(w, err.Error(), workflowErrorStatus(err))
+		return
+	}
+
+	log.Printf("Workflow created successfully: %v\n", workflow.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(workflow)
+}
+
+func (h *WorkflowHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to get a workflow")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid workflow ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid workflow ID", http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.service.GetWorkflow(r.Context(), id)
+	if err != nil {
+		log.Printf("Workflow not found with ID: %v\n", id)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(workflow)
+}
+
+func (h *WorkflowHandler) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to update a workflow")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid workflow ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid workflow ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateWorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	workflow := &models.WorkflowDefinition{
+		ID:          id,
+		Name:        req.Name,
+		Statuses:    req.Statuses,
+		Terminal:    req.Terminal,
+		Transitions: req.Transitions,
+		Categories:  req.Categories,
+	}
+
+	if err := h.service.UpdateWorkflow(r.Context(), workflow); err != nil {
+		log.Printf("Error updating workflow with ID %v: %v\n", id, err)
+		http.Error(w, err.Error(), workflowErrorStatus(err))
+		return
+	}
+
+	log.Printf("Workflow updated successfully: %v\n", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(workflow)
+}
+
+func (h *WorkflowHandler) DeleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a workflow")
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid workflow ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid workflow ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteWorkflow(r.Context(), id); err != nil {
+		log.Printf("Error deleting workflow with ID %v: %v\n", id, err)
+		status := http.StatusNotFound
+		if errors.Is(err, service.ErrStatusInUse) {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	log.Printf("Workflow deleted successfully: %v\n", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WorkflowHandler) ListWorkflows(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list workflows")
+
+	workflows, err := h.service.ListWorkflows(r.Context())
+	if err != nil {
+		log.Printf("Error retrieving workflows: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(workflows)
+}
+
+// workflowErrorStatus maps category binding conflicts and statuses still in use to 409 and other failures to 400
+func workflowErrorStatus(err error) int {
+	if errors.Is(err, service.ErrCategoryAlreadyBound) || errors.Is(err, service.ErrStatusInUse) {
+		return http.StatusConflict
+	}
+
+	return http.StatusBadRequest
+}
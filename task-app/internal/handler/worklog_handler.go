@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type WorklogHandler struct {
+	service *service.WorklogService
+}
+
+func NewWorklogHandler(service *service.WorklogService) *WorklogHandler {
+	return &WorklogHandler{service: service}
+}
+
+// StartTimer starts a timer for the caller. The body is optional.
+func (h *WorklogHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to start a timer")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.StartTimerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	worklog, err := h.service.StartTimer(r.Context(), taskID, req)
+	if err != nil {
+		log.Printf("Error starting timer on task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), worklogErrorStatus(err))
+		return
+	}
+
+	log.Printf("Timer started successfully: %v\n", worklog.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(worklog)
+}
+
+// StopTimer stops the caller's timer on the task.
+func (h *WorklogHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to stop a timer")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	worklog, err := h.service.StopTimer(r.Context(), taskID)
+	if err != nil {
+		log.Printf("Error stopping timer on task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), worklogErrorStatus(err))
+		return
+	}
+
+	log.Printf("Timer stopped successfully: %v\n", worklog.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(worklog)
+}
+
+func (h *WorklogHandler) CreateWorklog(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to create a worklog")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateWorklogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request body: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	worklog, err := h.service.CreateWorklog(r.Context(), taskID, req)
+	if err != nil {
+		log.Printf("Error creating worklog on task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), worklogErrorStatus(err))
+		return
+	}
+
+	log.Printf("Worklog created successfully: %v\n", worklog.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(worklog)
+}
+
+func (h *WorklogHandler) ListWorklogs(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to list worklogs")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	worklogs, err := h.service.ListWorklogs(r.Context(), taskID)
+	if err != nil {
+		log.Printf("Error listing worklogs of task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), worklogErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(worklogs)
+}
+
+func (h *WorklogHandler) DeleteWorklog(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request to delete a worklog")
+
+	vars := mux.Vars(r)
+	taskID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", vars["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	worklogID, err := uuid.Parse(vars["worklogId"])
+	if err != nil {
+		log.Printf("Invalid worklog ID: %v\n", vars["worklogId"])
+		http.Error(w, "Invalid worklog ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteWorklog(r.Context(), taskID, worklogID); err != nil {
+		log.Printf("Error deleting worklog with ID %v: %v\n", worklogID, err)
+		http.Error(w, err.Error(), worklogErrorStatus(err))
+		return
+	}
+
+	log.Printf("Worklog deleted successfully: %v\n", worklogID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WorklogHandler) TaskTime(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request for the time logged on a task")
+
+	taskID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Invalid task ID: %v\n", mux.Vars(r)["id"])
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	total, err := h.service.TaskTime(r.Context(), taskID)
+	if err != nil {
+		log.Printf("Error totalling time of task %v: %v\n", taskID, err)
+		http.Error(w, err.Error(), worklogErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(total)
+}
+
+// TimeReport totals logged time per task and category, filtered by the from, to, user and category query parameters.
+func (h *WorklogHandler) TimeReport(w http.ResponseWriter, r *http.Request) {
+	log.Println("Received request for a time report")
+
+	query := r.URL.Query()
+
+	from, to, err := service.ParseReportRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		log.Printf("Invalid report range: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.WorklogFilter{From: from, To: to}
+	if value := query.Get("user"); value != "" {
+		userID, err := uuid.Parse(value)
+		if err != nil {
+			log.Printf("Invalid user ID: %v\n", value)
+			http.Error(w, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+		filter.UserID = &userID
+	}
+
+	report, err := h.service.Report(r.Context(), filter, query.Get("category"))
+	if err != nil {
+		log.Printf("Error building time report: %v\n", err)
+		http.Error(w, err.Error(), worklogErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// worklogErrorStatus maps anonymous changes to 401, ownership failures to 403, timer conflicts to 409, missing worklogs or tasks to 404 and other failures to 400
+func worklogErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrWorklogAuthRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrNotWorklogOwner):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrTimerRunning), errors.Is(err, service.ErrWorklogOverlap):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrNoRunningTimer), errors.Is(err, service.ErrWorklogNotFound), errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file uploaded to a task. The content lives in the blob store under its SHA-256 hash,
+// so identical uploads share one blob.
+type Attachment struct {
+	ID          uuid.UUID  `json:"id"`
+	TaskID      uuid.UUID  `json:"task_id"`
+	Filename    string     `json:"filename"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	Hash        string     `json:"hash"`
+	UploadedBy  *uuid.UUID `json:"uploaded_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// AttachmentLimits restricts the size and MIME types of uploaded files.
+type AttachmentLimits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
@@ -0,0 +1,21 @@
+package models
+
+import "github.com/google/uuid"
+
+// ChecklistItem is one line of the checklist embedded in a task. Items are kept sorted by Position.
+type ChecklistItem struct {
+	ID       uuid.UUID `json:"id"`
+	Text     string    `json:"text"`
+	Done     bool      `json:"done"`
+	Position int       `json:"position"`
+}
+
+type AddChecklistItemRequest struct {
+	Text     string `json:"text"`
+	Position *int   `json:"position,omitempty"`
+}
+
+// ReorderChecklistRequest lists every item of a checklist in its new order.
+type ReorderChecklistRequest struct {
+	ItemIDs []uuid.UUID `json:"item_ids"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a message on a task. Comments with a ParentID are replies to another comment on the same task.
+type Comment struct {
+	ID        uuid.UUID     `json:"id"`
+	TaskID    uuid.UUID     `json:"task_id"`
+	ParentID  *uuid.UUID    `json:"parent_id,omitempty"`
+	AuthorID  uuid.UUID     `json:"author_id"`
+	Body      string        `json:"body"`
+	Edits     []CommentEdit `json:"edits,omitempty"`
+	Replies   []Comment     `json:"replies,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// CommentEdit records a previous body of a comment and when it was replaced.
+type CommentEdit struct {
+	Body     string    `json:"body"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+type CreateCommentRequest struct {
+	Body     string     `json:"body"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	AuthorID uuid.UUID  `json:"author_id"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentPage is one page of the top-level comments of a task, each with its replies nested beneath it.
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	Total    int       `json:"total"`
+	Offset   int       `json:"offset"`
+	Limit    int       `json:"limit"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldText   CustomFieldType = "TEXT"
+	CustomFieldNumber CustomFieldType = "NUMBER"
+	CustomFieldDate   CustomFieldType = "DATE"
+	CustomFieldEnum   CustomFieldType = "ENUM"
+	CustomFieldUser   CustomFieldType = "USER"
+)
+
+// CustomFieldPrefix marks custom fields in filter and sort parameters, e.g. cf.severity=high or sort=-cf.severity.
+const CustomFieldPrefix = "cf."
+
+// CustomFieldDateLayout is the format date custom field values are stored in.
+const CustomFieldDateLayout = "2006-01-02"
+
+// CustomFieldDefinition declares a typed field that tasks of a project can carry in their CustomFields.
+// Key and Type cannot change once the field exists.
+type CustomFieldDefinition struct {
+	ID        uuid.UUID       `json:"id"`
+	ProjectID uuid.UUID       `json:"project_id"`
+	Key       string          `json:"key"`
+	Name      string          `json:"name"`
+	Type      CustomFieldType `json:"type"`
+	Options   []string        `json:"options,omitempty"`
+	Required  bool            `json:"required"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type CreateCustomFieldRequest struct {
+	Key      string          `json:"key"`
+	Name     string          `json:"name"`
+	Type     CustomFieldType `json:"type"`
+	Options  []string        `json:"options,omitempty"`
+	Required bool            `json:"required"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "github.com/google/uuid"
+
+type AddDependencyRequest struct {
+	BlockedBy uuid.UUID `json:"blocked_by"`
+}
+
+// ExecutionPlan orders tasks so that every task comes after the tasks blocking it.
+// CriticalPath is the longest chain of dependent tasks in the plan.
+type ExecutionPlan struct {
+	Order        []Task `json:"order"`
+	CriticalPath []Task `json:"critical_path"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// FinishedFunc reports whether a task is in a terminal status of its workflow. The service passes it to the
+// repository under the "finished" filter key, so that overdue and due_within skip finished tasks of every
+// workflow rather than only DONE ones.
+type FinishedFunc func(task *Task) bool
+
+// TimeRange is an inclusive window of time used by relative task filters.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains reports whether t falls within the range.
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.From) && !t.After(r.To)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HistoryAction string
+
+const (
+	HistoryCreated HistoryAction = "CREATED"
+	HistoryUpdated HistoryAction = "UPDATED"
+	HistoryDeleted HistoryAction = "DELETED"
+)
+
+// HistoryEntry records one mutation of a task. Changes holds the JSON values of every field that changed;
+// a created task lists all of its fields with no Before value and a deleted task all of them with no After value.
+type HistoryEntry struct {
+	ID      uuid.UUID     `json:"id"`
+	TaskID  uuid.UUID     `json:"task_id"`
+	Action  HistoryAction `json:"action"`
+	ActorID *uuid.UUID    `json:"actor_id,omitempty"`
+	At      time.Time     `json:"at"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange holds the JSON value of a task field before and after a mutation. A missing value means
+// the field was unset.
+type FieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
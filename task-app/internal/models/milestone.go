@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Milestone marks a target date that tasks contribute to. The task counts are derived and never stored.
+type Milestone struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	DueDate       time.Time `json:"due_date"`
+	TotalTasks    int       `json:"total_tasks"`
+	FinishedTasks int       `json:"finished_tasks"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CreateMilestoneRequest struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+}
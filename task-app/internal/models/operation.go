@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OperationType string
+
+const (
+	OperationCreate      OperationType = "CREATE"
+	OperationUpdate      OperationType = "UPDATE"
+	OperationDelete      OperationType = "DELETE"
+	OperationDuplicate   OperationType = "DUPLICATE"
+	OperationInstantiate OperationType = "INSTANTIATE"
+)
+
+// DefaultOperationLogSize is the number of operations kept per user for undo and redo.
+const DefaultOperationLogSize = 20
+
+// Operation is a create, update, delete, duplicate or template instantiation performed by a user. Changes
+// holds every task the operation wrote, including subtasks and dependents affected along the way, so that
+// it can be undone and redone. UndoneAt is set while the operation is undone.
+type Operation struct {
+	ID       uuid.UUID     `json:"id"`
+	UserID   uuid.UUID     `json:"user_id"`
+	Type     OperationType `json:"type"`
+	TaskID   uuid.UUID     `json:"task_id"`
+	Changes  []TaskChange  `json:"changes"`
+	At       time.Time     `json:"at"`
+	UndoneAt *time.Time    `json:"undone_at,omitempty"`
+}
+
+// TaskChange holds a task before and after an operation. Before is nil for tasks the operation created
+// and After is nil for tasks it deleted. Attached keeps the data of a deleted task, such as its comments,
+// by kind, so that undoing the delete brings it back.
+type TaskChange struct {
+	TaskID   uuid.UUID                  `json:"task_id"`
+	Before   *Task                      `json:"before,omitempty"`
+	After    *Task                      `json:"after,omitempty"`
+	Attached map[string]json.RawMessage `json:"attached,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Project groups tasks. Tasks reference their project by ID, and a task's Category is the current name
+// of its project, so renaming a project renames the category of all of its tasks at once.
+type Project struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	OwnerID         *uuid.UUID `json:"owner_id,omitempty"`
+	DefaultPriority Priority   `json:"default_priority,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type CreateProjectRequest struct {
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	OwnerID         *uuid.UUID `json:"owner_id,omitempty"`
+	DefaultPriority Priority   `json:"default_priority,omitempty"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// RecurrenceTrigger decides when the next occurrence of a recurring task is generated.
+type RecurrenceTrigger string
+
+const (
+	// TriggerCompletion generates the next occurrence when the current one is finished
+	TriggerCompletion RecurrenceTrigger = "completion"
+	// TriggerSchedule generates the next occurrence once the current one is due, finished or not
+	TriggerSchedule RecurrenceTrigger = "schedule"
+)
+
+// Recurrence is an RRULE-style schedule attached to a task. Occurrences are counted from Start,
+// the due date of the first task in the series, so monthly and yearly dates do not drift.
+type Recurrence struct {
+	Frequency  Frequency         `json:"frequency"`
+	Interval   int               `json:"interval"`
+	ByDay      []string          `json:"by_day,omitempty"`
+	Until      *time.Time        `json:"until,omitempty"`
+	Count      int               `json:"count,omitempty"`
+	Trigger    RecurrenceTrigger `json:"trigger"`
+	Start      time.Time         `json:"start"`
+	Occurrence int               `json:"occurrence"`
+	NextID     *uuid.UUID        `json:"next_id,omitempty"`
+}
+
+type SetRecurrenceRequest struct {
+	Frequency Frequency         `json:"frequency"`
+	Interval  int               `json:"interval"`
+	ByDay     []string          `json:"by_day,omitempty"`
+	Until     *time.Time        `json:"until,omitempty"`
+	Count     int               `json:"count,omitempty"`
+	Trigger   RecurrenceTrigger `json:"trigger"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReminderStatus string
+
+const (
+	ReminderPending ReminderStatus = "PENDING"
+	ReminderSent    ReminderStatus = "SENT"
+	ReminderFailed  ReminderStatus = "FAILED"
+	// ReminderCancelled marks reminders of tasks that were finished before the reminder fired
+	ReminderCancelled ReminderStatus = "CANCELLED"
+)
+
+// Reminder notifies about a task either at a fixed time (At) or a number of minutes before the
+// task's due date (OffsetMinutes). FireAt is when it is delivered next; offset reminders follow
+// changes to the due date until they are sent. TaskTitle and DueDate are a snapshot of the task
+// taken when the reminder was last scheduled.
+type Reminder struct {
+	ID            uuid.UUID      `json:"id"`
+	TaskID        uuid.UUID      `json:"task_id"`
+	At            *time.Time     `json:"at,omitempty"`
+	OffsetMinutes *int           `json:"offset_minutes,omitempty"`
+	FireAt        time.Time      `json:"fire_at"`
+	Status        ReminderStatus `json:"status"`
+	Attempts      int            `json:"attempts"`
+	LastError     string         `json:"last_error,omitempty"`
+	SentAt        *time.Time     `json:"sent_at,omitempty"`
+	TaskTitle     string         `json:"task_title"`
+	DueDate       time.Time      `json:"due_date"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type CreateReminderRequest struct {
+	At            *time.Time `json:"at,omitempty"`
+	OffsetMinutes *int       `json:"offset_minutes,omitempty"`
+}
+
+// ReminderNotification is the message handed to a notifier when a reminder fires.
+type ReminderNotification struct {
+	ReminderID uuid.UUID `json:"reminder_id"`
+	TaskID     uuid.UUID `json:"task_id"`
+	TaskTitle  string    `json:"task_title"`
+	DueDate    time.Time `json:"due_date"`
+	FireAt     time.Time `json:"fire_at"`
+	SentAt     time.Time `json:"sent_at"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SprintState string
+
+const (
+	SprintPlanned SprintState = "PLANNED"
+	SprintActive  SprintState = "ACTIVE"
+	SprintClosed  SprintState = "CLOSED"
+)
+
+// DefaultSprintLength is used when a sprint is created without an end date.
+const DefaultSprintLength = 14 * 24 * time.Hour
+
+// Sprint is a time box tasks are planned into. Its State follows from its dates and is never stored.
+type Sprint struct {
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	Goal      string      `json:"goal"`
+	StartDate time.Time   `json:"start_date"`
+	EndDate   time.Time   `json:"end_date"`
+	State     SprintState `json:"state,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type CreateSprintRequest struct {
+	Name      string    `json:"name"`
+	Goal      string    `json:"goal"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// StateAt reports whether the sprint has not started, is running or has ended at the given time.
+func (s *Sprint) StateAt(now time.Time) SprintState {
+	switch {
+	case now.Before(s.StartDate):
+		return SprintPlanned
+	case now.Before(s.EndDate):
+		return SprintActive
+	default:
+		return SprintClosed
+	}
+}
+
+type ScopeChangeType string
+
+const (
+	ScopeAdded   ScopeChangeType = "ADDED"
+	ScopeRemoved ScopeChangeType = "REMOVED"
+)
+
+// SprintScopeChange records a task entering or leaving a sprint, with the size of the task at that moment.
+type SprintScopeChange struct {
+	ID              uuid.UUID       `json:"id"`
+	SprintID        uuid.UUID       `json:"sprint_id"`
+	TaskID          uuid.UUID       `json:"task_id"`
+	TaskTitle       string          `json:"task_title"`
+	Type            ScopeChangeType `json:"type"`
+	StoryPoints     *float64        `json:"story_points,omitempty"`
+	EstimateMinutes *int            `json:"estimate_minutes,omitempty"`
+	At              time.Time       `json:"at"`
+}
+
+// SprintWork sums the tasks, story points and estimated minutes of a set of tasks.
+type SprintWork struct {
+	Tasks           int     `json:"tasks"`
+	StoryPoints     float64 `json:"story_points"`
+	EstimateMinutes int     `json:"estimate_minutes"`
+}
+
+func (w *SprintWork) Add(storyPoints *float64, estimateMinutes *int) {
+	w.Tasks++
+	if storyPoints != nil {
+		w.StoryPoints += *storyPoints
+	}
+	if estimateMinutes != nil {
+		w.EstimateMinutes += *estimateMinutes
+	}
+}
+
+// SprintSummary compares the work committed when a sprint started with the work added, removed and
+// completed since. Remaining counts the remaining estimate of the unfinished tasks.
+type SprintSummary struct {
+	Sprint       Sprint              `json:"sprint"`
+	Committed    SprintWork          `json:"committed"`
+	Added        SprintWork          `json:"added"`
+	Removed      SprintWork          `json:"removed"`
+	Completed    SprintWork          `json:"completed"`
+	Remaining    SprintWork          `json:"remaining"`
+	ScopeChanges []SprintScopeChange `json:"scope_changes"`
+}
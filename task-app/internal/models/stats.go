@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Histogram intervals for due-date bucketing.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// AggregationOptions controls how TaskStats are grouped and bucketed.
+// Now is the reference time used to decide whether a task is overdue.
+type AggregationOptions struct {
+	GroupBy  []string
+	Interval string
+	Now      time.Time
+}
+
+type HistogramBucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+type GroupCount struct {
+	Key       map[string]string `json:"key"`
+	Count     int               `json:"count"`
+	Estimates EstimateTotals    `json:"estimates"`
+}
+
+// EstimateTotals sums the estimates of a set of tasks; Estimated counts the tasks that have any estimate.
+type EstimateTotals struct {
+	OriginalMinutes  int     `json:"original_minutes"`
+	RemainingMinutes int     `json:"remaining_minutes"`
+	StoryPoints      float64 `json:"story_points"`
+	Estimated        int     `json:"estimated"`
+}
+
+// Add includes the estimates of a task in the totals.
+func (e *EstimateTotals) Add(task *Task) {
+	if task.OriginalEstimate == nil && task.RemainingEstimate == nil && task.StoryPoints == nil {
+		return
+	}
+
+	e.Estimated++
+	if task.OriginalEstimate != nil {
+		e.OriginalMinutes += *task.OriginalEstimate
+	}
+	if task.RemainingEstimate != nil {
+		e.RemainingMinutes += *task.RemainingEstimate
+	}
+	if task.StoryPoints != nil {
+		e.StoryPoints += *task.StoryPoints
+	}
+}
+
+type TaskStats struct {
+	Total            int               `json:"total"`
+	Overdue          int               `json:"overdue"`
+	ByStatus         map[Status]int    `json:"by_status"`
+	ByPriority       map[Priority]int  `json:"by_priority"`
+	ByCategory       map[string]int    `json:"by_category"`
+	DueDateHistogram []HistogramBucket `json:"due_date_histogram"`
+	Estimates        EstimateTotals    `json:"estimates"`
+	Groups           []GroupCount      `json:"groups,omitempty"`
+}
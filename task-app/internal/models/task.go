@@ -22,22 +22,140 @@ const (
 )
 
 type Task struct {
-	ID          uuid.UUID `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Category    string    `json:"category"`
-	DueDate     time.Time `json:"due_date"`
-	Priority    Priority  `json:"priority"`
-	Status      Status    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                  uuid.UUID              `json:"id"`
+	Title               string                 `json:"title"`
+	Description         string                 `json:"description"`
+	Category            string                 `json:"category"`
+	ProjectID           *uuid.UUID             `json:"project_id,omitempty"`
+	SprintID            *uuid.UUID             `json:"sprint_id,omitempty"`
+	MilestoneID         *uuid.UUID             `json:"milestone_id,omitempty"`
+	DueDate             time.Time              `json:"due_date"`
+	Priority            Priority               `json:"priority"`
+	Status              Status                 `json:"status"`
+	ParentID            *uuid.UUID             `json:"parent_id,omitempty"`
+	BlockedBy           []uuid.UUID            `json:"blocked_by,omitempty"`
+	Tags                []uuid.UUID            `json:"tags,omitempty"`
+	Assignees           []uuid.UUID            `json:"assignees,omitempty"`
+	ReporterID          *uuid.UUID             `json:"reporter_id,omitempty"`
+	Checklist           []ChecklistItem        `json:"checklist,omitempty"`
+	ChecklistCompletion *float64               `json:"checklist_completion,omitempty"`
+	Recurrence          *Recurrence            `json:"recurrence,omitempty"`
+	OriginalEstimate    *int                   `json:"original_estimate_minutes,omitempty"`
+	RemainingEstimate   *int                   `json:"remaining_estimate_minutes,omitempty"`
+	StoryPoints         *float64               `json:"story_points,omitempty"`
+	CustomFields        map[string]interface{} `json:"custom_fields,omitempty"`
+	Progress            *float64               `json:"progress,omitempty"`
+	AllowedTransitions  []Status               `json:"allowed_transitions,omitempty"`
+	CreatedAt           time.Time              `json:"created_at"`
+	UpdatedAt           time.Time              `json:"updated_at"`
 }
 
 type CreateTaskRequest struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Category    string    `json:"category"`
-	DueDate     time.Time `json:"due_date"`
-	Priority    Priority  `json:"priority"`
-	Status      Status    `json:"status"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Category    string      `json:"category"`
+	ProjectID   *uuid.UUID  `json:"project_id,omitempty"`
+	DueDate     time.Time   `json:"due_date"`
+	Priority    Priority    `json:"priority"`
+	Status      Status      `json:"status"`
+	ParentID    *uuid.UUID  `json:"parent_id,omitempty"`
+	Assignees   []uuid.UUID `json:"assignees,omitempty"`
+	ReporterID  *uuid.UUID  `json:"reporter_id,omitempty"`
+
+	OriginalEstimate  *int     `json:"original_estimate_minutes,omitempty"`
+	RemainingEstimate *int     `json:"remaining_estimate_minutes,omitempty"`
+	StoryPoints       *float64 `json:"story_points,omitempty"`
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// TaskNode is a task together with its nested subtasks.
+type TaskNode struct {
+	Task
+	Children []TaskNode `json:"children"`
+}
+
+// DeleteMode controls what happens to the subtasks of a deleted task.
+type DeleteMode string
+
+const (
+	// DeleteOrphan detaches subtasks so they become top-level tasks
+	DeleteOrphan DeleteMode = "orphan"
+	// DeleteCascade deletes the whole subtree
+	DeleteCascade DeleteMode = "cascade"
+	// DeleteRestrict refuses to delete a task that still has subtasks
+	DeleteRestrict DeleteMode = "restrict"
+)
+
+// HierarchyOptions configures how subtasks are treated when their parent is deleted or duplicated.
+type HierarchyOptions struct {
+	OnDelete          DeleteMode
+	DuplicateChildren bool
+}
+
+// DefaultDuplicateTitlePattern names a duplicated task after its original.
+const DefaultDuplicateTitlePattern = "{{title}} (Copy)"
+
+// DuplicateOptions configures what a duplicated task copies from the original. TitlePattern names the
+// copy, with {{title}} standing for the original title and {{date}} for the new due date. DueDate moves
+// the copy to a new due date and ShiftDueDate moves it by an offset; subtasks copied along move by the
+// same amount. DeepCopy also copies the comments and attachments of every copied task.
+type DuplicateOptions struct {
+	Children      bool
+	KeepAssignees bool
+	KeepStatus    bool
+	TitlePattern  string
+	DueDate       *time.Time
+	ShiftDueDate  time.Duration
+	DeepCopy      bool
+	Overrides     DuplicateOverrides
+}
+
+// DuplicateOverrides replaces fields of the duplicated task, leaving fields that are not set as copied.
+type DuplicateOverrides struct {
+	Description *string   `json:"description,omitempty"`
+	Priority    *Priority `json:"priority,omitempty"`
+}
+
+// DuplicateTaskRequest is the optional body of a duplicate request. Fields that are not set fall back
+// to the configured defaults. ShiftDueDate is an offset such as "+7d", "+2w" or "+12h".
+type DuplicateTaskRequest struct {
+	Children      *bool              `json:"children,omitempty"`
+	KeepAssignees *bool              `json:"keep_assignees,omitempty"`
+	KeepStatus    bool               `json:"keep_status"`
+	TitlePattern  string             `json:"title_pattern,omitempty"`
+	DueDate       *time.Time         `json:"due_date,omitempty"`
+	ShiftDueDate  string             `json:"shift_due_date,omitempty"`
+	DeepCopy      bool               `json:"deep_copy"`
+	Overrides     DuplicateOverrides `json:"overrides"`
+}
+
+// TaskFields lists the JSON field names of a Task in declaration order.
+var TaskFields = []string{
+	"id",
+	"title",
+	"description",
+	"category",
+	"project_id",
+	"sprint_id",
+	"milestone_id",
+	"due_date",
+	"priority",
+	"status",
+	"parent_id",
+	"blocked_by",
+	"tags",
+	"assignees",
+	"reporter_id",
+	"checklist",
+	"checklist_completion",
+	"recurrence",
+	"original_estimate_minutes",
+	"remaining_estimate_minutes",
+	"story_points",
+	"custom_fields",
+	"progress",
+	"allowed_transitions",
+	"created_at",
+	"updated_at",
 }
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskTemplate is a named blueprint for a task and its subtasks. Texts may contain {{name}} placeholders
+// that are filled in from parameters when the template is instantiated; Variables lists them and is
+// derived from the template rather than stored.
+type TaskTemplate struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Task        TemplateTask `json:"task"`
+	Variables   []string     `json:"variables"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// TemplateTask describes a task created from a template. DueIn is the due date relative to the moment
+// the template is instantiated, e.g. "+3d", "+2w" or "+12h". Title, description, category, checklist items
+// and text custom field values may contain placeholders.
+type TemplateTask struct {
+	Title            string                 `json:"title"`
+	Description      string                 `json:"description"`
+	Category         string                 `json:"category"`
+	DueIn            string                 `json:"due_in"`
+	Priority         Priority               `json:"priority"`
+	Assignees        []uuid.UUID            `json:"assignees,omitempty"`
+	Checklist        []string               `json:"checklist,omitempty"`
+	OriginalEstimate *int                   `json:"original_estimate_minutes,omitempty"`
+	StoryPoints      *float64               `json:"story_points,omitempty"`
+	CustomFields     map[string]interface{} `json:"custom_fields,omitempty"`
+	Subtasks         []TemplateTask         `json:"subtasks,omitempty"`
+}
+
+type CreateTemplateRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Task        TemplateTask `json:"task"`
+}
+
+// SaveAsTemplateRequest turns an existing task, and optionally its subtasks, into a template.
+type SaveAsTemplateRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	IncludeSubtasks bool   `json:"include_subtasks"`
+}
+
+// InstantiateTemplateRequest supplies the placeholder values for a template. Due dates are relative to
+// Start, which defaults to now. The created task becomes a subtask of ParentID when it is set.
+type InstantiateTemplateRequest struct {
+	Params   map[string]string `json:"params"`
+	Start    *time.Time        `json:"start,omitempty"`
+	ParentID *uuid.UUID        `json:"parent_id,omitempty"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// View is a named, server-side saved combination of task filters, sort order and columns.
+type View struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      string            `json:"name"`
+	Filters   map[string]string `json:"filters"`
+	Sort      string            `json:"sort"`
+	Columns   []string          `json:"columns"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type CreateViewRequest struct {
+	Name    string            `json:"name"`
+	Filters map[string]string `json:"filters"`
+	Sort    string            `json:"sort"`
+	Columns []string          `json:"columns"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatusTransition struct {
+	From Status `json:"from"`
+	To   Status `json:"to"`
+}
+
+// WorkflowDefinition describes a custom set of statuses and transitions bound to one or more categories.
+// Statuses are ordered, the first being the initial status; terminal statuses count as finished.
+type WorkflowDefinition struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	Statuses    []Status           `json:"statuses"`
+	Terminal    []Status           `json:"terminal"`
+	Transitions []StatusTransition `json:"transitions"`
+	Categories  []string           `json:"categories"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type CreateWorkflowRequest struct {
+	Name        string             `json:"name"`
+	Statuses    []Status           `json:"statuses"`
+	Terminal    []Status           `json:"terminal"`
+	Transitions []StatusTransition `json:"transitions"`
+	Categories  []string           `json:"categories"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Worklog is time a user spent on a task. Worklogs without an End are running timers.
+type Worklog struct {
+	ID              uuid.UUID  `json:"id"`
+	TaskID          uuid.UUID  `json:"task_id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	Start           time.Time  `json:"start"`
+	End             *time.Time `json:"end,omitempty"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Note            string     `json:"note,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Running reports whether the worklog is a timer that has not been stopped.
+func (w *Worklog) Running() bool {
+	return w.End == nil
+}
+
+// StartTimerRequest starts tracking the time of the authenticated user.
+type StartTimerRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+type CreateWorklogRequest struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Note  string    `json:"note,omitempty"`
+}
+
+// WorklogFilter selects worklogs overlapping [From, To). Zero values do not filter.
+type WorklogFilter struct {
+	TaskID *uuid.UUID
+	UserID *uuid.UUID
+	From   time.Time
+	To     time.Time
+}
+
+// TaskTime is the total time logged on a task.
+type TaskTime struct {
+	TaskID       uuid.UUID `json:"task_id"`
+	Title        string    `json:"title,omitempty"`
+	Category     string    `json:"category,omitempty"`
+	TotalSeconds int64     `json:"total_seconds"`
+	Running      int       `json:"running_timers"`
+}
+
+type CategoryTime struct {
+	Category     string `json:"category"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
+
+// TimeReport totals the time logged within a date range. Worklogs crossing the range boundaries
+// only count with the part inside the range, and running timers count up to the time of the report.
+type TimeReport struct {
+	From         *time.Time     `json:"from,omitempty"`
+	To           *time.Time     `json:"to,omitempty"`
+	TotalSeconds int64          `json:"total_seconds"`
+	Tasks        []TaskTime     `json:"tasks"`
+	Categories   []CategoryTime `json:"categories"`
+}
@@ -0,0 +1,98 @@
+// Package notify delivers reminder notifications to pluggable sinks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+)
+
+// Notifier delivers a reminder notification. Returning an error makes the scheduler retry later,
+// so a notification may be delivered more than once.
+type Notifier interface {
+	Notify(ctx context.Context, notification models.ReminderNotification) error
+}
+
+// LogNotifier writes each notification as a line of JSON, to a log file or standard output.
+type LogNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewLogNotifier(w io.Writer) *LogNotifier {
+	return &LogNotifier{w: w}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification models.ReminderNotification) error {
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err = n.w.Write(append(line, '\n'))
+	return err
+}
+
+// WebhookNotifier POSTs each notification as JSON to a URL. Any response other than 2xx is a failure.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification models.ReminderNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Multi delivers to every notifier and fails when any of them fails.
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, notification models.ReminderNotification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
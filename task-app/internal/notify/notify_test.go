@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifiers(t *testing.T) {
+	ctx := context.Background()
+	notification := models.ReminderNotification{ReminderID: uuid.New(), TaskID: uuid.New(), TaskTitle: "Ship it"}
+
+	var received models.ReminderNotification
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	notifier := Multi{NewLogNotifier(&buf), NewWebhookNotifier(server.URL)}
+
+	assert.NoError(t, notifier.Notify(ctx, notification))
+	assert.Equal(t, notification.ReminderID, received.ReminderID)
+	assert.Contains(t, buf.String(), `"task_title":"Ship it"`)
+	assert.True(t, strings.HasSuffix(buf.String(), "\n"))
+
+	status = http.StatusInternalServerError
+	assert.Error(t, notifier.Notify(ctx, notification))
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"task-app/internal/models"
+)
+
+func (r *InMemoryTaskRepository) Aggregate(ctx context.Context, filters map[string]interface{}, opts models.AggregationOptions) (*models.TaskStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	stats := &models.TaskStats{
+		ByStatus:   make(map[models.Status]int),
+		ByPriority: make(map[models.Priority]int),
+		ByCategory: make(map[string]int),
+	}
+	buckets := make(map[time.Time]int)
+	groups := make(map[string]*models.GroupCount)
+
+	for _, stored := range r.tasks {
+		task := r.resolve(stored)
+		if !matchesFilters(task, filters) {
+			continue
+		}
+
+		stats.Total++
+		stats.ByStatus[task.Status]++
+		stats.ByPriority[task.Priority]++
+		stats.ByCategory[task.Category]++
+		stats.Estimates.Add(task)
+
+		if !isFinished(task, filters) && task.DueDate.Before(now) {
+			stats.Overdue++
+		}
+
+		if !task.DueDate.IsZero() {
+			buckets[bucketStart(task.DueDate, opts.Interval)]++
+		}
+
+		if len(opts.GroupBy) > 0 {
+			key, values := groupKey(task, opts.GroupBy)
+			group, exists := groups[key]
+			if !exists {
+				group = &models.GroupCount{Key: values}
+				groups[key] = group
+			}
+			group.Count++
+			group.Estimates.Add(task)
+		}
+	}
+
+	stats.DueDateHistogram = make([]models.HistogramBucket, 0, len(buckets))
+	for start, count := range buckets {
+		stats.DueDateHistogram = append(stats.DueDateHistogram, models.HistogramBucket{Start: start, Count: count})
+	}
+	sort.Slice(stats.DueDateHistogram, func(i, j int) bool {
+		return stats.DueDateHistogram[i].Start.Before(stats.DueDateHistogram[j].Start)
+	})
+
+	groupKeys := make([]string, 0, len(groups))
+	for key := range groups {
+		groupKeys = append(groupKeys, key)
+	}
+	sort.Strings(groupKeys)
+	for _, key := range groupKeys {
+		stats.Groups = append(stats.Groups, *groups[key])
+	}
+
+	log.Printf("Aggregated tasks with filters: %+v, Total: %d, Overdue: %d", filters, stats.Total, stats.Overdue)
+
+	return stats, nil
+}
+
+// bucketStart truncates t to the start of its day, ISO week or month in UTC
+func bucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch interval {
+	case models.IntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case models.IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// groupKey returns a stable composite key and the per-field values for the group-by fields of a task
+func groupKey(task *models.Task, fields []string) (string, map[string]string) {
+	values := make(map[string]string, len(fields))
+	parts := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		var value string
+		switch field {
+		case "status":
+			value = string(task.Status)
+		case "priority":
+			value = string(task.Priority)
+		case "category":
+			value = task.Category
+		}
+		values[field] = value
+		parts = append(parts, value)
+	}
+
+	return strings.Join(parts, "\x00"), values
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	ctx := context.Background()
+	now := time.Date(2030, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	tasks := []*models.Task{
+		{Title: "Overdue", Category: "Ops", DueDate: now.Add(-48 * time.Hour), Priority: models.PriorityHigh, Status: models.StatusToDo},
+		{Title: "Done late", Category: "Ops", DueDate: now.Add(-48 * time.Hour), Priority: models.PriorityLow, Status: models.StatusDone},
+		{Title: "Upcoming", Category: "Dev", DueDate: now.Add(24 * time.Hour), Priority: models.PriorityHigh, Status: models.StatusInProgress},
+	}
+	for _, task := range tasks {
+		_ = repo.Create(ctx, task)
+	}
+
+	tests := []struct {
+		name          string
+		filters       map[string]interface{}
+		opts          models.AggregationOptions
+		expectTotal   int
+		expectOverdue int
+		expectGroups  int
+		expectBuckets int
+	}{
+		{
+			name:          "All Tasks By Day",
+			filters:       map[string]interface{}{},
+			opts:          models.AggregationOptions{Now: now},
+			expectTotal:   3,
+			expectOverdue: 1,
+			expectBuckets: 2,
+		},
+		{
+			name:          "Grouped By Category And Priority",
+			filters:       map[string]interface{}{},
+			opts:          models.AggregationOptions{Now: now, GroupBy: []string{"category", "priority"}, Interval: models.IntervalMonth},
+			expectTotal:   3,
+			expectOverdue: 1,
+			expectGroups:  3,
+			expectBuckets: 1,
+		},
+		{
+			name:          "Filtered By Category",
+			filters:       map[string]interface{}{"category": "Ops"},
+			opts:          models.AggregationOptions{Now: now, GroupBy: []string{"category"}},
+			expectTotal:   2,
+			expectOverdue: 1,
+			expectGroups:  1,
+			expectBuckets: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats, err := repo.Aggregate(ctx, test.filters, test.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectTotal, stats.Total)
+			assert.Equal(t, test.expectOverdue, stats.Overdue)
+			assert.Len(t, stats.Groups, test.expectGroups)
+			assert.Len(t, stats.DueDateHistogram, test.expectBuckets)
+		})
+	}
+}
+
+func TestAggregateEstimates(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	ctx := context.Background()
+
+	minutes := func(value int) *int { return &value }
+	points := func(value float64) *float64 { return &value }
+
+	tasks := []*models.Task{
+		{Title: "Login", Category: "Dev", Status: models.StatusToDo, OriginalEstimate: minutes(120), RemainingEstimate: minutes(90), StoryPoints: points(3)},
+		{Title: "Signup", Category: "Dev", Status: models.StatusDone, OriginalEstimate: minutes(60), RemainingEstimate: minutes(0), StoryPoints: points(1.5)},
+		{Title: "Backups", Category: "Ops", Status: models.StatusToDo, StoryPoints: points(2)},
+		{Title: "Unestimated", Category: "Ops", Status: models.StatusToDo},
+	}
+	for _, task := range tasks {
+		_ = repo.Create(ctx, task)
+	}
+
+	stats, err := repo.Aggregate(ctx, map[string]interface{}{}, models.AggregationOptions{GroupBy: []string{"category"}})
+	assert.NoError(t, err)
+	assert.Equal(t, models.EstimateTotals{OriginalMinutes: 180, RemainingMinutes: 90, StoryPoints: 6.5, Estimated: 3}, stats.Estimates)
+
+	assert.Len(t, stats.Groups, 2)
+	assert.Equal(t, "Dev", stats.Groups[0].Key["category"])
+	assert.Equal(t, models.EstimateTotals{OriginalMinutes: 180, RemainingMinutes: 90, StoryPoints: 4.5, Estimated: 2}, stats.Groups[0].Estimates)
+	assert.Equal(t, models.EstimateTotals{StoryPoints: 2, Estimated: 1}, stats.Groups[1].Estimates)
+}
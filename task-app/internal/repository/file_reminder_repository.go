@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// FileReminderRepository keeps reminders in memory and writes all of them to a JSON file after
+// every change, so pending reminders survive a restart. The file is replaced atomically.
+type FileReminderRepository struct {
+	mu        sync.RWMutex
+	path      string
+	reminders map[uuid.UUID]*models.Reminder
+}
+
+// NewFileReminderRepository loads the reminders stored at path, starting empty when the file does not exist yet.
+func NewFileReminderRepository(path string) (*FileReminderRepository, error) {
+	r := &FileReminderRepository{
+		path:      path,
+		reminders: make(map[uuid.UUID]*models.Reminder),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read reminders: %w", err)
+	}
+
+	var stored []models.Reminder
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("decode reminders: %w", err)
+	}
+	for i := range stored {
+		r.reminders[stored[i].ID] = &stored[i]
+	}
+
+	log.Printf("Loaded %d reminders from %s", len(stored), path)
+
+	return r, nil
+}
+
+func (r *FileReminderRepository) Create(ctx context.Context, reminder *models.Reminder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reminder.ID = uuid.New()
+	reminder.CreatedAt = time.Now()
+
+	stored := *reminder
+	r.reminders[reminder.ID] = &stored
+	if err := r.save(); err != nil {
+		delete(r.reminders, reminder.ID)
+		return err
+	}
+
+	log.Printf("Created reminder: ID=%s, TaskID=%s, FireAt=%s", reminder.ID, reminder.TaskID, reminder.FireAt.Format(time.RFC3339))
+
+	return nil
+}
+
+func (r *FileReminderRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Reminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reminder, exists := r.reminders[id]
+	if !exists {
+		log.Printf("Reminder not found: ID=%s", id)
+
+		return nil, fmt.Errorf("reminder %w", ErrNotFound)
+	}
+
+	found := *reminder
+	return &found, nil
+}
+
+func (r *FileReminderRepository) Update(ctx context.Context, reminder *models.Reminder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.reminders[reminder.ID]
+	if !exists {
+		return fmt.Errorf("reminder %w", ErrNotFound)
+	}
+
+	stored := *reminder
+	r.reminders[reminder.ID] = &stored
+	if err := r.save(); err != nil {
+		r.reminders[reminder.ID] = previous
+		return err
+	}
+
+	log.Printf("Updated reminder: ID=%s, Status=%s", reminder.ID, reminder.Status)
+
+	return nil
+}
+
+func (r *FileReminderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.reminders[id]
+	if !exists {
+		return fmt.Errorf("reminder %w", ErrNotFound)
+	}
+
+	delete(r.reminders, id)
+	if err := r.save(); err != nil {
+		r.reminders[id] = previous
+		return err
+	}
+
+	log.Printf("Deleted reminder: ID=%s", id)
+
+	return nil
+}
+
+// ListByTask returns the reminders of a task ordered by when they fire.
+func (r *FileReminderRepository) ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.Reminder, error) {
+	return r.list(func(reminder *models.Reminder) bool { return reminder.TaskID == taskID }), nil
+}
+
+// List returns every reminder ordered by when they fire.
+func (r *FileReminderRepository) List(ctx context.Context) ([]models.Reminder, error) {
+	return r.list(func(reminder *models.Reminder) bool { return true }), nil
+}
+
+// ListPending returns every reminder that has not been sent or given up on, ordered by when they fire.
+func (r *FileReminderRepository) ListPending(ctx context.Context) ([]models.Reminder, error) {
+	return r.list(func(reminder *models.Reminder) bool { return reminder.Status == models.ReminderPending }), nil
+}
+
+func (r *FileReminderRepository) DeleteByTask(ctx context.Context, taskID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := make(map[uuid.UUID]*models.Reminder)
+	for id, reminder := range r.reminders {
+		if reminder.TaskID == taskID {
+			removed[id] = reminder
+			delete(r.reminders, id)
+		}
+	}
+	if len(removed) == 0 {
+		return 0, nil
+	}
+
+	if err := r.save(); err != nil {
+		for id, reminder := range removed {
+			r.reminders[id] = reminder
+		}
+		return 0, err
+	}
+
+	log.Printf("Deleted %d reminders of task: TaskID=%s", len(removed), taskID)
+
+	return len(removed), nil
+}
+
+func (r *FileReminderRepository) list(match func(*models.Reminder) bool) []models.Reminder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reminders := make([]models.Reminder, 0)
+	for _, reminder := range r.reminders {
+		if match(reminder) {
+			reminders = append(reminders, *reminder)
+		}
+	}
+
+	sortReminders(reminders)
+
+	return reminders
+}
+
+// save writes all reminders to a temporary file next to the target and renames it into place
+func (r *FileReminderRepository) save() error {
+	reminders := make([]models.Reminder, 0, len(r.reminders))
+	for _, reminder := range r.reminders {
+		reminders = append(reminders, *reminder)
+	}
+	sortReminders(reminders)
+
+	data, err := json.MarshalIndent(reminders, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), "reminders-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		log.Printf("Failed to save reminders: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func sortReminders(reminders []models.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].FireAt.Equal(reminders[j].FireAt) {
+			return reminders[i].FireAt.Before(reminders[j].FireAt)
+		}
+		return reminders[i].CreatedAt.Before(reminders[j].CreatedAt)
+	})
+}
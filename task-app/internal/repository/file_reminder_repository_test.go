@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileReminderRepository(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "reminders", "reminders.json")
+
+	repo, err := NewFileReminderRepository(path)
+	assert.NoError(t, err)
+
+	taskID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+	later := &models.Reminder{TaskID: taskID, FireAt: now.Add(2 * time.Hour), Status: models.ReminderPending}
+	sooner := &models.Reminder{TaskID: taskID, FireAt: now.Add(time.Hour), Status: models.ReminderPending}
+	other := &models.Reminder{TaskID: uuid.New(), FireAt: now, Status: models.ReminderPending}
+	for _, reminder := range []*models.Reminder{later, sooner, other} {
+		assert.NoError(t, repo.Create(ctx, reminder))
+	}
+
+	sent := *other
+	sent.Status = models.ReminderSent
+	assert.NoError(t, repo.Update(ctx, &sent))
+
+	// A new repository on the same file sees every change
+	reloaded, err := NewFileReminderRepository(path)
+	assert.NoError(t, err)
+
+	byTask, err := reloaded.ListByTask(ctx, taskID)
+	assert.NoError(t, err)
+	assert.Len(t, byTask, 2)
+	assert.Equal(t, sooner.ID, byTask[0].ID)
+	assert.True(t, byTask[0].FireAt.Equal(sooner.FireAt))
+
+	pending, err := reloaded.ListPending(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	stored, err := reloaded.GetByID(ctx, other.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReminderSent, stored.Status)
+
+	// Changing a returned reminder does not change the stored one
+	stored.Status = models.ReminderFailed
+	again, _ := reloaded.GetByID(ctx, other.ID)
+	assert.Equal(t, models.ReminderSent, again.Status)
+
+	removed, err := reloaded.DeleteByTask(ctx, taskID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.NoError(t, reloaded.Delete(ctx, other.ID))
+	assert.Error(t, reloaded.Delete(ctx, other.ID))
+
+	final, err := NewFileReminderRepository(path)
+	assert.NoError(t, err)
+	pending, _ = final.ListPending(ctx)
+	assert.Empty(t, pending)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryAttachmentRepository struct {
+	mu          sync.RWMutex
+	attachments map[uuid.UUID]*models.Attachment
+}
+
+func NewInMemoryAttachmentRepository() *InMemoryAttachmentRepository {
+	return &InMemoryAttachmentRepository{
+		attachments: make(map[uuid.UUID]*models.Attachment),
+	}
+}
+
+func (r *InMemoryAttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachment.ID = uuid.New()
+	attachment.CreatedAt = time.Now()
+	r.attachments[attachment.ID] = attachment
+
+	log.Printf("Created attachment: ID=%s, TaskID=%s", attachment.ID, attachment.TaskID)
+
+	return nil
+}
+
+func (r *InMemoryAttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attachment, exists := r.attachments[id]
+	if !exists {
+		log.Printf("Attachment not found: ID=%s", id)
+
+		return nil, fmt.Errorf("attachment %w", ErrNotFound)
+	}
+
+	log.Printf("Retrieved attachment: ID=%s, TaskID=%s", attachment.ID, attachment.TaskID)
+
+	return attachment, nil
+}
+
+func (r *InMemoryAttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.attachments[id]; !exists {
+		log.Printf("Attachment not found for deletion: ID=%s", id)
+		return fmt.Errorf("attachment %w", ErrNotFound)
+	}
+
+	delete(r.attachments, id)
+
+	log.Printf("Deleted attachment: ID=%s", id)
+
+	return nil
+}
+
+// ListByTask returns the attachments of a task, oldest first
+func (r *InMemoryAttachmentRepository) ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attachments := []models.Attachment{}
+	for _, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			attachments = append(attachments, *attachment)
+		}
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+
+	log.Printf("Listed attachments: TaskID=%s, Found: %d attachments", taskID, len(attachments))
+
+	return attachments, nil
+}
+
+// DeleteByTask removes the attachments of a task and returns them, so their blobs can be collected
+func (r *InMemoryAttachmentRepository) DeleteByTask(ctx context.Context, taskID uuid.UUID) ([]models.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted []models.Attachment
+	for id, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			deleted = append(deleted, *attachment)
+			delete(r.attachments, id)
+		}
+	}
+
+	log.Printf("Deleted attachments of task: TaskID=%s, Removed: %d attachments", taskID, len(deleted))
+
+	return deleted, nil
+}
+
+// ReferencedHashes returns the set of blob hashes used by at least one attachment
+func (r *InMemoryAttachmentRepository) ReferencedHashes(ctx context.Context) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hashes := make(map[string]bool)
+	for _, attachment := range r.attachments {
+		hashes[attachment.Hash] = true
+	}
+
+	return hashes, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryCommentRepository struct {
+	mu       sync.RWMutex
+	comments map[uuid.UUID]*models.Comment
+}
+
+func NewInMemoryCommentRepository() *InMemoryCommentRepository {
+	return &InMemoryCommentRepository{
+		comments: make(map[uuid.UUID]*models.Comment),
+	}
+}
+
+func (r *InMemoryCommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comment.ID = uuid.New()
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = time.Now()
+	r.comments[comment.ID] = comment
+
+	log.Printf("Created comment: ID=%s, TaskID=%s", comment.ID, comment.TaskID)
+
+	return nil
+}
+
+func (r *InMemoryCommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, exists := r.comments[id]
+	if !exists {
+		log.Printf("Comment not found: ID=%s", id)
+
+		return nil, fmt.Errorf("comment %w", ErrNotFound)
+	}
+
+	log.Printf("Retrieved comment: ID=%s, TaskID=%s", comment.ID, comment.TaskID)
+
+	return comment, nil
+}
+
+func (r *InMemoryCommentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.comments[comment.ID]
+	if !exists {
+		log.Printf("Comment not found for update: ID=%s", comment.ID)
+		return fmt.Errorf("comment %w", ErrNotFound)
+	}
+
+	comment.CreatedAt = existing.CreatedAt
+	comment.UpdatedAt = time.Now()
+	r.comments[comment.ID] = comment
+
+	log.Printf("Updated comment: ID=%s, TaskID=%s", comment.ID, comment.TaskID)
+
+	return nil
+}
+
+// Delete removes a comment together with all replies beneath it
+func (r *InMemoryCommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.comments[id]; !exists {
+		log.Printf("Comment not found for deletion: ID=%s", id)
+		return fmt.Errorf("comment %w", ErrNotFound)
+	}
+
+	ids := []uuid.UUID{id}
+	for i := 0; i < len(ids); i++ {
+		for _, comment := range r.comments {
+			if comment.ParentID != nil && *comment.ParentID == ids[i] {
+				ids = append(ids, comment.ID)
+			}
+		}
+	}
+	for _, commentID := range ids {
+		delete(r.comments, commentID)
+	}
+
+	log.Printf("Deleted comment: ID=%s, Removed: %d comments", id, len(ids))
+
+	return nil
+}
+
+// ListByTask returns every comment on a task, replies included, oldest first
+func (r *InMemoryCommentRepository) ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var comments []models.Comment
+	for _, comment := range r.comments {
+		if comment.TaskID == taskID {
+			comments = append(comments, *comment)
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+
+	log.Printf("Listed comments: TaskID=%s, Found: %d comments", taskID, len(comments))
+
+	return comments, nil
+}
+
+func (r *InMemoryCommentRepository) DeleteByTask(ctx context.Context, taskID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, comment := range r.comments {
+		if comment.TaskID == taskID {
+			delete(r.comments, id)
+			deleted++
+		}
+	}
+
+	log.Printf("Deleted comments of task: TaskID=%s, Removed: %d comments", taskID, deleted)
+
+	return deleted, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentOperations(t *testing.T) {
+	repo := NewInMemoryCommentRepository()
+	ctx := context.Background()
+	taskID := uuid.New()
+
+	comment := &models.Comment{TaskID: taskID, AuthorID: uuid.New(), Body: "First"}
+	assert.NoError(t, repo.Create(ctx, comment))
+	assert.NotEqual(t, uuid.Nil, comment.ID)
+
+	reply := &models.Comment{TaskID: taskID, ParentID: &comment.ID, AuthorID: uuid.New(), Body: "Reply"}
+	assert.NoError(t, repo.Create(ctx, reply))
+	other := &models.Comment{TaskID: uuid.New(), AuthorID: uuid.New(), Body: "Elsewhere"}
+	assert.NoError(t, repo.Create(ctx, other))
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		hasError bool
+	}{
+		{
+			name:     "Get Existing Comment",
+			id:       comment.ID,
+			hasError: false,
+		},
+		{
+			name:     "Get Non-existent Comment",
+			id:       uuid.New(),
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := repo.GetByID(ctx, test.id)
+			if test.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, comment.Body, result.Body)
+			}
+		})
+	}
+
+	comments, err := repo.ListByTask(ctx, taskID)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+
+	// Deleting a comment removes its replies
+	assert.NoError(t, repo.Delete(ctx, comment.ID))
+	_, err = repo.GetByID(ctx, reply.ID)
+	assert.Error(t, err)
+
+	deleted, err := repo.DeleteByTask(ctx, other.TaskID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
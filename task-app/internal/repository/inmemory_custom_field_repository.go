@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryCustomFieldRepository struct {
+	mu     sync.RWMutex
+	fields map[uuid.UUID]*models.CustomFieldDefinition
+}
+
+func NewInMemoryCustomFieldRepository() *InMemoryCustomFieldRepository {
+	return &InMemoryCustomFieldRepository{
+		fields: make(map[uuid.UUID]*models.CustomFieldDefinition),
+	}
+}
+
+func (r *InMemoryCustomFieldRepository) Create(ctx context.Context, def *models.CustomFieldDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	def.ID = uuid.New()
+	def.CreatedAt = time.Now()
+	def.UpdatedAt = time.Now()
+
+	r.fields[def.ID] = copyDefinition(def)
+
+	log.Printf("Created custom field: ID=%s, ProjectID=%s, Key=%s", def.ID, def.ProjectID, def.Key)
+
+	return nil
+}
+
+func (r *InMemoryCustomFieldRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CustomFieldDefinition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, exists := r.fields[id]
+	if !exists {
+		log.Printf("Custom field not found: ID=%s", id)
+
+		return nil, fmt.Errorf("custom field %w", ErrNotFound)
+	}
+
+	return copyDefinition(def), nil
+}
+
+func (r *InMemoryCustomFieldRepository) Update(ctx context.Context, def *models.CustomFieldDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.fields[def.ID]
+	if !exists {
+		log.Printf("Custom field not found for update: ID=%s", def.ID)
+		return fmt.Errorf("custom field %w", ErrNotFound)
+	}
+
+	def.CreatedAt = existing.CreatedAt
+	def.UpdatedAt = time.Now()
+
+	r.fields[def.ID] = copyDefinition(def)
+
+	log.Printf("Updated custom field: ID=%s, Key=%s", def.ID, def.Key)
+
+	return nil
+}
+
+func (r *InMemoryCustomFieldRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.fields[id]; !exists {
+		log.Printf("Custom field not found for deletion: ID=%s", id)
+		return fmt.Errorf("custom field %w", ErrNotFound)
+	}
+
+	delete(r.fields, id)
+
+	log.Printf("Deleted custom field: ID=%s", id)
+
+	return nil
+}
+
+// ListByProject returns the custom fields of a project ordered by key
+func (r *InMemoryCustomFieldRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]models.CustomFieldDefinition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]models.CustomFieldDefinition, 0)
+	for _, def := range r.fields {
+		if def.ProjectID == projectID {
+			defs = append(defs, *copyDefinition(def))
+		}
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Key < defs[j].Key
+	})
+
+	return defs, nil
+}
+
+func copyDefinition(def *models.CustomFieldDefinition) *models.CustomFieldDefinition {
+	copied := *def
+	copied.Options = append([]string(nil), def.Options...)
+
+	return &copied
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryHistoryRepository keeps the history of every task in the order it was recorded. History
+// outlives the task it belongs to.
+type InMemoryHistoryRepository struct {
+	mu      sync.RWMutex
+	entries map[uuid.UUID][]models.HistoryEntry
+}
+
+func NewInMemoryHistoryRepository() *InMemoryHistoryRepository {
+	return &InMemoryHistoryRepository{
+		entries: make(map[uuid.UUID][]models.HistoryEntry),
+	}
+}
+
+func (r *InMemoryHistoryRepository) Append(ctx context.Context, entry *models.HistoryEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = uuid.New()
+	r.entries[entry.TaskID] = append(r.entries[entry.TaskID], *entry)
+
+	log.Printf("Recorded history entry: ID=%s, TaskID=%s, Action=%s, Changes=%d", entry.ID, entry.TaskID, entry.Action, len(entry.Changes))
+
+	return nil
+}
+
+// ListByTask returns the history of a task, oldest first
+func (r *InMemoryHistoryRepository) ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.HistoryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]models.HistoryEntry, len(r.entries[taskID]))
+	copy(entries, r.entries[taskID])
+
+	log.Printf("Listed history: TaskID=%s, Found: %d entries", taskID, len(entries))
+
+	return entries, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryMilestoneRepository struct {
+	mu         sync.RWMutex
+	milestones map[uuid.UUID]*models.Milestone
+}
+
+func NewInMemoryMilestoneRepository() *InMemoryMilestoneRepository {
+	return &InMemoryMilestoneRepository{
+		milestones: make(map[uuid.UUID]*models.Milestone),
+	}
+}
+
+func (r *InMemoryMilestoneRepository) Create(ctx context.Context, milestone *models.Milestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	milestone.ID = uuid.New()
+	milestone.CreatedAt = time.Now()
+	milestone.UpdatedAt = time.Now()
+
+	stored := *milestone
+	r.milestones[milestone.ID] = &stored
+
+	log.Printf("Created milestone: ID=%s, Name=%s", milestone.ID, milestone.Name)
+
+	return nil
+}
+
+func (r *InMemoryMilestoneRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Milestone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	milestone, exists := r.milestones[id]
+	if !exists {
+		log.Printf("Milestone not found: ID=%s", id)
+
+		return nil, fmt.Errorf("milestone %w", ErrNotFound)
+	}
+
+	found := *milestone
+	return &found, nil
+}
+
+func (r *InMemoryMilestoneRepository) Update(ctx context.Context, milestone *models.Milestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.milestones[milestone.ID]
+	if !exists {
+		log.Printf("Milestone not found for update: ID=%s", milestone.ID)
+		return fmt.Errorf("milestone %w", ErrNotFound)
+	}
+
+	milestone.CreatedAt = existing.CreatedAt
+	milestone.UpdatedAt = time.Now()
+
+	stored := *milestone
+	r.milestones[milestone.ID] = &stored
+
+	log.Printf("Updated milestone: ID=%s, Name=%s", milestone.ID, milestone.Name)
+
+	return nil
+}
+
+func (r *InMemoryMilestoneRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.milestones[id]; !exists {
+		log.Printf("Milestone not found for deletion: ID=%s", id)
+		return fmt.Errorf("milestone %w", ErrNotFound)
+	}
+
+	delete(r.milestones, id)
+
+	log.Printf("Deleted milestone: ID=%s", id)
+
+	return nil
+}
+
+// List returns all milestones ordered by due date
+func (r *InMemoryMilestoneRepository) List(ctx context.Context) ([]models.Milestone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	milestones := make([]models.Milestone, 0, len(r.milestones))
+	for _, milestone := range r.milestones {
+		milestones = append(milestones, *milestone)
+	}
+
+	sort.Slice(milestones, func(i, j int) bool {
+		return milestones[i].DueDate.Before(milestones[j].DueDate)
+	})
+
+	log.Printf("Listed milestones: Found: %d milestones", len(milestones))
+
+	return milestones, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryOperationRepository keeps the operation log of every user in the order operations were performed.
+type InMemoryOperationRepository struct {
+	mu         sync.RWMutex
+	operations map[uuid.UUID][]models.Operation
+}
+
+func NewInMemoryOperationRepository() *InMemoryOperationRepository {
+	return &InMemoryOperationRepository{
+		operations: make(map[uuid.UUID][]models.Operation),
+	}
+}
+
+func (r *InMemoryOperationRepository) Create(ctx context.Context, operation *models.Operation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	operation.ID = uuid.New()
+	r.operations[operation.UserID] = append(r.operations[operation.UserID], *operation)
+
+	log.Printf("Logged operation: ID=%s, UserID=%s, Type=%s, Changes=%d", operation.ID, operation.UserID, operation.Type, len(operation.Changes))
+
+	return nil
+}
+
+func (r *InMemoryOperationRepository) Update(ctx context.Context, operation *models.Operation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	operations := r.operations[operation.UserID]
+	for i := range operations {
+		if operations[i].ID == operation.ID {
+			operations[i] = *operation
+			log.Printf("Updated operation: ID=%s", operation.ID)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("operation %w", ErrNotFound)
+}
+
+func (r *InMemoryOperationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for userID, operations := range r.operations {
+		for i := range operations {
+			if operations[i].ID == id {
+				r.operations[userID] = append(operations[:i:i], operations[i+1:]...)
+				log.Printf("Deleted operation: ID=%s", id)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("operation %w", ErrNotFound)
+}
+
+// ListByUser returns the operations of a user, oldest first
+func (r *InMemoryOperationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Operation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	operations := make([]models.Operation, len(r.operations[userID]))
+	copy(operations, r.operations[userID])
+
+	return operations, nil
+}
+
+// List returns the operations of all users
+func (r *InMemoryOperationRepository) List(ctx context.Context) ([]models.Operation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var operations []models.Operation
+	for _, logged := range r.operations {
+		operations = append(operations, logged...)
+	}
+
+	return operations, nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryProjectRepository struct {
+	mu       sync.RWMutex
+	projects map[uuid.UUID]*models.Project
+}
+
+func NewInMemoryProjectRepository() *InMemoryProjectRepository {
+	return &InMemoryProjectRepository{
+		projects: make(map[uuid.UUID]*models.Project),
+	}
+}
+
+func (r *InMemoryProjectRepository) Create(ctx context.Context, project *models.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	project.ID = uuid.New()
+	project.CreatedAt = time.Now()
+	project.UpdatedAt = time.Now()
+
+	stored := *project
+	r.projects[project.ID] = &stored
+
+	log.Printf("Created project: ID=%s, Name=%s", project.ID, project.Name)
+
+	return nil
+}
+
+func (r *InMemoryProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, exists := r.projects[id]
+	if !exists {
+		log.Printf("Project not found: ID=%s", id)
+
+		return nil, fmt.Errorf("project %w", ErrNotFound)
+	}
+
+	found := *project
+	return &found, nil
+}
+
+// GetByName finds a project by name, ignoring case
+func (r *InMemoryProjectRepository) GetByName(ctx context.Context, name string) (*models.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, project := range r.projects {
+		if strings.EqualFold(project.Name, name) {
+			found := *project
+			return &found, nil
+		}
+	}
+
+	log.Printf("Project not found: Name=%s", name)
+
+	return nil, fmt.Errorf("project %w", ErrNotFound)
+}
+
+func (r *InMemoryProjectRepository) Update(ctx context.Context, project *models.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.projects[project.ID]
+	if !exists {
+		log.Printf("Project not found for update: ID=%s", project.ID)
+		return fmt.Errorf("project %w", ErrNotFound)
+	}
+
+	project.CreatedAt = existing.CreatedAt
+	project.UpdatedAt = time.Now()
+
+	stored := *project
+	r.projects[project.ID] = &stored
+
+	log.Printf("Updated project: ID=%s, Name=%s", project.ID, project.Name)
+
+	return nil
+}
+
+func (r *InMemoryProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[id]; !exists {
+		log.Printf("Project not found for deletion: ID=%s", id)
+		return fmt.Errorf("project %w", ErrNotFound)
+	}
+
+	delete(r.projects, id)
+
+	log.Printf("Deleted project: ID=%s", id)
+
+	return nil
+}
+
+// List returns all projects ordered by name
+func (r *InMemoryProjectRepository) List(ctx context.Context) ([]models.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := make([]models.Project, 0, len(r.projects))
+	for _, project := range r.projects {
+		projects = append(projects, *project)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+	})
+
+	log.Printf("Listed projects: Found: %d projects", len(projects))
+
+	return projects, nil
+}
+
+// ProjectName returns the current name of a project, for resolving the category of tasks
+func (r *InMemoryProjectRepository) ProjectName(id uuid.UUID) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, exists := r.projects[id]
+	if !exists {
+		return "", false
+	}
+
+	return project.Name, true
+}
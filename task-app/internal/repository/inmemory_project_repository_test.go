@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectOperations(t *testing.T) {
+	repo := NewInMemoryProjectRepository()
+	ctx := context.Background()
+
+	project := &models.Project{Name: "Ops"}
+
+	err := repo.Create(ctx, project)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, project.ID)
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		hasError bool
+	}{
+		{
+			name:     "Get Existing Project",
+			id:       project.ID,
+			hasError: false,
+		},
+		{
+			name:     "Get Non-existent Project",
+			id:       uuid.New(),
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := repo.GetByID(ctx, test.id)
+			if test.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, project.Name, result.Name)
+			}
+		})
+	}
+
+	found, err := repo.GetByName(ctx, "OPS")
+	assert.NoError(t, err)
+	assert.Equal(t, project.ID, found.ID)
+
+	// Returned projects are copies
+	found.Name = "Changed"
+	name, ok := repo.ProjectName(project.ID)
+	assert.True(t, ok)
+	assert.Equal(t, "Ops", name)
+
+	project.Name = "Operations"
+	assert.NoError(t, repo.Update(ctx, project))
+	name, _ = repo.ProjectName(project.ID)
+	assert.Equal(t, "Operations", name)
+
+	assert.NoError(t, repo.Delete(ctx, project.ID))
+	_, ok = repo.ProjectName(project.ID)
+	assert.False(t, ok)
+	assert.Error(t, repo.Delete(ctx, project.ID))
+}
+
+func TestTaskProjectNames(t *testing.T) {
+	projects := NewInMemoryProjectRepository()
+	tasks := NewInMemoryTaskRepository()
+	tasks.SetProjectNames(projects)
+	ctx := context.Background()
+
+	project := &models.Project{Name: "Ops"}
+	assert.NoError(t, projects.Create(ctx, project))
+
+	task := &models.Task{
+		Title:     "Rotate keys",
+		Category:  "Ops",
+		ProjectID: &project.ID,
+		DueDate:   time.Now().Add(24 * time.Hour),
+		Priority:  models.PriorityMedium,
+		Status:    models.StatusToDo,
+	}
+	assert.NoError(t, tasks.Create(ctx, task))
+
+	project.Name = "Operations"
+	assert.NoError(t, projects.Update(ctx, project))
+
+	stored, err := tasks.GetByID(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Operations", stored.Category)
+
+	listed, err := tasks.List(ctx, map[string]interface{}{"category": "operations"})
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	listed, err = tasks.List(ctx, map[string]interface{}{"project_id": project.ID})
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	listed, err = tasks.List(ctx, map[string]interface{}{"category": "Ops"})
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,8 +15,9 @@ import (
 )
 
 type InMemoryTaskRepository struct {
-	mu    sync.RWMutex
-	tasks map[uuid.UUID]*models.Task
+	mu       sync.RWMutex
+	tasks    map[uuid.UUID]*models.Task
+	projects ProjectNames
 }
 
 func NewInMemoryTaskRepository() *InMemoryTaskRepository {
@@ -23,6 +26,27 @@ func NewInMemoryTaskRepository() *InMemoryTaskRepository {
 	}
 }
 
+// SetProjectNames makes the category of tasks that belong to a project follow the project's current name.
+func (r *InMemoryTaskRepository) SetProjectNames(projects ProjectNames) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects = projects
+}
+
+// resolve returns a copy of a stored task with its category taken from its project
+func (r *InMemoryTaskRepository) resolve(task *models.Task) *models.Task {
+	resolved := *task
+	resolved.CustomFields = copyCustomFields(task.CustomFields)
+	if r.projects != nil && task.ProjectID != nil {
+		if name, ok := r.projects.ProjectName(*task.ProjectID); ok {
+			resolved.Category = name
+		}
+	}
+
+	return &resolved
+}
+
 func (r *InMemoryTaskRepository) Create(ctx context.Context, task *models.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -45,12 +69,12 @@ func (r *InMemoryTaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 	if !exists {
 		log.Printf("Task not found: ID=%s", id)
 
-		return nil, fmt.Errorf("task not found")
+		return nil, fmt.Errorf("task %w", ErrNotFound)
 	}
 
 	log.Printf("Retrieved task: ID=%s, Title=%s, Category=%s", task.ID, task.Title, task.Category)
 
-	return task, nil
+	return r.resolve(task), nil
 }
 
 func (r *InMemoryTaskRepository) Update(ctx context.Context, task *models.Task) error {
@@ -59,7 +83,7 @@ func (r *InMemoryTaskRepository) Update(ctx context.Context, task *models.Task)
 
 	if _, exists := r.tasks[task.ID]; !exists {
 		log.Printf("Task not found for update: ID=%s", task.ID)
-		return fmt.Errorf("task not found")
+		return fmt.Errorf("task %w", ErrNotFound)
 	}
 
 	task.UpdatedAt = time.Now()
@@ -70,20 +94,75 @@ func (r *InMemoryTaskRepository) Update(ctx context.Context, task *models.Task)
 	return nil
 }
 
-func (r *InMemoryTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Restore puts back a task under its own ID, e.g. one that was deleted. It fails when the ID is taken.
+func (r *InMemoryTaskRepository) Restore(ctx context.Context, task *models.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.tasks[id]; !exists {
+	if _, exists := r.tasks[task.ID]; exists {
+		log.Printf("Task already exists, cannot restore: ID=%s", task.ID)
+		return fmt.Errorf("task already exists")
+	}
+
+	task.UpdatedAt = time.Now()
+	r.tasks[task.ID] = task
+
+	log.Printf("Restored task: ID=%s, Title=%s", task.ID, task.Title)
+
+	return nil
+}
+
+func (r *InMemoryTaskRepository) Delete(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.tasks[id]
+	if !exists {
 		log.Printf("Task not found for deletion: ID=%s", id)
-		return fmt.Errorf("task not found")
+		return nil, fmt.Errorf("task %w", ErrNotFound)
 	}
 
+	touched := make(map[uuid.UUID]models.Task)
+	r.touch(touched, stored)
 	delete(r.tasks, id)
 
+	// Subtasks of a deleted task become top-level tasks
+	for _, task := range r.tasks {
+		if task.ParentID != nil && *task.ParentID == id {
+			r.touch(touched, task)
+			task.ParentID = nil
+			task.UpdatedAt = time.Now()
+		}
+	}
+	r.removeDependencyEdges(id, touched)
+
 	log.Printf("Deleted task: ID=%s", id)
 
-	return nil
+	return touchedTasks(touched), nil
+}
+
+func (r *InMemoryTaskRepository) DeleteTree(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tasks[id]; !exists {
+		log.Printf("Task not found for deletion: ID=%s", id)
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	touched := make(map[uuid.UUID]models.Task)
+	ids := r.subtreeIDs(id)
+	for _, taskID := range ids {
+		r.touch(touched, r.tasks[taskID])
+		delete(r.tasks, taskID)
+	}
+	for _, taskID := range ids {
+		r.removeDependencyEdges(taskID, touched)
+	}
+
+	log.Printf("Deleted task tree: ID=%s, Deleted: %d tasks", id, len(ids))
+
+	return touchedTasks(touched), nil
 }
 
 func (r *InMemoryTaskRepository) List(ctx context.Context, filters map[string]interface{}) ([]models.Task, error) {
@@ -91,7 +170,8 @@ func (r *InMemoryTaskRepository) List(ctx context.Context, filters map[string]in
 	defer r.mu.RUnlock()
 
 	var filteredTasks []models.Task
-	for _, task := range r.tasks {
+	for _, stored := range r.tasks {
+		task := r.resolve(stored)
 		if matchesFilters(task, filters) {
 			filteredTasks = append(filteredTasks, *task)
 		}
@@ -109,26 +189,287 @@ func (r *InMemoryTaskRepository) Duplicate(ctx context.Context, id uuid.UUID) (*
 	originalTask, exists := r.tasks[id]
 	if !exists {
 		log.Printf("Task not found for duplication: ID=%s", id)
-		return nil, fmt.Errorf("task not found")
+		return nil, fmt.Errorf("task %w", ErrNotFound)
 	}
 
 	duplicatedTask := &models.Task{
 		ID:          uuid.New(),
-		Title:       originalTask.Title + " (Copy)",
+		Title:       originalTask.Title,
 		Description: originalTask.Description,
 		Category:    originalTask.Category,
+		ProjectID:   originalTask.ProjectID,
 		DueDate:     originalTask.DueDate,
 		Priority:    originalTask.Priority,
-		Status:      models.StatusToDo,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Status:      originalTask.Status,
+		ParentID:    originalTask.ParentID,
+		Tags:        append([]uuid.UUID{}, originalTask.Tags...),
+		Assignees:   append([]uuid.UUID{}, originalTask.Assignees...),
+		ReporterID:  originalTask.ReporterID,
+		Checklist:   copyChecklist(originalTask.Checklist),
+
+		CustomFields: copyCustomFields(originalTask.CustomFields),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
+	copyEstimates(originalTask, duplicatedTask)
 
 	r.tasks[duplicatedTask.ID] = duplicatedTask
 
 	log.Printf("Duplicated task: OriginalID=%s, NewID=%s, Title=%s", id, duplicatedTask.ID, duplicatedTask.Title)
 
-	return duplicatedTask, nil
+	return r.resolve(duplicatedTask), nil
+}
+
+func (r *InMemoryTaskRepository) DuplicateTree(ctx context.Context, id uuid.UUID) (*models.Task, map[uuid.UUID]uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tasks[id]; !exists {
+		log.Printf("Task not found for duplication: ID=%s", id)
+		return nil, nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	// Map every original task in the subtree to its copy so children can be re-parented
+	copies := make(map[uuid.UUID]*models.Task)
+	ids := r.subtreeIDs(id)
+	for _, taskID := range ids {
+		original := r.tasks[taskID]
+		copied := &models.Task{
+			ID:          uuid.New(),
+			Title:       original.Title,
+			Description: original.Description,
+			Category:    original.Category,
+			ProjectID:   original.ProjectID,
+			DueDate:     original.DueDate,
+			Priority:    original.Priority,
+			Status:      original.Status,
+			ParentID:    original.ParentID,
+			Tags:        append([]uuid.UUID{}, original.Tags...),
+			Assignees:   append([]uuid.UUID{}, original.Assignees...),
+			ReporterID:  original.ReporterID,
+			Checklist:   copyChecklist(original.Checklist),
+
+			CustomFields: copyCustomFields(original.CustomFields),
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		copyEstimates(original, copied)
+		copies[taskID] = copied
+	}
+
+	copyIDs := make(map[uuid.UUID]uuid.UUID, len(copies))
+	for originalID, copied := range copies {
+		if copied.ParentID != nil {
+			if parentCopy, ok := copies[*copied.ParentID]; ok {
+				copied.ParentID = &parentCopy.ID
+			}
+		}
+		r.tasks[copied.ID] = copied
+		copyIDs[originalID] = copied.ID
+	}
+
+	duplicatedTask := copies[id]
+
+	log.Printf("Duplicated task tree: OriginalID=%s, NewID=%s, Copied: %d tasks", id, duplicatedTask.ID, len(copies))
+
+	return r.resolve(duplicatedTask), copyIDs, nil
+}
+
+func (r *InMemoryTaskRepository) MergeTag(ctx context.Context, from, into uuid.UUID) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	touched := make(map[uuid.UUID]models.Task)
+	for _, task := range r.tasks {
+		if !containsID(task.Tags, from) {
+			continue
+		}
+		r.touch(touched, task)
+
+		tags := make([]uuid.UUID, 0, len(task.Tags))
+		for _, tagID := range task.Tags {
+			if tagID == from {
+				tagID = into
+			}
+			if !containsID(tags, tagID) {
+				tags = append(tags, tagID)
+			}
+		}
+		task.Tags = tags
+		task.UpdatedAt = time.Now()
+	}
+
+	log.Printf("Merged tag: From=%s, Into=%s, Updated: %d tasks", from, into, len(touched))
+
+	return touchedTasks(touched), nil
+}
+
+func (r *InMemoryTaskRepository) DetachTag(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	touched := make(map[uuid.UUID]models.Task)
+	for _, task := range r.tasks {
+		for i, tagID := range task.Tags {
+			if tagID == id {
+				r.touch(touched, task)
+				task.Tags = append(task.Tags[:i:i], task.Tags[i+1:]...)
+				task.UpdatedAt = time.Now()
+				break
+			}
+		}
+	}
+
+	log.Printf("Detached tag: ID=%s, Updated: %d tasks", id, len(touched))
+
+	return touchedTasks(touched), nil
+}
+
+// UnassignUser removes a user from the assignees of every task and clears it as reporter
+func (r *InMemoryTaskRepository) UnassignUser(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	touched := make(map[uuid.UUID]models.Task)
+	for _, task := range r.tasks {
+		assigned := containsID(task.Assignees, id)
+		reporting := task.ReporterID != nil && *task.ReporterID == id
+		if !assigned && !reporting {
+			continue
+		}
+		r.touch(touched, task)
+
+		for i, assigneeID := range task.Assignees {
+			if assigneeID == id {
+				task.Assignees = append(task.Assignees[:i:i], task.Assignees[i+1:]...)
+				break
+			}
+		}
+		if reporting {
+			task.ReporterID = nil
+		}
+		task.UpdatedAt = time.Now()
+	}
+
+	log.Printf("Unassigned user: ID=%s, Updated: %d tasks", id, len(touched))
+
+	return touchedTasks(touched), nil
+}
+
+// removeDependencyEdges drops a deleted task from the blocked_by lists of the remaining tasks, remembering
+// the changed tasks in touched; the caller must hold the lock
+func (r *InMemoryTaskRepository) removeDependencyEdges(id uuid.UUID, touched map[uuid.UUID]models.Task) {
+	for _, task := range r.tasks {
+		for i, blockerID := range task.BlockedBy {
+			if blockerID == id {
+				r.touch(touched, task)
+				task.BlockedBy = append(task.BlockedBy[:i:i], task.BlockedBy[i+1:]...)
+				task.UpdatedAt = time.Now()
+				break
+			}
+		}
+	}
+}
+
+// touch remembers a task as it was before the first change of a bulk operation; the caller must hold the lock
+func (r *InMemoryTaskRepository) touch(touched map[uuid.UUID]models.Task, task *models.Task) {
+	if _, ok := touched[task.ID]; !ok {
+		touched[task.ID] = *r.resolve(task)
+	}
+}
+
+// touchedTasks lists the tasks remembered by touch
+func touchedTasks(touched map[uuid.UUID]models.Task) []models.Task {
+	tasks := make([]models.Task, 0, len(touched))
+	for _, task := range touched {
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+// subtreeIDs returns the ID of a task followed by the IDs of all its descendants; the caller must hold the lock
+func (r *InMemoryTaskRepository) subtreeIDs(id uuid.UUID) []uuid.UUID {
+	ids := []uuid.UUID{id}
+
+	for i := 0; i < len(ids); i++ {
+		for _, task := range r.tasks {
+			if task.ParentID != nil && *task.ParentID == ids[i] {
+				ids = append(ids, task.ID)
+			}
+		}
+	}
+
+	return ids
+}
+
+// copyCustomFields copies the custom field values of a task so the copy can change independently
+func copyCustomFields(values map[string]interface{}) map[string]interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	copied := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+// copyChecklist gives the items of a duplicated checklist new IDs and unchecks them
+func copyChecklist(items []models.ChecklistItem) []models.ChecklistItem {
+	if len(items) == 0 {
+		return nil
+	}
+
+	copied := make([]models.ChecklistItem, len(items))
+	for i, item := range items {
+		copied[i] = models.ChecklistItem{ID: uuid.New(), Text: item.Text, Position: item.Position}
+	}
+
+	return copied
+}
+
+// copyEstimates copies the original estimate and story points of a task. The remaining estimate of
+// the copy starts out at the original estimate, as none of its work is done yet.
+func copyEstimates(original, copied *models.Task) {
+	if original.OriginalEstimate != nil {
+		minutes, remaining := *original.OriginalEstimate, *original.OriginalEstimate
+		copied.OriginalEstimate = &minutes
+		copied.RemainingEstimate = &remaining
+	}
+	if original.StoryPoints != nil {
+		points := *original.StoryPoints
+		copied.StoryPoints = &points
+	}
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyID(ids, candidates []uuid.UUID) bool {
+	for _, candidate := range candidates {
+		if containsID(ids, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAllIDs(ids, candidates []uuid.UUID) bool {
+	for _, candidate := range candidates {
+		if !containsID(ids, candidate) {
+			return false
+		}
+	}
+	return true
 }
 
 func matchesFilters(task *models.Task, filters map[string]interface{}) bool {
@@ -139,7 +480,23 @@ func matchesFilters(task *models.Task, filters map[string]interface{}) bool {
 				return false
 			}
 		case "category":
-			if task.Category != value {
+			category, ok := value.(string)
+			if !ok || !strings.EqualFold(task.Category, category) {
+				return false
+			}
+		case "project_id":
+			projectID, ok := value.(uuid.UUID)
+			if !ok || task.ProjectID == nil || *task.ProjectID != projectID {
+				return false
+			}
+		case "sprint_id":
+			sprintID, ok := value.(uuid.UUID)
+			if !ok || task.SprintID == nil || *task.SprintID != sprintID {
+				return false
+			}
+		case "milestone_id":
+			milestoneID, ok := value.(uuid.UUID)
+			if !ok || task.MilestoneID == nil || *task.MilestoneID != milestoneID {
 				return false
 			}
 		case "status":
@@ -155,7 +512,92 @@ func matchesFilters(task *models.Task, filters map[string]interface{}) bool {
 			if !ok || !task.DueDate.Equal(dueDate) {
 				return false
 			}
+		case "parent_id":
+			parentID, ok := value.(uuid.UUID)
+			if !ok || task.ParentID == nil || *task.ParentID != parentID {
+				return false
+			}
+		case "blocked_by":
+			blockerID, ok := value.(uuid.UUID)
+			if !ok || !containsID(task.BlockedBy, blockerID) {
+				return false
+			}
+		case "tags_any":
+			tagIDs, ok := value.([]uuid.UUID)
+			if !ok || !containsAnyID(task.Tags, tagIDs) {
+				return false
+			}
+		case "tags_all":
+			tagIDs, ok := value.([]uuid.UUID)
+			if !ok || !containsAllIDs(task.Tags, tagIDs) {
+				return false
+			}
+		case "assignee":
+			userID, ok := value.(uuid.UUID)
+			if !ok || !containsID(task.Assignees, userID) {
+				return false
+			}
+		case "reporter":
+			userID, ok := value.(uuid.UUID)
+			if !ok || task.ReporterID == nil || *task.ReporterID != userID {
+				return false
+			}
+		case "unassigned":
+			unassigned, ok := value.(bool)
+			if !ok || unassigned != (len(task.Assignees) == 0) {
+				return false
+			}
+		case "overdue":
+			// Overdue tasks are past their due date at the reference time and not yet finished
+			now, ok := value.(time.Time)
+			if !ok || isFinished(task, filters) || !task.DueDate.Before(now) {
+				return false
+			}
+		case "due_within":
+			window, ok := value.(models.TimeRange)
+			if !ok || isFinished(task, filters) || !window.Contains(task.DueDate) {
+				return false
+			}
+		case "stale":
+			cutoff, ok := value.(time.Time)
+			if !ok || !task.UpdatedAt.Before(cutoff) {
+				return false
+			}
+		case "custom_fields":
+			wanted, ok := value.(map[string]string)
+			if !ok {
+				return false
+			}
+			for key, want := range wanted {
+				if !matchesCustomField(task.CustomFields[key], want) {
+					return false
+				}
+			}
 		}
 	}
 	return true
 }
+
+// isFinished reports whether a task is finished according to the finished filter, or is DONE when the
+// filters carry none
+func isFinished(task *models.Task, filters map[string]interface{}) bool {
+	if finished, ok := filters["finished"].(models.FinishedFunc); ok {
+		return finished(task)
+	}
+
+	return task.Status == models.StatusDone
+}
+
+// matchesCustomField compares a stored custom field value with a filter value: numbers numerically,
+// everything else as text ignoring case. Tasks without the field never match.
+func matchesCustomField(value interface{}, want string) bool {
+	switch stored := value.(type) {
+	case float64:
+		number, err := strconv.ParseFloat(want, 64)
+		return err == nil && stored == number
+	case string:
+		return strings.EqualFold(stored, want)
+	default:
+		return false
+	}
+}
@@ -80,7 +80,7 @@ func TestGetOperations(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			result, err := repo.GetByID(ctx, test.id)
 			if test.hasError {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, ErrNotFound)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, test.expected.Title, result.Title)
@@ -174,7 +174,7 @@ func TestDeleteOperations(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			err := repo.Delete(ctx, test.id)
+			_, err := repo.Delete(ctx, test.id)
 			if test.hasError {
 				assert.Error(t, err)
 			} else {
@@ -185,3 +185,58 @@ func TestDeleteOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestSmartFilters(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	ctx := context.Background()
+	now := time.Now()
+
+	overdue := &models.Task{Title: "Overdue", DueDate: now.Add(-time.Hour), Priority: models.PriorityHigh, Status: models.StatusToDo}
+	overdueDone := &models.Task{Title: "Overdue but done", DueDate: now.Add(-time.Hour), Priority: models.PriorityLow, Status: models.StatusDone}
+	dueSoon := &models.Task{Title: "Due soon", DueDate: now.Add(24 * time.Hour), Priority: models.PriorityLow, Status: models.StatusInProgress}
+	later := &models.Task{Title: "Later", DueDate: now.Add(10 * 24 * time.Hour), Priority: models.PriorityHigh, Status: models.StatusToDo}
+	for _, task := range []*models.Task{overdue, overdueDone, dueSoon, later} {
+		_ = repo.Create(ctx, task)
+	}
+	later.UpdatedAt = now.AddDate(0, 0, -30)
+
+	tests := []struct {
+		name     string
+		filters  map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "Overdue",
+			filters:  map[string]interface{}{"overdue": now},
+			expected: []string{"Overdue"},
+		},
+		{
+			name:     "Due Within 48h",
+			filters:  map[string]interface{}{"due_within": models.TimeRange{From: now, To: now.Add(48 * time.Hour)}},
+			expected: []string{"Due soon"},
+		},
+		{
+			name:     "Stale",
+			filters:  map[string]interface{}{"stale": now.AddDate(0, 0, -14)},
+			expected: []string{"Later"},
+		},
+		{
+			name:     "Overdue Composed With Priority",
+			filters:  map[string]interface{}{"overdue": now, "priority": models.PriorityLow},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tasks, err := repo.List(ctx, test.filters)
+			assert.NoError(t, err)
+
+			var titles []string
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+			}
+			assert.Equal(t, test.expected, titles)
+		})
+	}
+}
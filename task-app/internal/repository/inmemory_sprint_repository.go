@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// InMemorySprintRepository stores sprints and the log of their scope changes.
+type InMemorySprintRepository struct {
+	mu           sync.RWMutex
+	sprints      map[uuid.UUID]*models.Sprint
+	scopeChanges map[uuid.UUID][]models.SprintScopeChange
+}
+
+func NewInMemorySprintRepository() *InMemorySprintRepository {
+	return &InMemorySprintRepository{
+		sprints:      make(map[uuid.UUID]*models.Sprint),
+		scopeChanges: make(map[uuid.UUID][]models.SprintScopeChange),
+	}
+}
+
+func (r *InMemorySprintRepository) Create(ctx context.Context, sprint *models.Sprint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sprint.ID = uuid.New()
+	sprint.CreatedAt = time.Now()
+	sprint.UpdatedAt = time.Now()
+
+	stored := *sprint
+	r.sprints[sprint.ID] = &stored
+
+	log.Printf("Created sprint: ID=%s, Name=%s", sprint.ID, sprint.Name)
+
+	return nil
+}
+
+func (r *InMemorySprintRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Sprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sprint, exists := r.sprints[id]
+	if !exists {
+		log.Printf("Sprint not found: ID=%s", id)
+
+		return nil, fmt.Errorf("sprint %w", ErrNotFound)
+	}
+
+	found := *sprint
+	return &found, nil
+}
+
+func (r *InMemorySprintRepository) Update(ctx context.Context, sprint *models.Sprint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.sprints[sprint.ID]
+	if !exists {
+		log.Printf("Sprint not found for update: ID=%s", sprint.ID)
+		return fmt.Errorf("sprint %w", ErrNotFound)
+	}
+
+	sprint.CreatedAt = existing.CreatedAt
+	sprint.UpdatedAt = time.Now()
+
+	stored := *sprint
+	r.sprints[sprint.ID] = &stored
+
+	log.Printf("Updated sprint: ID=%s, Name=%s", sprint.ID, sprint.Name)
+
+	return nil
+}
+
+// Delete removes a sprint together with its scope changes
+func (r *InMemorySprintRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.sprints[id]; !exists {
+		log.Printf("Sprint not found for deletion: ID=%s", id)
+		return fmt.Errorf("sprint %w", ErrNotFound)
+	}
+
+	delete(r.sprints, id)
+	delete(r.scopeChanges, id)
+
+	log.Printf("Deleted sprint: ID=%s", id)
+
+	return nil
+}
+
+// List returns all sprints ordered by start date
+func (r *InMemorySprintRepository) List(ctx context.Context) ([]models.Sprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sprints := make([]models.Sprint, 0, len(r.sprints))
+	for _, sprint := range r.sprints {
+		sprints = append(sprints, *sprint)
+	}
+
+	sort.Slice(sprints, func(i, j int) bool {
+		return sprints[i].StartDate.Before(sprints[j].StartDate)
+	})
+
+	log.Printf("Listed sprints: Found: %d sprints", len(sprints))
+
+	return sprints, nil
+}
+
+func (r *InMemorySprintRepository) AddScopeChange(ctx context.Context, change *models.SprintScopeChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.sprints[change.SprintID]; !exists {
+		return fmt.Errorf("sprint %w", ErrNotFound)
+	}
+
+	change.ID = uuid.New()
+	r.scopeChanges[change.SprintID] = append(r.scopeChanges[change.SprintID], *change)
+
+	log.Printf("Recorded scope change: SprintID=%s, TaskID=%s, Type=%s", change.SprintID, change.TaskID, change.Type)
+
+	return nil
+}
+
+// ListScopeChanges returns the scope changes of a sprint in the order they were recorded
+func (r *InMemorySprintRepository) ListScopeChanges(ctx context.Context, sprintID uuid.UUID) ([]models.SprintScopeChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := append([]models.SprintScopeChange{}, r.scopeChanges[sprintID]...)
+
+	return changes, nil
+}
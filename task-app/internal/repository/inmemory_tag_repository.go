@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryTagRepository struct {
+	mu   sync.RWMutex
+	tags map[uuid.UUID]*models.Tag
+}
+
+func NewInMemoryTagRepository() *InMemoryTagRepository {
+	return &InMemoryTagRepository{
+		tags: make(map[uuid.UUID]*models.Tag),
+	}
+}
+
+func (r *InMemoryTagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tag.ID = uuid.New()
+	tag.CreatedAt = time.Now()
+	tag.UpdatedAt = time.Now()
+	r.tags[tag.ID] = tag
+
+	log.Printf("Created tag: ID=%s, Name=%s", tag.ID, tag.Name)
+
+	return nil
+}
+
+func (r *InMemoryTagRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tag, exists := r.tags[id]
+	if !exists {
+		log.Printf("Tag not found: ID=%s", id)
+
+		return nil, fmt.Errorf("tag %w", ErrNotFound)
+	}
+
+	log.Printf("Retrieved tag: ID=%s, Name=%s", tag.ID, tag.Name)
+
+	return tag, nil
+}
+
+// GetByName looks a tag up by name, ignoring case
+func (r *InMemoryTagRepository) GetByName(ctx context.Context, name string) (*models.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, tag := range r.tags {
+		if strings.EqualFold(tag.Name, name) {
+			return tag, nil
+		}
+	}
+
+	return nil, fmt.Errorf("tag %w", ErrNotFound)
+}
+
+func (r *InMemoryTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.tags[tag.ID]
+	if !exists {
+		log.Printf("Tag not found for update: ID=%s", tag.ID)
+		return fmt.Errorf("tag %w", ErrNotFound)
+	}
+
+	tag.CreatedAt = existing.CreatedAt
+	tag.UpdatedAt = time.Now()
+	r.tags[tag.ID] = tag
+
+	log.Printf("Updated tag: ID=%s, Name=%s", tag.ID, tag.Name)
+
+	return nil
+}
+
+func (r *InMemoryTagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tags[id]; !exists {
+		log.Printf("Tag not found for deletion: ID=%s", id)
+		return fmt.Errorf("tag %w", ErrNotFound)
+	}
+
+	delete(r.tags, id)
+
+	log.Printf("Deleted tag: ID=%s", id)
+
+	return nil
+}
+
+func (r *InMemoryTagRepository) List(ctx context.Context) ([]models.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags := make([]models.Tag, 0, len(r.tags))
+	for _, tag := range r.tags {
+		tags = append(tags, *tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	log.Printf("Listed tags: Found: %d tags", len(tags))
+
+	return tags, nil
+}
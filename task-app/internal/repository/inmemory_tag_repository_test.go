@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagOperations(t *testing.T) {
+	repo := NewInMemoryTagRepository()
+	ctx := context.Background()
+
+	tag := &models.Tag{
+		Name:  "backend",
+		Color: "#336699",
+	}
+
+	err := repo.Create(ctx, tag)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, tag.ID)
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		hasError bool
+	}{
+		{
+			name:     "Get Existing Tag",
+			id:       tag.ID,
+			hasError: false,
+		},
+		{
+			name:     "Get Non-existent Tag",
+			id:       uuid.New(),
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := repo.GetByID(ctx, test.id)
+			if test.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tag.Name, result.Name)
+			}
+		})
+	}
+
+	found, err := repo.GetByName(ctx, "BACKEND")
+	assert.NoError(t, err)
+	assert.Equal(t, tag.ID, found.ID)
+
+	_, err = repo.GetByName(ctx, "frontend")
+	assert.Error(t, err)
+
+	err = repo.Update(ctx, &models.Tag{ID: tag.ID, Name: "Renamed"})
+	assert.NoError(t, err)
+
+	tags, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+	assert.Equal(t, "Renamed", tags[0].Name)
+
+	assert.NoError(t, repo.Delete(ctx, tag.ID))
+	assert.Error(t, repo.Delete(ctx, tag.ID))
+}
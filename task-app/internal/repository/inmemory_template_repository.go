@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[uuid.UUID]*models.TaskTemplate
+}
+
+func NewInMemoryTemplateRepository() *InMemoryTemplateRepository {
+	return &InMemoryTemplateRepository{
+		templates: make(map[uuid.UUID]*models.TaskTemplate),
+	}
+}
+
+func (r *InMemoryTemplateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	template.ID = uuid.New()
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = time.Now()
+
+	r.templates[template.ID] = copyTemplate(template)
+
+	log.Printf("Created template: ID=%s, Name=%s", template.ID, template.Name)
+
+	return nil
+}
+
+func (r *InMemoryTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.TaskTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, exists := r.templates[id]
+	if !exists {
+		log.Printf("Template not found: ID=%s", id)
+
+		return nil, fmt.Errorf("template %w", ErrNotFound)
+	}
+
+	return copyTemplate(template), nil
+}
+
+// GetByName finds a template by name, ignoring case
+func (r *InMemoryTemplateRepository) GetByName(ctx context.Context, name string) (*models.TaskTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, template := range r.templates {
+		if strings.EqualFold(template.Name, name) {
+			return copyTemplate(template), nil
+		}
+	}
+
+	log.Printf("Template not found: Name=%s", name)
+
+	return nil, fmt.Errorf("template %w", ErrNotFound)
+}
+
+func (r *InMemoryTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.templates[template.ID]
+	if !exists {
+		log.Printf("Template not found for update: ID=%s", template.ID)
+		return fmt.Errorf("template %w", ErrNotFound)
+	}
+
+	template.CreatedAt = existing.CreatedAt
+	template.UpdatedAt = time.Now()
+
+	r.templates[template.ID] = copyTemplate(template)
+
+	log.Printf("Updated template: ID=%s, Name=%s", template.ID, template.Name)
+
+	return nil
+}
+
+func (r *InMemoryTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.templates[id]; !exists {
+		log.Printf("Template not found for deletion: ID=%s", id)
+		return fmt.Errorf("template %w", ErrNotFound)
+	}
+
+	delete(r.templates, id)
+
+	log.Printf("Deleted template: ID=%s", id)
+
+	return nil
+}
+
+// List returns all templates ordered by name
+func (r *InMemoryTemplateRepository) List(ctx context.Context) ([]models.TaskTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]models.TaskTemplate, 0, len(r.templates))
+	for _, template := range r.templates {
+		templates = append(templates, *copyTemplate(template))
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return strings.ToLower(templates[i].Name) < strings.ToLower(templates[j].Name)
+	})
+
+	log.Printf("Listed templates: Found: %d templates", len(templates))
+
+	return templates, nil
+}
+
+// copyTemplate deep-copies a template so callers cannot change stored templates through shared slices or maps
+func copyTemplate(template *models.TaskTemplate) *models.TaskTemplate {
+	copied := *template
+	copied.Task = copyTemplateTask(template.Task)
+	copied.Variables = append([]string(nil), template.Variables...)
+
+	return &copied
+}
+
+func copyTemplateTask(task models.TemplateTask) models.TemplateTask {
+	copied := task
+	copied.Assignees = append([]uuid.UUID(nil), task.Assignees...)
+	copied.Checklist = append([]string(nil), task.Checklist...)
+	copied.CustomFields = copyCustomFields(task.CustomFields)
+	copied.Subtasks = nil
+	for _, subtask := range task.Subtasks {
+		copied.Subtasks = append(copied.Subtasks, copyTemplateTask(subtask))
+	}
+
+	return copied
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]*models.User
+}
+
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{
+		users: make(map[uuid.UUID]*models.User),
+	}
+}
+
+func (r *InMemoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.ID = uuid.New()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = user
+
+	log.Printf("Created user: ID=%s, Name=%s", user.ID, user.Name)
+
+	return nil
+}
+
+func (r *InMemoryUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[id]
+	if !exists {
+		log.Printf("User not found: ID=%s", id)
+
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	log.Printf("Retrieved user: ID=%s, Name=%s", user.ID, user.Name)
+
+	return user, nil
+}
+
+// GetByEmail looks a user up by email address, ignoring case
+func (r *InMemoryUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user %w", ErrNotFound)
+}
+
+func (r *InMemoryUserRepository) Update(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.users[user.ID]
+	if !exists {
+		log.Printf("User not found for update: ID=%s", user.ID)
+		return fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	user.CreatedAt = existing.CreatedAt
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = user
+
+	log.Printf("Updated user: ID=%s, Name=%s", user.ID, user.Name)
+
+	return nil
+}
+
+func (r *InMemoryUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.users[id]; !exists {
+		log.Printf("User not found for deletion: ID=%s", id)
+		return fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	delete(r.users, id)
+
+	log.Printf("Deleted user: ID=%s", id)
+
+	return nil
+}
+
+func (r *InMemoryUserRepository) List(ctx context.Context) ([]models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]models.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, *user)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Name < users[j].Name
+	})
+
+	log.Printf("Listed users: Found: %d users", len(users))
+
+	return users, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserOperations(t *testing.T) {
+	repo := NewInMemoryUserRepository()
+	ctx := context.Background()
+
+	user := &models.User{
+		Name:  "Jane Doe",
+		Email: "jane@example.com",
+	}
+
+	err := repo.Create(ctx, user)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, user.ID)
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		hasError bool
+	}{
+		{
+			name:     "Get Existing User",
+			id:       user.ID,
+			hasError: false,
+		},
+		{
+			name:     "Get Non-existent User",
+			id:       uuid.New(),
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := repo.GetByID(ctx, test.id)
+			if test.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, user.Name, result.Name)
+			}
+		})
+	}
+
+	found, err := repo.GetByEmail(ctx, "JANE@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, found.ID)
+
+	_, err = repo.GetByEmail(ctx, "john@example.com")
+	assert.Error(t, err)
+
+	err = repo.Update(ctx, &models.User{ID: user.ID, Name: "Renamed"})
+	assert.NoError(t, err)
+
+	users, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "Renamed", users[0].Name)
+
+	assert.NoError(t, repo.Delete(ctx, user.ID))
+	assert.Error(t, repo.Delete(ctx, user.ID))
+}
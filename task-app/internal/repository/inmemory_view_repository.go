@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryViewRepository struct {
+	mu    sync.RWMutex
+	views map[uuid.UUID]*models.View
+}
+
+func NewInMemoryViewRepository() *InMemoryViewRepository {
+	return &InMemoryViewRepository{
+		views: make(map[uuid.UUID]*models.View),
+	}
+}
+
+func (r *InMemoryViewRepository) Create(ctx context.Context, view *models.View) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	view.ID = uuid.New()
+	view.CreatedAt = time.Now()
+	view.UpdatedAt = time.Now()
+	r.views[view.ID] = view
+
+	log.Printf("Created view: ID=%s, Name=%s", view.ID, view.Name)
+
+	return nil
+}
+
+func (r *InMemoryViewRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	view, exists := r.views[id]
+	if !exists {
+		log.Printf("View not found: ID=%s", id)
+
+		return nil, fmt.Errorf("view %w", ErrNotFound)
+	}
+
+	log.Printf("Retrieved view: ID=%s, Name=%s", view.ID, view.Name)
+
+	return view, nil
+}
+
+func (r *InMemoryViewRepository) Update(ctx context.Context, view *models.View) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.views[view.ID]
+	if !exists {
+		log.Printf("View not found for update: ID=%s", view.ID)
+		return fmt.Errorf("view %w", ErrNotFound)
+	}
+
+	view.CreatedAt = existing.CreatedAt
+	view.UpdatedAt = time.Now()
+	r.views[view.ID] = view
+
+	log.Printf("Updated view: ID=%s, Name=%s", view.ID, view.Name)
+
+	return nil
+}
+
+func (r *InMemoryViewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.views[id]; !exists {
+		log.Printf("View not found for deletion: ID=%s", id)
+		return fmt.Errorf("view %w", ErrNotFound)
+	}
+
+	delete(r.views, id)
+
+	log.Printf("Deleted view: ID=%s", id)
+
+	return nil
+}
+
+func (r *InMemoryViewRepository) List(ctx context.Context) ([]models.View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	views := make([]models.View, 0, len(r.views))
+	for _, view := range r.views {
+		views = append(views, *view)
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+
+	log.Printf("Listed views: Found: %d views", len(views))
+
+	return views, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewOperations(t *testing.T) {
+	repo := NewInMemoryViewRepository()
+	ctx := context.Background()
+
+	view := &models.View{
+		Name:    "High priority",
+		Filters: map[string]string{"priority": "HIGH"},
+	}
+
+	err := repo.Create(ctx, view)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, view.ID)
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		hasError bool
+	}{
+		{
+			name:     "Get Existing View",
+			id:       view.ID,
+			hasError: false,
+		},
+		{
+			name:     "Get Non-existent View",
+			id:       uuid.New(),
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := repo.GetByID(ctx, test.id)
+			if test.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, view.Name, result.Name)
+			}
+		})
+	}
+
+	err = repo.Update(ctx, &models.View{ID: view.ID, Name: "Renamed"})
+	assert.NoError(t, err)
+
+	views, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, views, 1)
+	assert.Equal(t, "Renamed", views[0].Name)
+
+	assert.NoError(t, repo.Delete(ctx, view.ID))
+	assert.Error(t, repo.Delete(ctx, view.ID))
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryWorkflowRepository struct {
+	mu        sync.RWMutex
+	workflows map[uuid.UUID]*models.WorkflowDefinition
+}
+
+func NewInMemoryWorkflowRepository() *InMemoryWorkflowRepository {
+	return &InMemoryWorkflowRepository{
+		workflows: make(map[uuid.UUID]*models.WorkflowDefinition),
+	}
+}
+
+func (r *InMemoryWorkflowRepository) Create(ctx context.Context, workflow *models.WorkflowDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workflow.ID = uuid.New()
+	workflow.CreatedAt = time.Now()
+	workflow.UpdatedAt = time.Now()
+	r.workflows[workflow.ID] = workflow
+
+	log.Printf("Created workflow: ID=%s, Name=%s", workflow.ID, workflow.Name)
+
+	return nil
+}
+
+func (r *InMemoryWorkflowRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WorkflowDefinition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflow, exists := r.workflows[id]
+	if !exists {
+		log.Printf("Workflow not found: ID=%s", id)
+
+		return nil, fmt.Errorf("workflow %w", ErrNotFound)
+	}
+
+	log.Printf("Retrieved workflow: ID=%s, Name=%s", workflow.ID, workflow.Name)
+
+	return workflow, nil
+}
+
+func (r *InMemoryWorkflowRepository) Update(ctx context.Context, workflow *models.WorkflowDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.workflows[workflow.ID]
+	if !exists {
+		log.Printf("Workflow not found for update: ID=%s", workflow.ID)
+		return fmt.Errorf("workflow %w", ErrNotFound)
+	}
+
+	workflow.CreatedAt = existing.CreatedAt
+	workflow.UpdatedAt = time.Now()
+	r.workflows[workflow.ID] = workflow
+
+	log.Printf("Updated workflow: ID=%s, Name=%s", workflow.ID, workflow.Name)
+
+	return nil
+}
+
+func (r *InMemoryWorkflowRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.workflows[id]; !exists {
+		log.Printf("Workflow not found for deletion: ID=%s", id)
+		return fmt.Errorf("workflow %w", ErrNotFound)
+	}
+
+	delete(r.workflows, id)
+
+	log.Printf("Deleted workflow: ID=%s", id)
+
+	return nil
+}
+
+func (r *InMemoryWorkflowRepository) List(ctx context.Context) ([]models.WorkflowDefinition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflows := make([]models.WorkflowDefinition, 0, len(r.workflows))
+	for _, workflow := range r.workflows {
+		workflows = append(workflows, *workflow)
+	}
+
+	sort.Slice(workflows, func(i, j int) bool {
+		return workflows[i].Name < workflows[j].Name
+	})
+
+	log.Printf("Listed workflows: Found: %d workflows", len(workflows))
+
+	return workflows, nil
+}
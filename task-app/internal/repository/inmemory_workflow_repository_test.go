@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowOperations(t *testing.T) {
+	repo := NewInMemoryWorkflowRepository()
+	ctx := context.Background()
+
+	workflow := &models.WorkflowDefinition{
+		Name:     "QA",
+		Statuses: []models.Status{"TODO", "VERIFIED"},
+	}
+
+	err := repo.Create(ctx, workflow)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, workflow.ID)
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		hasError bool
+	}{
+		{
+			name:     "Get Existing Workflow",
+			id:       workflow.ID,
+			hasError: false,
+		},
+		{
+			name:     "Get Non-existent Workflow",
+			id:       uuid.New(),
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := repo.GetByID(ctx, test.id)
+			if test.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, workflow.Name, result.Name)
+			}
+		})
+	}
+
+	err = repo.Update(ctx, &models.WorkflowDefinition{ID: workflow.ID, Name: "Renamed"})
+	assert.NoError(t, err)
+
+	workflows, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, workflows, 1)
+	assert.Equal(t, "Renamed", workflows[0].Name)
+
+	assert.NoError(t, repo.Delete(ctx, workflow.ID))
+	assert.Error(t, repo.Delete(ctx, workflow.ID))
+}
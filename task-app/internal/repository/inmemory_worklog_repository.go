@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryWorklogRepository struct {
+	mu       sync.RWMutex
+	worklogs map[uuid.UUID]*models.Worklog
+}
+
+func NewInMemoryWorklogRepository() *InMemoryWorklogRepository {
+	return &InMemoryWorklogRepository{
+		worklogs: make(map[uuid.UUID]*models.Worklog),
+	}
+}
+
+func (r *InMemoryWorklogRepository) Create(ctx context.Context, worklog *models.Worklog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	worklog.ID = uuid.New()
+	worklog.CreatedAt = time.Now()
+	worklog.UpdatedAt = time.Now()
+
+	stored := *worklog
+	r.worklogs[worklog.ID] = &stored
+
+	log.Printf("Created worklog: ID=%s, TaskID=%s, UserID=%s", worklog.ID, worklog.TaskID, worklog.UserID)
+
+	return nil
+}
+
+func (r *InMemoryWorklogRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Worklog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	worklog, exists := r.worklogs[id]
+	if !exists {
+		log.Printf("Worklog not found: ID=%s", id)
+
+		return nil, fmt.Errorf("worklog %w", ErrNotFound)
+	}
+
+	found := *worklog
+	return &found, nil
+}
+
+func (r *InMemoryWorklogRepository) Update(ctx context.Context, worklog *models.Worklog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.worklogs[worklog.ID]
+	if !exists {
+		log.Printf("Worklog not found for update: ID=%s", worklog.ID)
+		return fmt.Errorf("worklog %w", ErrNotFound)
+	}
+
+	worklog.CreatedAt = existing.CreatedAt
+	worklog.UpdatedAt = time.Now()
+
+	stored := *worklog
+	r.worklogs[worklog.ID] = &stored
+
+	log.Printf("Updated worklog: ID=%s", worklog.ID)
+
+	return nil
+}
+
+func (r *InMemoryWorklogRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.worklogs[id]; !exists {
+		log.Printf("Worklog not found for deletion: ID=%s", id)
+		return fmt.Errorf("worklog %w", ErrNotFound)
+	}
+
+	delete(r.worklogs, id)
+
+	log.Printf("Deleted worklog: ID=%s", id)
+
+	return nil
+}
+
+// List returns the worklogs matching the filter, earliest start first. Running timers overlap every range after their start.
+func (r *InMemoryWorklogRepository) List(ctx context.Context, filter models.WorklogFilter) ([]models.Worklog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	worklogs := make([]models.Worklog, 0)
+	for _, worklog := range r.worklogs {
+		if filter.TaskID != nil && worklog.TaskID != *filter.TaskID {
+			continue
+		}
+		if filter.UserID != nil && worklog.UserID != *filter.UserID {
+			continue
+		}
+		if !filter.To.IsZero() && !worklog.Start.Before(filter.To) {
+			continue
+		}
+		if !filter.From.IsZero() && worklog.End != nil && !worklog.End.After(filter.From) {
+			continue
+		}
+		worklogs = append(worklogs, *worklog)
+	}
+
+	sort.Slice(worklogs, func(i, j int) bool {
+		return worklogs[i].Start.Before(worklogs[j].Start)
+	})
+
+	log.Printf("Listed worklogs: Found: %d worklogs", len(worklogs))
+
+	return worklogs, nil
+}
+
+func (r *InMemoryWorklogRepository) DeleteByTask(ctx context.Context, taskID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, worklog := range r.worklogs {
+		if worklog.TaskID == taskID {
+			delete(r.worklogs, id)
+			deleted++
+		}
+	}
+
+	log.Printf("Deleted worklogs of task: TaskID=%s, Removed: %d worklogs", taskID, deleted)
+
+	return deleted, nil
+}
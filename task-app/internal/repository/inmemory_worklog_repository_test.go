@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryWorklogRepository_List(t *testing.T) {
+	repo := NewInMemoryWorklogRepository()
+	ctx := context.Background()
+
+	taskID, userID := uuid.New(), uuid.New()
+	base := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	end := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+
+	morning := &models.Worklog{TaskID: taskID, UserID: userID, Start: base, End: end(2 * time.Hour)}
+	afternoon := &models.Worklog{TaskID: uuid.New(), UserID: userID, Start: base.Add(4 * time.Hour), End: end(5 * time.Hour)}
+	running := &models.Worklog{TaskID: taskID, UserID: uuid.New(), Start: base.Add(6 * time.Hour)}
+	for _, worklog := range []*models.Worklog{running, afternoon, morning} {
+		assert.NoError(t, repo.Create(ctx, worklog))
+	}
+
+	tests := []struct {
+		name     string
+		filter   models.WorklogFilter
+		expected []uuid.UUID
+	}{
+		{name: "All", filter: models.WorklogFilter{}, expected: []uuid.UUID{morning.ID, afternoon.ID, running.ID}},
+		{name: "By Task", filter: models.WorklogFilter{TaskID: &taskID}, expected: []uuid.UUID{morning.ID, running.ID}},
+		{name: "By User", filter: models.WorklogFilter{UserID: &userID}, expected: []uuid.UUID{morning.ID, afternoon.ID}},
+		{name: "Overlapping Range", filter: models.WorklogFilter{From: base.Add(time.Hour), To: base.Add(4*time.Hour + time.Minute)}, expected: []uuid.UUID{morning.ID, afternoon.ID}},
+		{name: "Touching Range Excluded", filter: models.WorklogFilter{From: base.Add(2 * time.Hour), To: base.Add(4 * time.Hour)}, expected: []uuid.UUID{}},
+		{name: "Running Timer Open Ended", filter: models.WorklogFilter{From: base.Add(48 * time.Hour)}, expected: []uuid.UUID{running.ID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			worklogs, err := repo.List(ctx, test.filter)
+			assert.NoError(t, err)
+
+			ids := make([]uuid.UUID, 0, len(worklogs))
+			for _, worklog := range worklogs {
+				ids = append(ids, worklog.ID)
+			}
+			assert.Equal(t, test.expected, ids)
+		})
+	}
+
+	removed, err := repo.DeleteByTask(ctx, taskID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrBlobTooLarge is returned by Put when the content exceeds the size limit.
+var ErrBlobTooLarge = errors.New("blob exceeds size limit")
+
+// LocalBlobStore keeps content-addressed blobs on the local filesystem, each stored under the
+// hex SHA-256 of its content in a directory named after the first two characters of the hash.
+type LocalBlobStore struct {
+	mu   sync.Mutex
+	root string
+}
+
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create blob store: %w", err)
+	}
+
+	return &LocalBlobStore{root: root}, nil
+}
+
+// Put stores the content of r and returns its hash and size. Content that is already stored is not
+// written twice. When more than maxSize bytes are read nothing is stored and ErrBlobTooLarge is returned.
+func (s *LocalBlobStore) Put(r io.Reader, maxSize int64) (string, int64, error) {
+	tmp, err := os.CreateTemp(s.root, "upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(r, maxSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if size > maxSize {
+		return "", 0, ErrBlobTooLarge
+	}
+
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	path := s.path(hash)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Stat(path); err == nil {
+		log.Printf("Blob already stored: Hash=%s", hash)
+		return hash, size, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", 0, err
+	}
+
+	log.Printf("Stored blob: Hash=%s, Size=%d", hash, size)
+
+	return hash, size, nil
+}
+
+func (s *LocalBlobStore) Open(hash string) (io.ReadCloser, error) {
+	if !isBlobHash(hash) {
+		return nil, fmt.Errorf("blob %w", ErrNotFound)
+	}
+
+	file, err := os.Open(s.path(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("blob %w", ErrNotFound)
+	}
+
+	return file, err
+}
+
+func (s *LocalBlobStore) Delete(hash string) error {
+	if !isBlobHash(hash) {
+		return fmt.Errorf("blob %w", ErrNotFound)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(hash)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("blob %w", ErrNotFound)
+		}
+		return err
+	}
+
+	log.Printf("Deleted blob: Hash=%s", hash)
+
+	return nil
+}
+
+// List returns the hashes of all stored blobs
+func (s *LocalBlobStore) List() ([]string, error) {
+	var hashes []string
+
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && isBlobHash(entry.Name()) {
+			hashes = append(hashes, entry.Name())
+		}
+		return nil
+	})
+
+	return hashes, err
+}
+
+func (s *LocalBlobStore) path(hash string) string {
+	return filepath.Join(s.root, hash[:2], hash)
+}
+
+// isBlobHash reports whether name is a hex SHA-256 digest, which also keeps paths inside the store
+func isBlobHash(name string) bool {
+	if len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+
+	hash, size, err := store.Put(strings.NewReader("hello"), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", hash)
+	assert.Equal(t, int64(5), size)
+
+	// Identical content is stored once
+	again, _, err := store.Put(strings.NewReader("hello"), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, again)
+
+	hashes, err := store.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{hash}, hashes)
+
+	tests := []struct {
+		name     string
+		content  string
+		maxSize  int64
+		hasError bool
+	}{
+		{name: "Exactly At Limit", content: "12345", maxSize: 5},
+		{name: "Over Limit", content: "123456", maxSize: 5, hasError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := store.Put(strings.NewReader(test.content), test.maxSize)
+			if test.hasError {
+				assert.ErrorIs(t, err, ErrBlobTooLarge)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	reader, err := store.Open(hash)
+	assert.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, "hello", string(content))
+
+	_, err = store.Open("../../etc/passwd")
+	assert.Error(t, err)
+
+	assert.NoError(t, store.Delete(hash))
+	assert.Error(t, store.Delete(hash))
+	_, err = store.Open(hash)
+	assert.Error(t, err)
+}
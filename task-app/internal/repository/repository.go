@@ -2,15 +2,175 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"io"
 	"task-app/internal/models"
 )
 
+// ErrNotFound is wrapped by the error of every lookup that finds no record.
+var ErrNotFound = errors.New("not found")
+
 type TaskRepository interface {
 	Create(ctx context.Context, task *models.Task) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Task, error)
 	Update(ctx context.Context, task *models.Task) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete deletes a task and turns its subtasks into top-level tasks. Like DeleteTree, it returns every
+	// task it deleted or changed as they were before.
+	Delete(ctx context.Context, id uuid.UUID) ([]models.Task, error)
+	Restore(ctx context.Context, task *models.Task) error
 	List(ctx context.Context, filters map[string]interface{}) ([]models.Task, error)
 	Duplicate(ctx context.Context, id uuid.UUID) (*models.Task, error)
+	// DeleteTree deletes a task and its subtree. It returns every task it deleted or changed, such as tasks
+	// that depended on a deleted task, as they were before; so do MergeTag, DetachTag and UnassignUser.
+	DeleteTree(ctx context.Context, id uuid.UUID) ([]models.Task, error)
+	// DuplicateTree copies a task and its subtree, returning the copy of the task and a map from
+	// every original in the subtree to its copy.
+	DuplicateTree(ctx context.Context, id uuid.UUID) (*models.Task, map[uuid.UUID]uuid.UUID, error)
+	MergeTag(ctx context.Context, from, into uuid.UUID) ([]models.Task, error)
+	DetachTag(ctx context.Context, id uuid.UUID) ([]models.Task, error)
+	UnassignUser(ctx context.Context, id uuid.UUID) ([]models.Task, error)
+	Aggregate(ctx context.Context, filters map[string]interface{}, opts models.AggregationOptions) (*models.TaskStats, error)
+}
+
+type ViewRepository interface {
+	Create(ctx context.Context, view *models.View) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.View, error)
+	Update(ctx context.Context, view *models.View) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.View, error)
+}
+
+type WorkflowRepository interface {
+	Create(ctx context.Context, workflow *models.WorkflowDefinition) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.WorkflowDefinition, error)
+	Update(ctx context.Context, workflow *models.WorkflowDefinition) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.WorkflowDefinition, error)
+}
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *models.Tag) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, error)
+	GetByName(ctx context.Context, name string) (*models.Tag, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.Tag, error)
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *models.Comment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Comment, error)
+	Update(ctx context.Context, comment *models.Comment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.Comment, error)
+	DeleteByTask(ctx context.Context, taskID uuid.UUID) (int, error)
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.Attachment, error)
+	DeleteByTask(ctx context.Context, taskID uuid.UUID) ([]models.Attachment, error)
+	ReferencedHashes(ctx context.Context) (map[string]bool, error)
+}
+
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *models.Reminder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Reminder, error)
+	Update(ctx context.Context, reminder *models.Reminder) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.Reminder, error)
+	List(ctx context.Context) ([]models.Reminder, error)
+	ListPending(ctx context.Context) ([]models.Reminder, error)
+	DeleteByTask(ctx context.Context, taskID uuid.UUID) (int, error)
+}
+
+type WorklogRepository interface {
+	Create(ctx context.Context, worklog *models.Worklog) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Worklog, error)
+	Update(ctx context.Context, worklog *models.Worklog) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter models.WorklogFilter) ([]models.Worklog, error)
+	DeleteByTask(ctx context.Context, taskID uuid.UUID) (int, error)
+}
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *models.Project) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error)
+	GetByName(ctx context.Context, name string) (*models.Project, error)
+	Update(ctx context.Context, project *models.Project) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.Project, error)
+}
+
+type SprintRepository interface {
+	Create(ctx context.Context, sprint *models.Sprint) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Sprint, error)
+	Update(ctx context.Context, sprint *models.Sprint) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.Sprint, error)
+	AddScopeChange(ctx context.Context, change *models.SprintScopeChange) error
+	ListScopeChanges(ctx context.Context, sprintID uuid.UUID) ([]models.SprintScopeChange, error)
+}
+
+type MilestoneRepository interface {
+	Create(ctx context.Context, milestone *models.Milestone) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Milestone, error)
+	Update(ctx context.Context, milestone *models.Milestone) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.Milestone, error)
+}
+
+type CustomFieldRepository interface {
+	Create(ctx context.Context, def *models.CustomFieldDefinition) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CustomFieldDefinition, error)
+	Update(ctx context.Context, def *models.CustomFieldDefinition) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]models.CustomFieldDefinition, error)
+}
+
+type TemplateRepository interface {
+	Create(ctx context.Context, template *models.TaskTemplate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.TaskTemplate, error)
+	GetByName(ctx context.Context, name string) (*models.TaskTemplate, error)
+	Update(ctx context.Context, template *models.TaskTemplate) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.TaskTemplate, error)
+}
+
+type HistoryRepository interface {
+	Append(ctx context.Context, entry *models.HistoryEntry) error
+	ListByTask(ctx context.Context, taskID uuid.UUID) ([]models.HistoryEntry, error)
+}
+
+type OperationRepository interface {
+	Create(ctx context.Context, operation *models.Operation) error
+	Update(ctx context.Context, operation *models.Operation) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Operation, error)
+	List(ctx context.Context) ([]models.Operation, error)
+}
+
+// ProjectNames resolves project IDs to their current names.
+type ProjectNames interface {
+	ProjectName(id uuid.UUID) (string, bool)
+}
+
+// BlobStore keeps file content addressed by its hash.
+type BlobStore interface {
+	Put(r io.Reader, maxSize int64) (string, int64, error)
+	Open(hash string) (io.ReadCloser, error)
+	Delete(hash string) error
+	List() ([]string, error)
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]models.User, error)
 }
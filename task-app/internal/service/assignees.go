@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrDuplicateAssignee = errors.New("user is assigned more than once")
+)
+
+// SetUserRepository makes the service check assignees and reporters against a user directory.
+// Until one is set any user ID is accepted.
+func (s *TaskService) SetUserRepository(users repository.UserRepository) {
+	s.users = users
+}
+
+// SetKeepAssignees controls whether duplicated tasks keep the assignees of the original by default.
+func (s *TaskService) SetKeepAssignees(keep bool) {
+	s.keepAssignees = keep
+}
+
+// ListAssignedTasks returns the tasks assigned to a user that also match filters.
+func (s *TaskService) ListAssignedTasks(ctx context.Context, userID uuid.UUID, filters map[string]interface{}) ([]models.Task, error) {
+	assigned := make(map[string]interface{}, len(filters)+1)
+	for key, value := range filters {
+		assigned[key] = value
+	}
+	assigned["assignee"] = userID
+
+	return s.ListTasks(ctx, assigned)
+}
+
+// checkPeople verifies that every assignee and the reporter of a task are known users
+func (s *TaskService) checkPeople(ctx context.Context, task *models.Task) error {
+	seen := make(map[uuid.UUID]bool, len(task.Assignees))
+	for _, userID := range task.Assignees {
+		if seen[userID] {
+			return fmt.Errorf("%w: %s", ErrDuplicateAssignee, userID)
+		}
+		seen[userID] = true
+	}
+
+	if s.users == nil {
+		return nil
+	}
+
+	people := task.Assignees
+	if task.ReporterID != nil {
+		people = append(append([]uuid.UUID{}, people...), *task.ReporterID)
+	}
+	for _, userID := range people {
+		if err := s.checkUser(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkUser verifies that a user ID names a known user, accepting any ID until a user directory is set
+func (s *TaskService) checkUser(ctx context.Context, userID uuid.UUID) error {
+	if s.users == nil {
+		return nil
+	}
+
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	return nil
+}
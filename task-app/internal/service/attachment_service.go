@@ -0,0 +1,376 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+	ErrAttachmentTooLarge    = errors.New("attachment exceeds size limit")
+	ErrAttachmentTypeDenied  = errors.New("attachment type not allowed")
+	ErrAttachmentNameMissing = errors.New("attachment filename is required")
+)
+
+// attachmentsKept is the kind under which the attachments of deleted tasks are kept for undo
+const attachmentsKept = "attachments"
+
+// DefaultAttachmentLimits accepts files up to 10 MiB: common image formats, PDFs, archives and plain-text logs.
+var DefaultAttachmentLimits = models.AttachmentLimits{
+	MaxSize: 10 << 20,
+	AllowedTypes: []string{
+		"image/png",
+		"image/jpeg",
+		"image/gif",
+		"image/webp",
+		"application/pdf",
+		"application/zip",
+		"application/x-gzip",
+		"text/plain",
+	},
+}
+
+// AttachmentService stores files for tasks in a content-addressed blob store and removes blobs once
+// no attachment references them and no logged operation can restore an attachment that did.
+type AttachmentService struct {
+	mu          sync.Mutex
+	repo        repository.AttachmentRepository
+	blobs       repository.BlobStore
+	taskService *TaskService
+	limits      models.AttachmentLimits
+}
+
+// NewAttachmentService creates the service and registers it to remove the attachments of deleted tasks, to
+// copy them to deep copies and to keep them for undoing a delete.
+func NewAttachmentService(repo repository.AttachmentRepository, blobs repository.BlobStore, taskService *TaskService) *AttachmentService {
+	s := &AttachmentService{
+		repo:        repo,
+		blobs:       blobs,
+		taskService: taskService,
+		limits:      DefaultAttachmentLimits,
+	}
+	taskService.OnTaskDeleted(s.deleteTaskAttachments)
+	taskService.OnTaskDuplicated(s.copyTaskAttachments)
+	taskService.KeepOnDelete(attachmentsKept, s.keepTaskAttachments, s.restoreTaskAttachments)
+
+	return s
+}
+
+// SetLimits replaces the size and MIME-type limits applied to uploads.
+func (s *AttachmentService) SetLimits(limits models.AttachmentLimits) {
+	s.limits = limits
+}
+
+// Limits returns the size and MIME-type limits applied to uploads.
+func (s *AttachmentService) Limits() models.AttachmentLimits {
+	return s.limits
+}
+
+// Upload stores content as an attachment of a task. The MIME type is detected from the content
+// rather than trusted from the client.
+func (s *AttachmentService) Upload(ctx context.Context, taskID uuid.UUID, filename string, content io.Reader) (*models.Attachment, error) {
+	filename = filepath.Base(strings.TrimSpace(filename))
+	log.Printf("Uploading attachment: TaskID=%s, Filename=%s", taskID, filename)
+
+	if filename == "" || filename == "." || filename == string(filepath.Separator) {
+		return nil, ErrAttachmentNameMissing
+	}
+
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReaderSize(content, 512)
+	head, err := reader.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	contentType := detectContentType(head)
+	if !s.allowedType(contentType) {
+		log.Printf("Rejected attachment type: TaskID=%s, ContentType=%s", taskID, contentType)
+
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentTypeDenied, contentType)
+	}
+
+	// Hold the lock from storing the blob until it is referenced, so garbage collection cannot remove it in between
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash, size, err := s.blobs.Put(reader, s.limits.MaxSize)
+	if errors.Is(err, repository.ErrBlobTooLarge) {
+		return nil, fmt.Errorf("%w: %d bytes", ErrAttachmentTooLarge, s.limits.MaxSize)
+	}
+	if err != nil {
+		log.Printf("Failed to store attachment: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	attachment := &models.Attachment{
+		TaskID:      taskID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Hash:        hash,
+	}
+	if userID, ok := auth.UserFromContext(ctx); ok {
+		attachment.UploadedBy = &userID
+	}
+
+	if err := s.repo.Create(ctx, attachment); err != nil {
+		log.Printf("Failed to create attachment: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Attachment uploaded successfully: ID=%s, Hash=%s, Size=%d", attachment.ID, hash, size)
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) GetAttachment(ctx context.Context, taskID, id uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil || attachment.TaskID != taskID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+// Open returns an attachment together with a reader for its content; the caller must close it.
+func (s *AttachmentService) Open(ctx context.Context, taskID, id uuid.UUID) (*models.Attachment, io.ReadCloser, error) {
+	log.Printf("Opening attachment: TaskID=%s, ID=%s", taskID, id)
+
+	attachment, err := s.GetAttachment(ctx, taskID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(attachment.Hash)
+	if err != nil {
+		log.Printf("Failed to open blob: Hash=%s, Error=%v", attachment.Hash, err)
+
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, taskID uuid.UUID) ([]models.Attachment, error) {
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil {
+		log.Printf("Failed to list attachments: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Listed attachments successfully: TaskID=%s, Found %d attachments", taskID, len(attachments))
+
+	return attachments, nil
+}
+
+// DeleteAttachment removes an attachment and its blob when no other attachment shares it.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID, id uuid.UUID) error {
+	log.Printf("Deleting attachment: TaskID=%s, ID=%s", taskID, id)
+
+	attachment, err := s.GetAttachment(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete attachment: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	s.collect(ctx, []string{attachment.Hash})
+
+	log.Printf("Attachment deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// CollectGarbage deletes every stored blob that no attachment references, and that no logged operation can
+// restore an attachment for, and returns how many were removed.
+func (s *AttachmentService) CollectGarbage(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashes, err := s.blobs.List()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := s.collect(ctx, hashes)
+
+	log.Printf("Garbage collection finished: Checked %d blobs, Removed %d", len(hashes), removed)
+
+	return removed, nil
+}
+
+// collect deletes the given blobs that are no longer referenced; the caller must hold the lock
+func (s *AttachmentService) collect(ctx context.Context, hashes []string) int {
+	referenced, err := s.repo.ReferencedHashes(ctx)
+	if err != nil {
+		log.Printf("Failed to load referenced blobs: Error=%v", err)
+		return 0
+	}
+
+	// Undoing the delete of a task brings back its attachments, so their blobs stay
+	kept, err := s.taskService.KeptData(ctx, attachmentsKept)
+	if err != nil {
+		log.Printf("Failed to load kept attachments: Error=%v", err)
+		return 0
+	}
+	for _, data := range kept {
+		var attachments []models.Attachment
+		if err := json.Unmarshal(data, &attachments); err != nil {
+			log.Printf("Failed to read kept attachments: Error=%v", err)
+			return 0
+		}
+		for _, attachment := range attachments {
+			referenced[attachment.Hash] = true
+		}
+	}
+
+	removed := 0
+	for _, hash := range hashes {
+		if referenced[hash] {
+			continue
+		}
+		// Mark the hash so a blob listed twice is only deleted once
+		referenced[hash] = true
+		if err := s.blobs.Delete(hash); err != nil {
+			log.Printf("Failed to delete blob: Hash=%s, Error=%v", hash, err)
+			continue
+		}
+		removed++
+	}
+
+	return removed
+}
+
+// copyTaskAttachments attaches the files of a task to its deep copy. The copies share the blobs of the originals.
+func (s *AttachmentService) copyTaskAttachments(ctx context.Context, originalID, copyID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attachments, err := s.repo.ListByTask(ctx, originalID)
+	if err != nil {
+		log.Printf("Failed to copy attachments of task: TaskID=%s, Error=%v", originalID, err)
+		return err
+	}
+
+	for _, attachment := range attachments {
+		copied := attachment
+		copied.TaskID = copyID
+		if err := s.repo.Create(ctx, &copied); err != nil {
+			log.Printf("Failed to copy attachment: ID=%s, Error=%v", attachment.ID, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keepTaskAttachments returns the attachments of a task that is about to be deleted
+func (s *AttachmentService) keepTaskAttachments(ctx context.Context, taskID uuid.UUID) (interface{}, error) {
+	attachments, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil || len(attachments) == 0 {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// restoreTaskAttachments brings back the attachments kept for a restored task whose blobs are still stored
+func (s *AttachmentService) restoreTaskAttachments(ctx context.Context, taskID uuid.UUID, data json.RawMessage) error {
+	var attachments []models.Attachment
+	if err := json.Unmarshal(data, &attachments); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, attachment := range attachments {
+		content, err := s.blobs.Open(attachment.Hash)
+		if err != nil {
+			log.Printf("Attachment blob is gone: ID=%s, Hash=%s", attachment.ID, attachment.Hash)
+			continue
+		}
+		content.Close()
+
+		restored := attachment
+		restored.TaskID = taskID
+		if err := s.repo.Create(ctx, &restored); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteTaskAttachments removes the attachments of a deleted task and collects their blobs, unless a logged
+// operation keeps the attachments for undo
+func (s *AttachmentService) deleteTaskAttachments(ctx context.Context, taskID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted, err := s.repo.DeleteByTask(ctx, taskID)
+	if err != nil {
+		log.Printf("Failed to delete attachments of task: TaskID=%s, Error=%v", taskID, err)
+		return
+	}
+
+	hashes := make([]string, 0, len(deleted))
+	for _, attachment := range deleted {
+		hashes = append(hashes, attachment.Hash)
+	}
+	if changesFrom(ctx) == nil {
+		s.collect(ctx, hashes)
+	}
+}
+
+func (s *AttachmentService) allowedType(contentType string) bool {
+	for _, allowed := range s.limits.AllowedTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// detectContentType sniffs the MIME type of content from its first bytes, without parameters such as charset
+func detectContentType(head []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newAttachmentService(t *testing.T) (*AttachmentService, *TaskService, *repository.LocalBlobStore) {
+	blobs, err := repository.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewAttachmentService(repository.NewInMemoryAttachmentRepository(), blobs, taskService)
+
+	return service, taskService, blobs
+}
+
+func TestUploadAttachment(t *testing.T) {
+	service, taskService, _ := newAttachmentService(t)
+	service.SetLimits(models.AttachmentLimits{MaxSize: 64, AllowedTypes: []string{"image/png", "text/plain"}})
+	ctx := context.Background()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("With files"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		filename    string
+		content     []byte
+		contentType string
+		expectedErr error
+	}{
+		{
+			name:        "Screenshot",
+			filename:    "screen.png",
+			content:     append(pngHeader, "pixels"...),
+			contentType: "image/png",
+		},
+		{
+			name:        "Log",
+			filename:    "../../server.log",
+			content:     []byte("2024-01-01 started\n"),
+			contentType: "text/plain",
+		},
+		{
+			name:        "Too Large",
+			filename:    "big.log",
+			content:     bytes.Repeat([]byte("a"), 65),
+			expectedErr: ErrAttachmentTooLarge,
+		},
+		{
+			name:        "Type Not Allowed",
+			filename:    "doc.pdf",
+			content:     []byte("%PDF-1.4"),
+			expectedErr: ErrAttachmentTypeDenied,
+		},
+		{
+			name:        "Missing Filename",
+			filename:    " ",
+			content:     []byte("text"),
+			expectedErr: ErrAttachmentNameMissing,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attachment, err := service.Upload(ctx, task.ID, test.filename, bytes.NewReader(test.content))
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.contentType, attachment.ContentType)
+			assert.Equal(t, int64(len(test.content)), attachment.Size)
+			assert.NotContains(t, attachment.Filename, "/")
+		})
+	}
+
+	attachments, err := service.ListAttachments(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 2)
+
+	_, err = service.Upload(ctx, task.ID, "orphan.log", strings.NewReader("text"))
+	assert.NoError(t, err)
+	_, err = service.ListAttachments(ctx, uuid.New())
+	assert.Error(t, err)
+}
+
+func TestAttachmentDeduplicationAndGarbageCollection(t *testing.T) {
+	service, taskService, blobs := newAttachmentService(t)
+	ctx := context.Background()
+
+	first, err := taskService.CreateTask(ctx, newTaskRequest("First"))
+	assert.NoError(t, err)
+	second, err := taskService.CreateTask(ctx, newTaskRequest("Second"))
+	assert.NoError(t, err)
+
+	shared, err := service.Upload(ctx, first.ID, "crash.log", strings.NewReader("panic: boom"))
+	assert.NoError(t, err)
+	copied, err := service.Upload(ctx, second.ID, "crash-again.log", strings.NewReader("panic: boom"))
+	assert.NoError(t, err)
+	assert.Equal(t, shared.Hash, copied.Hash)
+
+	hashes, err := blobs.List()
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 1)
+
+	// The blob survives while another attachment still references it
+	assert.NoError(t, service.DeleteAttachment(ctx, first.ID, shared.ID))
+	_, content, err := service.Open(ctx, second.ID, copied.ID)
+	assert.NoError(t, err)
+	content.Close()
+
+	// Deleting the last referencing task removes the blob
+	assert.NoError(t, taskService.DeleteTask(ctx, second.ID))
+	hashes, err = blobs.List()
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+
+	_, _, err = blobs.Put(strings.NewReader("left over"), 100)
+	assert.NoError(t, err)
+	removed, err := service.CollectGarbage(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrInvalidChecklistOrder = errors.New("reorder must list every checklist item exactly once")
+)
+
+// SetChecklistAutoComplete controls whether checking the last open checklist item finishes the task.
+func (s *TaskService) SetChecklistAutoComplete(enabled bool) {
+	s.checklistAutoComplete = enabled
+}
+
+// TagLinks returns the lock held while a task's checklist or tags are read and written back. Services
+// changing the tags of tasks hold it too, so a task update never writes back tags changed meanwhile.
+func (s *TaskService) TagLinks() sync.Locker {
+	return &s.checklistMu
+}
+
+// AddChecklistItem inserts an item at position, or appends it when position is nil or past the end.
+func (s *TaskService) AddChecklistItem(ctx context.Context, id uuid.UUID, req models.AddChecklistItemRequest) (*models.Task, error) {
+	log.Printf("Adding checklist item: ID=%s", id)
+
+	return s.updateChecklist(ctx, id, func(items []models.ChecklistItem) ([]models.ChecklistItem, error) {
+		item := models.ChecklistItem{ID: uuid.New(), Text: req.Text}
+
+		position := len(items)
+		if req.Position != nil && *req.Position >= 0 && *req.Position < len(items) {
+			position = *req.Position
+		}
+
+		items = append(items[:position:position], append([]models.ChecklistItem{item}, items[position:]...)...)
+		return items, nil
+	})
+}
+
+// ToggleChecklistItem flips the done flag of an item.
+func (s *TaskService) ToggleChecklistItem(ctx context.Context, id, itemID uuid.UUID) (*models.Task, error) {
+	log.Printf("Toggling checklist item: ID=%s, ItemID=%s", id, itemID)
+
+	return s.updateChecklist(ctx, id, func(items []models.ChecklistItem) ([]models.ChecklistItem, error) {
+		for i := range items {
+			if items[i].ID == itemID {
+				items[i].Done = !items[i].Done
+				return items, nil
+			}
+		}
+		return nil, ErrChecklistItemNotFound
+	})
+}
+
+func (s *TaskService) RemoveChecklistItem(ctx context.Context, id, itemID uuid.UUID) (*models.Task, error) {
+	log.Printf("Removing checklist item: ID=%s, ItemID=%s", id, itemID)
+
+	return s.updateChecklist(ctx, id, func(items []models.ChecklistItem) ([]models.ChecklistItem, error) {
+		for i := range items {
+			if items[i].ID == itemID {
+				return append(items[:i:i], items[i+1:]...), nil
+			}
+		}
+		return nil, ErrChecklistItemNotFound
+	})
+}
+
+// ReorderChecklist puts the items of a checklist in the order of itemIDs, which must name each item once.
+func (s *TaskService) ReorderChecklist(ctx context.Context, id uuid.UUID, itemIDs []uuid.UUID) (*models.Task, error) {
+	log.Printf("Reordering checklist: ID=%s", id)
+
+	return s.updateChecklist(ctx, id, func(items []models.ChecklistItem) ([]models.ChecklistItem, error) {
+		if len(itemIDs) != len(items) {
+			return nil, ErrInvalidChecklistOrder
+		}
+
+		byID := make(map[uuid.UUID]models.ChecklistItem, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+
+		reordered := make([]models.ChecklistItem, 0, len(items))
+		for _, itemID := range itemIDs {
+			item, ok := byID[itemID]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidChecklistOrder, itemID)
+			}
+			delete(byID, itemID)
+			reordered = append(reordered, item)
+		}
+
+		return reordered, nil
+	})
+}
+
+// updateChecklist applies change to a copy of a task's checklist, renumbers the positions, validates and stores the result
+func (s *TaskService) updateChecklist(ctx context.Context, id uuid.UUID, change func([]models.ChecklistItem) ([]models.ChecklistItem, error)) (*models.Task, error) {
+	// Changes read and write the whole checklist, so concurrent ones must not interleave
+	s.checklistMu.Lock()
+	defer s.checklistMu.Unlock()
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := change(append([]models.ChecklistItem{}, task.Checklist...))
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Position = i
+	}
+
+	if err := s.validator.ValidateChecklist(items); err != nil {
+		log.Printf("Checklist validation failed: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	updated := *task
+	updated.Checklist = items
+	s.autoComplete(&updated)
+
+	if err := s.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to update checklist: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	if updated.Status != task.Status {
+		s.statusChanged(ctx, &updated)
+	}
+
+	log.Printf("Checklist updated successfully: ID=%s, Items=%d, Status=%s", id, len(items), updated.Status)
+
+	updated.ChecklistCompletion = checklistCompletion(updated.Checklist)
+
+	return &updated, nil
+}
+
+// autoComplete moves a task whose checklist is fully checked to the first terminal status of its workflow
+// that the task may move to, when enabled. The task keeps its status when no terminal status is allowed.
+func (s *TaskService) autoComplete(task *models.Task) {
+	if !s.checklistAutoComplete || len(task.Checklist) == 0 || s.isFinished(task) {
+		return
+	}
+
+	for _, item := range task.Checklist {
+		if !item.Done {
+			return
+		}
+	}
+
+	workflow := s.workflowFor(task.Category)
+	for _, status := range workflow.Statuses() {
+		if !workflow.IsTerminal(status) {
+			continue
+		}
+
+		finished := *task
+		finished.Status = status
+		if err := workflow.CheckTransition(task.Status, &finished); err != nil {
+			log.Printf("Checklist complete but task cannot move to %s: ID=%s, Error=%v", status, task.ID, err)
+			continue
+		}
+
+		log.Printf("Checklist complete, moving task to %s: ID=%s", status, task.ID)
+		task.Status = status
+		s.clearRemainingWork(task)
+		return
+	}
+}
+
+// checklistCompletion returns the fraction of checked items, or nil for a task without a checklist
+func checklistCompletion(items []models.ChecklistItem) *float64 {
+	if len(items) == 0 {
+		return nil
+	}
+
+	done := 0
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+
+	completion := float64(done) / float64(len(items))
+	return &completion
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func checklistTexts(task *models.Task) []string {
+	var texts []string
+	for _, item := range task.Checklist {
+		texts = append(texts, item.Text)
+	}
+	return texts
+}
+
+func TestChecklistItems(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Release"))
+	assert.NoError(t, err)
+
+	for _, text := range []string{"Tag", "Build", "Announce"} {
+		_, err := service.AddChecklistItem(ctx, task.ID, models.AddChecklistItemRequest{Text: text})
+		assert.NoError(t, err)
+	}
+
+	first := 0
+	updated, err := service.AddChecklistItem(ctx, task.ID, models.AddChecklistItemRequest{Text: "Freeze", Position: &first})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Freeze", "Tag", "Build", "Announce"}, checklistTexts(updated))
+	for i, item := range updated.Checklist {
+		assert.Equal(t, i, item.Position)
+	}
+
+	_, err = service.AddChecklistItem(ctx, task.ID, models.AddChecklistItemRequest{Text: "  "})
+	assert.Error(t, err)
+
+	items := updated.Checklist
+	tests := []struct {
+		name        string
+		order       []uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Missing Item",
+			order:       []uuid.UUID{items[0].ID, items[1].ID, items[2].ID},
+			expectedErr: ErrInvalidChecklistOrder,
+		},
+		{
+			name:        "Repeated Item",
+			order:       []uuid.UUID{items[0].ID, items[0].ID, items[1].ID, items[2].ID},
+			expectedErr: ErrInvalidChecklistOrder,
+		},
+		{
+			name:  "Reversed",
+			order: []uuid.UUID{items[3].ID, items[2].ID, items[1].ID, items[0].ID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.ReorderChecklist(ctx, task.ID, test.order)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	updated, err = service.ToggleChecklistItem(ctx, task.ID, items[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Announce", "Build", "Tag", "Freeze"}, checklistTexts(updated))
+	assert.InDelta(t, 0.25, *updated.ChecklistCompletion, 0.001)
+
+	updated, err = service.RemoveChecklistItem(ctx, task.ID, items[1].ID)
+	assert.NoError(t, err)
+	assert.Len(t, updated.Checklist, 3)
+
+	_, err = service.ToggleChecklistItem(ctx, task.ID, uuid.New())
+	assert.ErrorIs(t, err, ErrChecklistItemNotFound)
+
+	// Regular updates keep the checklist
+	stored, err := service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.0/3, *stored.ChecklistCompletion, 0.001)
+	stored.Checklist = nil
+	assert.NoError(t, service.UpdateTask(ctx, stored))
+	stored, err = service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, stored.Checklist, 3)
+
+	duplicated, err := service.DuplicateTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, checklistTexts(stored), checklistTexts(duplicated))
+	for _, item := range duplicated.Checklist {
+		assert.False(t, item.Done)
+	}
+}
+
+func TestChecklistAutoComplete(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		expected models.Status
+	}{
+		{name: "Enabled", enabled: true, expected: models.StatusDone},
+		{name: "Disabled", enabled: false, expected: models.StatusToDo},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewTaskService(repository.NewInMemoryTaskRepository())
+			service.SetChecklistAutoComplete(test.enabled)
+			ctx := context.Background()
+
+			task, err := service.CreateTask(ctx, newTaskRequest("Small"))
+			assert.NoError(t, err)
+
+			var itemIDs []uuid.UUID
+			for _, text := range []string{"One", "Two"} {
+				updated, err := service.AddChecklistItem(ctx, task.ID, models.AddChecklistItemRequest{Text: text})
+				assert.NoError(t, err)
+				itemIDs = append(itemIDs, updated.Checklist[len(updated.Checklist)-1].ID)
+			}
+
+			updated, err := service.ToggleChecklistItem(ctx, task.ID, itemIDs[0])
+			assert.NoError(t, err)
+			assert.Equal(t, models.StatusToDo, updated.Status)
+
+			updated, err = service.ToggleChecklistItem(ctx, task.ID, itemIDs[1])
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, updated.Status)
+		})
+	}
+}
+
+func TestChecklistAutoCompleteFollowsWorkflow(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	taskService.SetChecklistAutoComplete(true)
+	_, err := NewWorkflowService(repository.NewInMemoryWorkflowRepository(), taskService).CreateWorkflow(context.Background(), qaWorkflowRequest())
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		status   models.Status
+		expected models.Status
+	}{
+		{name: "Terminal status reachable", status: "IN_REVIEW", expected: "VERIFIED"},
+		{name: "Terminal status not reachable", status: models.StatusToDo, expected: models.StatusToDo},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task, err := taskService.CreateTask(ctx, models.CreateTaskRequest{
+				Title: "Review", Category: "QA", DueDate: time.Now().Add(24 * time.Hour), Priority: models.PriorityHigh, Status: test.status,
+			})
+			assert.NoError(t, err)
+
+			updated, err := taskService.AddChecklistItem(ctx, task.ID, models.AddChecklistItemRequest{Text: "Check"})
+			assert.NoError(t, err)
+
+			updated, err = taskService.ToggleChecklistItem(ctx, task.ID, updated.Checklist[0].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, updated.Status)
+		})
+	}
+}
+
+func TestConcurrentChecklistChanges(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Busy"))
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := service.AddChecklistItem(ctx, task.ID, models.AddChecklistItemRequest{Text: fmt.Sprintf("Item %d", i)})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	stored, err := service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, stored.Checklist, 20)
+}
+
+// slowTaskRepository returns tasks late so that concurrent changes of the same task overlap
+type slowTaskRepository struct {
+	repository.TaskRepository
+}
+
+func (r slowTaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	task, err := r.TaskRepository.GetByID(ctx, id)
+	time.Sleep(time.Millisecond)
+	return task, err
+}
+
+func TestConcurrentUpdatesKeepChecklistAndTags(t *testing.T) {
+	taskRepo := repository.NewInMemoryTaskRepository()
+	service := NewTaskService(slowTaskRepository{taskRepo})
+	tagService := NewTagService(repository.NewInMemoryTagRepository(), taskRepo)
+	tagService.SetTagLinks(service.TagLinks())
+	ctx := context.Background()
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Busy"))
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		tag, err := tagService.CreateTag(ctx, models.CreateTagRequest{Name: fmt.Sprintf("tag-%d", i)})
+		assert.NoError(t, err)
+
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			_, err := service.AddChecklistItem(ctx, task.ID, models.AddChecklistItemRequest{Text: fmt.Sprintf("Item %d", i)})
+			assert.NoError(t, err)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := tagService.AttachTag(ctx, task.ID, tag.ID)
+			assert.NoError(t, err)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			update := *task
+			update.Title = fmt.Sprintf("Busy %d", i)
+			assert.NoError(t, service.UpdateTask(ctx, &update))
+		}(i)
+	}
+	wg.Wait()
+
+	stored, err := service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, stored.Checklist, 20)
+	assert.Len(t, stored.Tags, 20)
+}
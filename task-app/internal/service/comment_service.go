@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultCommentPageSize = 20
+	MaxCommentPageSize     = 100
+)
+
+var (
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrCommentAuthorMissing = errors.New("comment author is required")
+	ErrNotCommentAuthor     = errors.New("only the author can change a comment")
+	ErrCommentAuthRequired  = errors.New("changing a comment requires an authenticated user")
+	ErrInvalidPagination    = errors.New("offset must not be negative and limit must be between 1 and 100")
+)
+
+// CommentService manages threaded comments on tasks.
+type CommentService struct {
+	repo        repository.CommentRepository
+	taskService *TaskService
+	validator   *utils.Validator
+}
+
+// NewCommentService creates the service and registers it to remove the comments of deleted tasks, to copy
+// them to deep copies and to keep them for undoing a delete.
+func NewCommentService(repo repository.CommentRepository, taskService *TaskService) *CommentService {
+	s := &CommentService{
+		repo:        repo,
+		taskService: taskService,
+		validator:   utils.NewValidator(),
+	}
+	taskService.OnTaskDeleted(s.deleteTaskComments)
+	taskService.OnTaskDuplicated(s.copyTaskComments)
+	taskService.KeepOnDelete("comments", s.keepTaskComments, s.restoreTaskComments)
+
+	return s
+}
+
+// CreateComment adds a comment to a task. The authenticated user is the author; anonymous requests
+// must name a known user through AuthorID. Replies must answer a comment on the same task.
+func (s *CommentService) CreateComment(ctx context.Context, taskID uuid.UUID, req models.CreateCommentRequest) (*models.Comment, error) {
+	comment := &models.Comment{
+		TaskID:   taskID,
+		ParentID: req.ParentID,
+		AuthorID: req.AuthorID,
+		Body:     req.Body,
+	}
+	if userID, ok := auth.UserFromContext(ctx); ok {
+		comment.AuthorID = userID
+	}
+
+	log.Printf("Creating comment: TaskID=%s, AuthorID=%s", taskID, comment.AuthorID)
+
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	if comment.AuthorID == uuid.Nil {
+		return nil, ErrCommentAuthorMissing
+	}
+
+	if err := s.taskService.checkUser(ctx, comment.AuthorID); err != nil {
+		log.Printf("Invalid comment author: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.validator.ValidateComment(comment); err != nil {
+		log.Printf("Comment validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if comment.ParentID != nil {
+		if _, err := s.getComment(ctx, taskID, *comment.ParentID); err != nil {
+			log.Printf("Invalid parent comment: %v", err)
+
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Create(ctx, comment); err != nil {
+		log.Printf("Failed to create comment: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Comment created successfully: ID=%s", comment.ID)
+	return comment, nil
+}
+
+// GetComment returns a comment with its replies nested beneath it.
+func (s *CommentService) GetComment(ctx context.Context, taskID, id uuid.UUID) (*models.Comment, error) {
+	log.Printf("Retrieving comment: TaskID=%s, ID=%s", taskID, id)
+
+	if _, err := s.getComment(ctx, taskID, id); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := replyIndex(comments)
+	for _, comment := range comments {
+		if comment.ID == id {
+			thread := buildThread(replies, comment)
+			return &thread, nil
+		}
+	}
+
+	return nil, ErrCommentNotFound
+}
+
+// ListComments returns a page of the top-level comments of a task, oldest first, with replies nested.
+func (s *CommentService) ListComments(ctx context.Context, taskID uuid.UUID, offset, limit int) (*models.CommentPage, error) {
+	log.Printf("Listing comments: TaskID=%s, Offset=%d, Limit=%d", taskID, offset, limit)
+
+	if offset < 0 || limit < 1 || limit > MaxCommentPageSize {
+		return nil, ErrInvalidPagination
+	}
+
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil {
+		log.Printf("Failed to list comments: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	replies := replyIndex(comments)
+	page := &models.CommentPage{Comments: []models.Comment{}, Offset: offset, Limit: limit}
+	for _, comment := range comments {
+		if comment.ParentID != nil {
+			continue
+		}
+		if page.Total >= offset && len(page.Comments) < limit {
+			page.Comments = append(page.Comments, buildThread(replies, comment))
+		}
+		page.Total++
+	}
+
+	log.Printf("Listed comments successfully: TaskID=%s, Returned %d of %d threads", taskID, len(page.Comments), page.Total)
+
+	return page, nil
+}
+
+// UpdateComment replaces the body of a comment, keeping the previous body in its edit history.
+func (s *CommentService) UpdateComment(ctx context.Context, taskID, id uuid.UUID, req models.UpdateCommentRequest) (*models.Comment, error) {
+	log.Printf("Updating comment: TaskID=%s, ID=%s", taskID, id)
+
+	existing, err := s.getComment(ctx, taskID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCommentAuthor(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	updated.Body = req.Body
+	updated.Replies = nil
+	if err := s.validator.ValidateComment(&updated); err != nil {
+		log.Printf("Comment validation failed: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	if updated.Body == existing.Body {
+		return existing, nil
+	}
+
+	updated.Edits = append(append([]models.CommentEdit{}, existing.Edits...), models.CommentEdit{
+		Body:     existing.Body,
+		EditedAt: time.Now(),
+	})
+
+	if err := s.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to update comment: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	log.Printf("Comment updated successfully: ID=%s, Edits=%d", id, len(updated.Edits))
+
+	return &updated, nil
+}
+
+// DeleteComment removes a comment and all replies beneath it.
+func (s *CommentService) DeleteComment(ctx context.Context, taskID, id uuid.UUID) error {
+	log.Printf("Deleting comment: TaskID=%s, ID=%s", taskID, id)
+
+	existing, err := s.getComment(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := checkCommentAuthor(ctx, existing); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete comment: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("Comment deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// deleteTaskComments removes every comment of a deleted task
+func (s *CommentService) deleteTaskComments(ctx context.Context, taskID uuid.UUID) {
+	if _, err := s.repo.DeleteByTask(ctx, taskID); err != nil {
+		log.Printf("Failed to delete comments of task: TaskID=%s, Error=%v", taskID, err)
+	}
+}
+
+// copyTaskComments copies the comments of a task to its deep copy, keeping replies under their copied parents
+func (s *CommentService) copyTaskComments(ctx context.Context, originalID, copyID uuid.UUID) error {
+	comments, err := s.repo.ListByTask(ctx, originalID)
+	if err != nil {
+		log.Printf("Failed to copy comments of task: TaskID=%s, Error=%v", originalID, err)
+		return err
+	}
+
+	return s.createCopies(ctx, copyID, comments)
+}
+
+// keepTaskComments returns the comments of a task that is about to be deleted
+func (s *CommentService) keepTaskComments(ctx context.Context, taskID uuid.UUID) (interface{}, error) {
+	comments, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil || len(comments) == 0 {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// restoreTaskComments brings back the comments kept for a restored task
+func (s *CommentService) restoreTaskComments(ctx context.Context, taskID uuid.UUID, data json.RawMessage) error {
+	var comments []models.Comment
+	if err := json.Unmarshal(data, &comments); err != nil {
+		return err
+	}
+
+	return s.createCopies(ctx, taskID, comments)
+}
+
+// createCopies creates copies of comments on a task, keeping replies under their copied parents
+func (s *CommentService) createCopies(ctx context.Context, taskID uuid.UUID, comments []models.Comment) error {
+	// Comments are listed oldest first, so a parent is always copied before its replies
+	copyIDs := make(map[uuid.UUID]uuid.UUID, len(comments))
+	for _, comment := range comments {
+		copied := &models.Comment{
+			TaskID:   taskID,
+			AuthorID: comment.AuthorID,
+			Body:     comment.Body,
+			Edits:    append([]models.CommentEdit(nil), comment.Edits...),
+		}
+		if comment.ParentID != nil {
+			parentID := copyIDs[*comment.ParentID]
+			copied.ParentID = &parentID
+		}
+
+		if err := s.repo.Create(ctx, copied); err != nil {
+			log.Printf("Failed to copy comment: ID=%s, Error=%v", comment.ID, err)
+			return err
+		}
+		copyIDs[comment.ID] = copied.ID
+	}
+
+	return nil
+}
+
+// getComment loads a comment and checks that it belongs to the task
+func (s *CommentService) getComment(ctx context.Context, taskID, id uuid.UUID) (*models.Comment, error) {
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil || comment.TaskID != taskID {
+		return nil, ErrCommentNotFound
+	}
+
+	return comment, nil
+}
+
+// checkCommentAuthor rejects changes by anyone but the authenticated author
+func checkCommentAuthor(ctx context.Context, comment *models.Comment) error {
+	userID, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return ErrCommentAuthRequired
+	}
+	if userID != comment.AuthorID {
+		return ErrNotCommentAuthor
+	}
+
+	return nil
+}
+
+// replyIndex groups comments by the comment they reply to
+func replyIndex(comments []models.Comment) map[uuid.UUID][]models.Comment {
+	index := make(map[uuid.UUID][]models.Comment)
+	for _, comment := range comments {
+		if comment.ParentID != nil {
+			index[*comment.ParentID] = append(index[*comment.ParentID], comment)
+		}
+	}
+
+	return index
+}
+
+// buildThread nests the replies of a comment beneath it, recursively
+func buildThread(index map[uuid.UUID][]models.Comment, comment models.Comment) models.Comment {
+	comment.Replies = nil
+	for _, reply := range index[comment.ID] {
+		comment.Replies = append(comment.Replies, buildThread(index, reply))
+	}
+
+	return comment
+}
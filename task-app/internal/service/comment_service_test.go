@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateComment(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewCommentService(repository.NewInMemoryCommentRepository(), taskService)
+	ctx := context.Background()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Discussed"))
+	assert.NoError(t, err)
+	otherTask, err := taskService.CreateTask(ctx, newTaskRequest("Other"))
+	assert.NoError(t, err)
+
+	author := uuid.New()
+	root, err := service.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Root", AuthorID: author})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		taskID      uuid.UUID
+		req         models.CreateCommentRequest
+		expectedErr error
+	}{
+		{
+			name:   "Reply",
+			taskID: task.ID,
+			req:    models.CreateCommentRequest{Body: "Reply", ParentID: &root.ID, AuthorID: author},
+		},
+		{
+			name:        "Missing Author",
+			taskID:      task.ID,
+			req:         models.CreateCommentRequest{Body: "Anonymous"},
+			expectedErr: ErrCommentAuthorMissing,
+		},
+		{
+			name:        "Reply To Comment On Another Task",
+			taskID:      otherTask.ID,
+			req:         models.CreateCommentRequest{Body: "Misplaced", ParentID: &root.ID, AuthorID: author},
+			expectedErr: ErrCommentNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.CreateComment(ctx, test.taskID, test.req)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	_, err = service.CreateComment(ctx, uuid.New(), models.CreateCommentRequest{Body: "Nowhere", AuthorID: author})
+	assert.Error(t, err)
+
+	// The authenticated user takes precedence over author_id
+	authenticated := uuid.New()
+	comment, err := service.CreateComment(auth.WithUser(ctx, authenticated), task.ID, models.CreateCommentRequest{Body: "Mine", AuthorID: author})
+	assert.NoError(t, err)
+	assert.Equal(t, authenticated, comment.AuthorID)
+
+	// Once users are known, anonymous comments must name one of them
+	users := repository.NewInMemoryUserRepository()
+	user := &models.User{Name: "Jane Doe", Email: "jane@example.com"}
+	assert.NoError(t, users.Create(ctx, user))
+	taskService.SetUserRepository(users)
+
+	_, err = service.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Impostor", AuthorID: author})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	_, err = service.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Known", AuthorID: user.ID})
+	assert.NoError(t, err)
+}
+
+func TestCommentThreadsAndPagination(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewCommentService(repository.NewInMemoryCommentRepository(), taskService)
+	ctx := context.Background()
+	author := uuid.New()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Busy"))
+	assert.NoError(t, err)
+
+	var roots []*models.Comment
+	for i := 0; i < 5; i++ {
+		root, err := service.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: fmt.Sprintf("Comment %d", i), AuthorID: author})
+		assert.NoError(t, err)
+		roots = append(roots, root)
+	}
+	reply, err := service.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Reply", ParentID: &roots[0].ID, AuthorID: author})
+	assert.NoError(t, err)
+	_, err = service.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Nested", ParentID: &reply.ID, AuthorID: author})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		offset   int
+		limit    int
+		expected int
+		hasError bool
+	}{
+		{name: "First Page", offset: 0, limit: 2, expected: 2},
+		{name: "Last Page", offset: 4, limit: 2, expected: 1},
+		{name: "Past The End", offset: 10, limit: 2, expected: 0},
+		{name: "Zero Limit", offset: 0, limit: 0, hasError: true},
+		{name: "Negative Offset", offset: -1, limit: 2, hasError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page, err := service.ListComments(ctx, task.ID, test.offset, test.limit)
+			if test.hasError {
+				assert.ErrorIs(t, err, ErrInvalidPagination)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 5, page.Total)
+			assert.Len(t, page.Comments, test.expected)
+		})
+	}
+
+	page, err := service.ListComments(ctx, task.ID, 0, 1)
+	assert.NoError(t, err)
+	assert.Len(t, page.Comments[0].Replies, 1)
+	assert.Len(t, page.Comments[0].Replies[0].Replies, 1)
+
+	// Deleting the task removes its comments
+	assert.NoError(t, taskService.DeleteTask(ctx, task.ID))
+	_, err = service.GetComment(ctx, task.ID, roots[0].ID)
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+}
+
+func TestUpdateComment(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewCommentService(repository.NewInMemoryCommentRepository(), taskService)
+	ctx := context.Background()
+	author := uuid.New()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Edited"))
+	assert.NoError(t, err)
+	comment, err := service.CreateComment(auth.WithUser(ctx, author), task.ID, models.CreateCommentRequest{Body: "Draft"})
+	assert.NoError(t, err)
+
+	updated, err := service.UpdateComment(auth.WithUser(ctx, author), task.ID, comment.ID, models.UpdateCommentRequest{Body: "Final"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Final", updated.Body)
+	assert.Len(t, updated.Edits, 1)
+	assert.Equal(t, "Draft", updated.Edits[0].Body)
+
+	_, err = service.UpdateComment(auth.WithUser(ctx, uuid.New()), task.ID, comment.ID, models.UpdateCommentRequest{Body: "Hijacked"})
+	assert.ErrorIs(t, err, ErrNotCommentAuthor)
+
+	_, err = service.UpdateComment(ctx, task.ID, comment.ID, models.UpdateCommentRequest{Body: "Anonymous"})
+	assert.ErrorIs(t, err, ErrCommentAuthRequired)
+
+	_, err = service.UpdateComment(auth.WithUser(ctx, author), task.ID, comment.ID, models.UpdateCommentRequest{Body: " "})
+	assert.Error(t, err)
+
+	assert.ErrorIs(t, service.DeleteComment(ctx, task.ID, comment.ID), ErrCommentAuthRequired)
+	assert.ErrorIs(t, service.DeleteComment(auth.WithUser(ctx, uuid.New()), task.ID, comment.ID), ErrNotCommentAuthor)
+	assert.NoError(t, service.DeleteComment(auth.WithUser(ctx, author), task.ID, comment.ID))
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCustomFieldKeyTaken  = errors.New("custom field key already in use in project")
+	ErrCustomFieldImmutable = errors.New("custom field key and type cannot be changed")
+	ErrCustomFieldNotFound  = errors.New("custom field not found")
+)
+
+// CustomFieldService manages the custom fields defined per project. Values live on the tasks, keyed by the
+// field's key, and are validated by the task service against the fields of the task's project.
+type CustomFieldService struct {
+	mu        sync.Mutex
+	repo      repository.CustomFieldRepository
+	projects  repository.ProjectRepository
+	taskRepo  repository.TaskRepository
+	validator *utils.Validator
+}
+
+func NewCustomFieldService(repo repository.CustomFieldRepository, projects repository.ProjectRepository, taskRepo repository.TaskRepository) *CustomFieldService {
+	return &CustomFieldService{
+		repo:      repo,
+		projects:  projects,
+		taskRepo:  taskRepo,
+		validator: utils.NewValidator(),
+	}
+}
+
+func (s *CustomFieldService) CreateField(ctx context.Context, projectID uuid.UUID, req models.CreateCustomFieldRequest) (*models.CustomFieldDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	def := &models.CustomFieldDefinition{
+		ProjectID: projectID,
+		Key:       req.Key,
+		Name:      req.Name,
+		Type:      req.Type,
+		Options:   req.Options,
+		Required:  req.Required,
+	}
+
+	log.Printf("Creating custom field: ProjectID=%s, Key=%s, Type=%s", projectID, def.Key, def.Type)
+
+	if _, err := s.projects.GetByID(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+
+	if err := s.validateField(ctx, def); err != nil {
+		log.Printf("Custom field validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, def); err != nil {
+		log.Printf("Failed to create custom field: Key=%s, Error=%v", def.Key, err)
+
+		return nil, err
+	}
+
+	log.Printf("Custom field created successfully: ID=%s", def.ID)
+
+	return def, nil
+}
+
+func (s *CustomFieldService) ListFields(ctx context.Context, projectID uuid.UUID) ([]models.CustomFieldDefinition, error) {
+	log.Printf("Listing custom fields: ProjectID=%s", projectID)
+
+	if _, err := s.projects.GetByID(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+
+	return s.repo.ListByProject(ctx, projectID)
+}
+
+func (s *CustomFieldService) GetField(ctx context.Context, projectID, id uuid.UUID) (*models.CustomFieldDefinition, error) {
+	log.Printf("Fetching custom field: ProjectID=%s, ID=%s", projectID, id)
+
+	return s.getField(ctx, projectID, id)
+}
+
+// UpdateField changes the name, options or required flag of a custom field. Values already stored on tasks
+// are checked against the new definition the next time those tasks are saved.
+func (s *CustomFieldService) UpdateField(ctx context.Context, def *models.CustomFieldDefinition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Updating custom field: ProjectID=%s, ID=%s", def.ProjectID, def.ID)
+
+	existing, err := s.getField(ctx, def.ProjectID, def.ID)
+	if err != nil {
+		return err
+	}
+
+	if def.Key != existing.Key || def.Type != existing.Type {
+		return ErrCustomFieldImmutable
+	}
+
+	if err := s.validateField(ctx, def); err != nil {
+		log.Printf("Custom field validation failed: ID=%s, Error=%v", def.ID, err)
+		return err
+	}
+
+	if err := s.repo.Update(ctx, def); err != nil {
+		log.Printf("Failed to update custom field: ID=%s, Error=%v", def.ID, err)
+
+		return err
+	}
+
+	log.Printf("Custom field updated successfully: ID=%s", def.ID)
+
+	return nil
+}
+
+// DeleteField deletes a custom field and removes its values from the tasks of the project.
+func (s *CustomFieldService) DeleteField(ctx context.Context, projectID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Deleting custom field: ProjectID=%s, ID=%s", projectID, id)
+
+	def, err := s.getField(ctx, projectID, id)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := s.taskRepo.List(ctx, map[string]interface{}{"project_id": projectID})
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if _, ok := task.CustomFields[def.Key]; !ok {
+			continue
+		}
+
+		delete(task.CustomFields, def.Key)
+		if len(task.CustomFields) == 0 {
+			task.CustomFields = nil
+		}
+		if err := s.taskRepo.Update(ctx, &task); err != nil {
+			log.Printf("Failed to remove custom field value: TaskID=%s, Error=%v", task.ID, err)
+
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete custom field: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("Custom field deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// getField loads a custom field, treating fields of other projects as missing
+func (s *CustomFieldService) getField(ctx context.Context, projectID, id uuid.UUID) (*models.CustomFieldDefinition, error) {
+	def, err := s.repo.GetByID(ctx, id)
+	if err != nil || def.ProjectID != projectID {
+		return nil, fmt.Errorf("%w: %s", ErrCustomFieldNotFound, id)
+	}
+
+	return def, nil
+}
+
+// validateField checks the definition and that its key is unique within the project
+func (s *CustomFieldService) validateField(ctx context.Context, def *models.CustomFieldDefinition) error {
+	if err := s.validator.ValidateCustomFieldDefinition(def); err != nil {
+		return err
+	}
+
+	defs, err := s.repo.ListByProject(ctx, def.ProjectID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range defs {
+		if existing.Key == def.Key && existing.ID != def.ID {
+			return fmt.Errorf("%w: %s", ErrCustomFieldKeyTaken, def.Key)
+		}
+	}
+
+	return nil
+}
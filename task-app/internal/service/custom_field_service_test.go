@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCustomField(t *testing.T) {
+	projectService, taskService, taskRepo := newProjectServices()
+	customFieldRepo := repository.NewInMemoryCustomFieldRepository()
+	taskService.SetCustomFieldRepository(customFieldRepo)
+	service := NewCustomFieldService(customFieldRepo, projectService.repo, taskRepo)
+	ctx := context.Background()
+
+	ops, err := projectService.CreateProject(ctx, models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+
+	_, err = service.CreateField(ctx, ops.ID, models.CreateCustomFieldRequest{Key: "severity", Name: "Severity", Type: models.CustomFieldEnum, Options: []string{"Low", "High"}})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		projectID   uuid.UUID
+		req         models.CreateCustomFieldRequest
+		expectedErr error
+	}{
+		{"Valid Field", ops.ID, models.CreateCustomFieldRequest{Key: "customer", Name: "Customer", Type: models.CustomFieldText}, nil},
+		{"Duplicate Key", ops.ID, models.CreateCustomFieldRequest{Key: "severity", Name: "Severity", Type: models.CustomFieldText}, ErrCustomFieldKeyTaken},
+		{"Unknown Project", uuid.New(), models.CreateCustomFieldRequest{Key: "customer", Name: "Customer", Type: models.CustomFieldText}, ErrProjectNotFound},
+		{"Invalid Type", ops.ID, models.CreateCustomFieldRequest{Key: "env", Name: "Environment", Type: "LIST"}, utils.ErrInvalidCustomFieldType},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			def, err := service.CreateField(ctx, test.projectID, test.req)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, def.ID)
+		})
+	}
+}
+
+func TestTaskCustomFields(t *testing.T) {
+	projectService, taskService, taskRepo := newProjectServices()
+	customFieldRepo := repository.NewInMemoryCustomFieldRepository()
+	taskService.SetCustomFieldRepository(customFieldRepo)
+	service := NewCustomFieldService(customFieldRepo, projectService.repo, taskRepo)
+	ctx := context.Background()
+
+	ops, err := projectService.CreateProject(ctx, models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+	_, err = projectService.CreateProject(ctx, models.CreateProjectRequest{Name: "Sales"})
+	assert.NoError(t, err)
+
+	severity, err := service.CreateField(ctx, ops.ID, models.CreateCustomFieldRequest{Key: "severity", Name: "Severity", Type: models.CustomFieldEnum, Options: []string{"Low", "Medium", "High"}})
+	assert.NoError(t, err)
+	_, err = service.CreateField(ctx, ops.ID, models.CreateCustomFieldRequest{Key: "impact", Name: "Impact", Type: models.CustomFieldNumber})
+	assert.NoError(t, err)
+
+	create := func(title string, values map[string]interface{}) *models.Task {
+		req := newTaskRequest(title)
+		req.Category = "Ops"
+		req.CustomFields = values
+		task, err := taskService.CreateTask(ctx, req)
+		assert.NoError(t, err)
+		return task
+	}
+
+	outage := create("Outage", map[string]interface{}{"severity": "high", "impact": 10.0})
+	create("Slow page", map[string]interface{}{"severity": "Low", "impact": 2.0})
+	create("Typo", nil)
+	assert.Equal(t, "High", outage.CustomFields["severity"])
+
+	t.Run("Fields Of Another Project", func(t *testing.T) {
+		req := newTaskRequest("Call back")
+		req.Category = "Sales"
+		req.CustomFields = map[string]interface{}{"severity": "High"}
+		_, err := taskService.CreateTask(ctx, req)
+		assert.ErrorIs(t, err, utils.ErrUnknownCustomField)
+	})
+
+	t.Run("Invalid Value On Update", func(t *testing.T) {
+		task, err := taskService.GetTask(ctx, outage.ID)
+		assert.NoError(t, err)
+		task.CustomFields["severity"] = "Critical"
+		assert.ErrorIs(t, taskService.UpdateTask(ctx, task), utils.ErrInvalidCustomFieldValue)
+	})
+
+	t.Run("Filter And Sort", func(t *testing.T) {
+		filters, err := ParseTaskFilters(map[string]string{"cf.severity": "HIGH"})
+		assert.NoError(t, err)
+		tasks, err := taskService.ListTasks(ctx, filters)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, outage.ID, tasks[0].ID)
+
+		filters, err = ParseTaskFilters(map[string]string{"cf.impact": "2"})
+		assert.NoError(t, err)
+		tasks, err = taskService.ListTasks(ctx, filters)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+
+		tasks, err = taskService.ListTasks(ctx, map[string]interface{}{})
+		assert.NoError(t, err)
+		assert.NoError(t, SortTasks(tasks, "-cf.impact"))
+		titles := []string{tasks[0].Title, tasks[1].Title, tasks[2].Title}
+		assert.Equal(t, []string{"Outage", "Slow page", "Typo"}, titles)
+	})
+
+	t.Run("Required Field Added Later", func(t *testing.T) {
+		_, err := service.CreateField(ctx, ops.ID, models.CreateCustomFieldRequest{Key: "owner_team", Name: "Owner team", Type: models.CustomFieldText, Required: true})
+		assert.NoError(t, err)
+
+		// Existing tasks can still be saved without the new field
+		task, err := taskService.GetTask(ctx, outage.ID)
+		assert.NoError(t, err)
+		task.Title = "Major outage"
+		assert.NoError(t, taskService.UpdateTask(ctx, task))
+
+		// Updates including the field must set it, and new tasks need it
+		cleared := *task
+		cleared.CustomFields = map[string]interface{}{"severity": "High", "impact": 10.0, "owner_team": nil}
+		assert.ErrorIs(t, taskService.UpdateTask(ctx, &cleared), utils.ErrCustomFieldRequired)
+
+		req := newTaskRequest("Disk full")
+		req.Category = "Ops"
+		_, err = taskService.CreateTask(ctx, req)
+		assert.ErrorIs(t, err, utils.ErrCustomFieldRequired)
+
+		req.CustomFields = map[string]interface{}{"owner_team": "Platform"}
+		disk, err := taskService.CreateTask(ctx, req)
+		assert.NoError(t, err)
+		assert.NoError(t, taskService.DeleteTask(ctx, disk.ID))
+	})
+
+	t.Run("Key And Type Are Immutable", func(t *testing.T) {
+		changed := *severity
+		changed.Type = models.CustomFieldText
+		changed.Options = nil
+		assert.ErrorIs(t, service.UpdateField(ctx, &changed), ErrCustomFieldImmutable)
+	})
+
+	t.Run("Delete Removes Values", func(t *testing.T) {
+		assert.NoError(t, service.DeleteField(ctx, ops.ID, severity.ID))
+
+		task, err := taskService.GetTask(ctx, outage.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"impact": 10.0}, task.CustomFields)
+	})
+
+	t.Run("Update Without Custom Fields Keeps Values", func(t *testing.T) {
+		update := func(values map[string]interface{}) error {
+			task, err := taskService.GetTask(ctx, outage.ID)
+			assert.NoError(t, err)
+			task.CustomFields = values
+			return taskService.UpdateTask(ctx, task)
+		}
+		stored := func() map[string]interface{} {
+			task, err := taskService.GetTask(ctx, outage.ID)
+			assert.NoError(t, err)
+			return task.CustomFields
+		}
+
+		assert.NoError(t, update(nil))
+		assert.Equal(t, map[string]interface{}{"impact": 10.0}, stored())
+
+		assert.NoError(t, update(map[string]interface{}{"owner_team": "Platform"}))
+		assert.NoError(t, update(nil))
+		assert.Equal(t, map[string]interface{}{"impact": 10.0, "owner_team": "Platform"}, stored())
+
+		// Only an explicit null clears a value, and required values cannot be cleared
+		assert.ErrorIs(t, update(map[string]interface{}{"owner_team": nil}), utils.ErrCustomFieldRequired)
+		assert.NoError(t, update(map[string]interface{}{"impact": nil}))
+		assert.Equal(t, map[string]interface{}{"owner_team": "Platform"}, stored())
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// SetCustomFieldRepository lets tasks carry the custom fields defined for their project.
+// Until one is set tasks cannot have custom fields.
+func (s *TaskService) SetCustomFieldRepository(customFields repository.CustomFieldRepository) {
+	s.customFields = customFields
+}
+
+// applyCustomFields validates and normalizes the custom field values of a task against the fields of its
+// project, and checks that user fields refer to existing users. Required fields must be set on new tasks;
+// existing tasks only need them when they hold the field or the update includes it, so tasks created
+// before a field became required can still be saved but a required value cannot be cleared.
+func (s *TaskService) applyCustomFields(ctx context.Context, task *models.Task, creating bool) error {
+	var defs []models.CustomFieldDefinition
+	if s.customFields != nil && task.ProjectID != nil {
+		var err error
+		defs, err = s.customFields.ListByProject(ctx, *task.ProjectID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !creating {
+		for i := range defs {
+			if _, included := task.CustomFields[defs[i].Key]; !included {
+				defs[i].Required = false
+			}
+		}
+	}
+
+	values, err := s.validator.ValidateCustomFields(task.CustomFields, defs)
+	if err != nil {
+		return err
+	}
+
+	if s.users != nil {
+		for _, def := range defs {
+			userID, ok := values[def.Key].(string)
+			if def.Type != models.CustomFieldUser || !ok {
+				continue
+			}
+			if _, err := s.users.GetByID(ctx, uuid.MustParse(userID)); err != nil {
+				return fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+			}
+		}
+	}
+
+	task.CustomFields = values
+
+	return nil
+}
+
+// mergeCustomFields lays the custom field values of an update over those stored for the task, so fields
+// the update leaves out keep their values and fields it sets to null are cleared. Stored values only carry
+// over while the task stays in the same project, whose fields they belong to.
+func mergeCustomFields(existing, task *models.Task) map[string]interface{} {
+	sameProject := (existing.ProjectID == nil && task.ProjectID == nil) ||
+		(existing.ProjectID != nil && task.ProjectID != nil && *existing.ProjectID == *task.ProjectID)
+	if !sameProject || len(existing.CustomFields) == 0 {
+		return task.CustomFields
+	}
+
+	merged := make(map[string]interface{}, len(existing.CustomFields)+len(task.CustomFields))
+	for key, value := range existing.CustomFields {
+		merged[key] = value
+	}
+	for key, value := range task.CustomFields {
+		merged[key] = value
+	}
+
+	return merged
+}
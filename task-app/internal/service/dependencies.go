@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSelfDependency     = errors.New("task cannot be blocked by itself")
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrDependencyExists   = errors.New("dependency already exists")
+	ErrDependencyNotFound = errors.New("dependency not found")
+)
+
+// ListDependencies returns the tasks blocking the given task.
+func (s *TaskService) ListDependencies(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	log.Printf("Listing dependencies of task: ID=%s", id)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers := make([]models.Task, 0, len(task.BlockedBy))
+	for _, blockerID := range task.BlockedBy {
+		blocker, err := s.repo.GetByID(ctx, blockerID)
+		if err != nil {
+			return nil, err
+		}
+		blockers = append(blockers, *blocker)
+	}
+
+	return blockers, nil
+}
+
+// AddDependency records that id is blocked by blockerID and moves the task to BLOCKED while the blocker is unfinished.
+func (s *TaskService) AddDependency(ctx context.Context, id, blockerID uuid.UUID) (*models.Task, error) {
+	log.Printf("Adding dependency: ID=%s, BlockedBy=%s", id, blockerID)
+
+	if id == blockerID {
+		return nil, ErrSelfDependency
+	}
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(ctx, blockerID); err != nil {
+		return nil, err
+	}
+
+	for _, existing := range task.BlockedBy {
+		if existing == blockerID {
+			return nil, ErrDependencyExists
+		}
+	}
+
+	// Adding the edge creates a cycle exactly when the blocker already (transitively) depends on the task
+	reaches, err := s.dependsOn(ctx, blockerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if reaches {
+		log.Printf("Rejected dependency cycle: ID=%s, BlockedBy=%s", id, blockerID)
+		return nil, ErrDependencyCycle
+	}
+
+	updated := *task
+	updated.BlockedBy = append(append([]uuid.UUID{}, task.BlockedBy...), blockerID)
+
+	if err := s.applyBlockedStatus(ctx, &updated, false); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to add dependency: ID=%s, Error=%v", id, err)
+		return nil, err
+	}
+
+	log.Printf("Dependency added successfully: ID=%s, BlockedBy=%s, Status=%s", id, blockerID, updated.Status)
+
+	return &updated, nil
+}
+
+// RemoveDependency deletes the edge between id and blockerID and unblocks the task once nothing unfinished blocks it.
+func (s *TaskService) RemoveDependency(ctx context.Context, id, blockerID uuid.UUID) (*models.Task, error) {
+	log.Printf("Removing dependency: ID=%s, BlockedBy=%s", id, blockerID)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *task
+	updated.BlockedBy = make([]uuid.UUID, 0, len(task.BlockedBy))
+	for _, existing := range task.BlockedBy {
+		if existing != blockerID {
+			updated.BlockedBy = append(updated.BlockedBy, existing)
+		}
+	}
+
+	if len(updated.BlockedBy) == len(task.BlockedBy) {
+		return nil, ErrDependencyNotFound
+	}
+
+	if err := s.applyBlockedStatus(ctx, &updated, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to remove dependency: ID=%s, Error=%v", id, err)
+		return nil, err
+	}
+
+	log.Printf("Dependency removed successfully: ID=%s, BlockedBy=%s, Status=%s", id, blockerID, updated.Status)
+
+	return &updated, nil
+}
+
+// PlanExecution topologically sorts the tasks matching filters and finds the longest dependency chain among them.
+func (s *TaskService) PlanExecution(ctx context.Context, filters map[string]interface{}) (*models.ExecutionPlan, error) {
+	log.Printf("Planning execution order with filters: %+v", filters)
+
+	tasks, err := s.repo.List(ctx, s.withFinished(filters))
+	if err != nil {
+		return nil, err
+	}
+
+	// Deterministic tie-breaking: earliest due date first, then highest priority
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].DueDate.Equal(tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(tasks[j].DueDate)
+		}
+		return priorityRank[tasks[i].Priority] > priorityRank[tasks[j].Priority]
+	})
+
+	index := make(map[uuid.UUID]int, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = i
+	}
+
+	inDegree := make([]int, len(tasks))
+	dependents := make([][]int, len(tasks))
+	for i, task := range tasks {
+		for _, blockerID := range task.BlockedBy {
+			if j, ok := index[blockerID]; ok {
+				inDegree[i]++
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	var ready []int
+	for i := range tasks {
+		if inDegree[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	plan := &models.ExecutionPlan{Order: make([]models.Task, 0, len(tasks))}
+	length := make([]int, len(tasks))
+	previous := make([]int, len(tasks))
+	for i := range previous {
+		previous[i] = -1
+		length[i] = 1
+	}
+
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		current := ready[0]
+		ready = ready[1:]
+		plan.Order = append(plan.Order, tasks[current])
+
+		for _, next := range dependents[current] {
+			if length[current]+1 > length[next] {
+				length[next] = length[current] + 1
+				previous[next] = current
+			}
+
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	if len(plan.Order) != len(tasks) {
+		return nil, ErrDependencyCycle
+	}
+
+	end := -1
+	for i := range tasks {
+		if end == -1 || length[i] > length[end] {
+			end = i
+		}
+	}
+	for current := end; current != -1; current = previous[current] {
+		plan.CriticalPath = append([]models.Task{tasks[current]}, plan.CriticalPath...)
+	}
+	if plan.CriticalPath == nil {
+		plan.CriticalPath = []models.Task{}
+	}
+
+	log.Printf("Execution plan computed: %d tasks, critical path of %d tasks", len(plan.Order), len(plan.CriticalPath))
+
+	return plan, nil
+}
+
+// dependsOn reports whether from is transitively blocked by target
+func (s *TaskService) dependsOn(ctx context.Context, from, target uuid.UUID) (bool, error) {
+	visited := make(map[uuid.UUID]bool)
+	stack := []uuid.UUID{from}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == target {
+			return true, nil
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		task, err := s.repo.GetByID(ctx, current)
+		if err != nil {
+			return false, err
+		}
+		stack = append(stack, task.BlockedBy...)
+	}
+
+	return false, nil
+}
+
+// applyBlockedStatus moves an unfinished task to BLOCKED while any of its blockers is unfinished.
+// When unblock is true a BLOCKED task with no unfinished blockers goes back to the initial status
+// of its workflow. Workflows without a BLOCKED status are left alone.
+func (s *TaskService) applyBlockedStatus(ctx context.Context, task *models.Task, unblock bool) error {
+	workflow := s.workflowFor(task.Category)
+	if workflow.IsTerminal(task.Status) || !workflow.HasStatus(models.StatusBlocked) {
+		return nil
+	}
+
+	blocked := false
+	for _, blockerID := range task.BlockedBy {
+		blocker, err := s.repo.GetByID(ctx, blockerID)
+		if err != nil {
+			return err
+		}
+		if !s.isFinished(blocker) {
+			blocked = true
+			break
+		}
+	}
+
+	switch {
+	case blocked && task.Status != models.StatusBlocked:
+		log.Printf("Blocking task with unfinished dependencies: ID=%s", task.ID)
+		task.Status = models.StatusBlocked
+	case !blocked && unblock && task.Status == models.StatusBlocked:
+		log.Printf("Unblocking task, all dependencies are finished: ID=%s", task.ID)
+		task.Status = workflow.InitialStatus()
+	}
+
+	return nil
+}
+
+// refreshDependents re-evaluates the BLOCKED status of every task blocked by id
+func (s *TaskService) refreshDependents(ctx context.Context, id uuid.UUID) error {
+	dependents, err := s.repo.List(ctx, map[string]interface{}{"blocked_by": id})
+	if err != nil {
+		return err
+	}
+
+	for _, dependent := range dependents {
+		s.refreshBlockedStatus(ctx, dependent)
+	}
+
+	return nil
+}
+
+// refreshBlockedStatus applies the dependency rules to a stored task and saves it when its status changes
+func (s *TaskService) refreshBlockedStatus(ctx context.Context, task models.Task) {
+	before := task.Status
+	if err := s.applyBlockedStatus(ctx, &task, true); err != nil {
+		log.Printf("Failed to evaluate dependencies: ID=%s, Error=%v", task.ID, err)
+		return
+	}
+
+	if task.Status == before {
+		return
+	}
+
+	if err := s.repo.Update(ctx, &task); err != nil {
+		log.Printf("Failed to update dependent task: ID=%s, Error=%v", task.ID, err)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDependency(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	design := createTask(t, service, newTaskRequest("Design"))
+	build := createTask(t, service, newTaskRequest("Build"))
+	ship := createTask(t, service, newTaskRequest("Ship"))
+
+	_, err := service.AddDependency(ctx, build.ID, design.ID)
+	assert.NoError(t, err)
+	_, err = service.AddDependency(ctx, ship.ID, build.ID)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		task     *models.Task
+		blocker  *models.Task
+		expected error
+	}{
+		{"Self Dependency", design, design, ErrSelfDependency},
+		{"Direct Cycle", design, build, ErrDependencyCycle},
+		{"Transitive Cycle", design, ship, ErrDependencyCycle},
+		{"Duplicate Dependency", build, design, ErrDependencyExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.AddDependency(ctx, test.task.ID, test.blocker.ID)
+			assert.ErrorIs(t, err, test.expected)
+		})
+	}
+
+	blocked, _ := service.GetTask(ctx, build.ID)
+	assert.Equal(t, models.StatusBlocked, blocked.Status)
+
+	blockers, err := service.ListDependencies(ctx, ship.ID)
+	assert.NoError(t, err)
+	assert.Len(t, blockers, 1)
+	assert.Equal(t, build.ID, blockers[0].ID)
+}
+
+func TestAutomaticBlockedStatus(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	first := createTask(t, service, newTaskRequest("First"))
+	second := createTask(t, service, newTaskRequest("Second"))
+	dependent := createTask(t, service, newTaskRequest("Dependent"))
+
+	_, _ = service.AddDependency(ctx, dependent.ID, first.ID)
+	_, _ = service.AddDependency(ctx, dependent.ID, second.ID)
+
+	complete := func(task *models.Task) {
+		done := *task
+		done.Status = models.StatusDone
+		assert.NoError(t, service.UpdateTask(ctx, &done))
+	}
+
+	complete(first)
+	current, _ := service.GetTask(ctx, dependent.ID)
+	assert.Equal(t, models.StatusBlocked, current.Status)
+
+	complete(second)
+	current, _ = service.GetTask(ctx, dependent.ID)
+	assert.Equal(t, models.StatusToDo, current.Status)
+
+	// A direct update cannot move a task out of BLOCKED while a prerequisite is unfinished
+	reopened := *second
+	reopened.Status = models.StatusInProgress
+	assert.NoError(t, service.UpdateTask(ctx, &reopened))
+	current, _ = service.GetTask(ctx, dependent.ID)
+	assert.Equal(t, models.StatusBlocked, current.Status)
+
+	// Deleting the unfinished prerequisite releases the dependent task
+	assert.NoError(t, service.DeleteTask(ctx, second.ID))
+	current, _ = service.GetTask(ctx, dependent.ID)
+	assert.Equal(t, models.StatusToDo, current.Status)
+	assert.Len(t, current.BlockedBy, 1)
+
+	_, err := service.RemoveDependency(ctx, dependent.ID, first.ID)
+	assert.NoError(t, err)
+	_, err = service.RemoveDependency(ctx, dependent.ID, first.ID)
+	assert.ErrorIs(t, err, ErrDependencyNotFound)
+}
+
+func TestPlanExecution(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	// ship depends on build, which depends on design; docs is independent but due first
+	due := func(title string, in time.Duration) models.CreateTaskRequest {
+		req := newTaskRequest(title)
+		req.DueDate = time.Now().Add(in)
+		return req
+	}
+	ship := createTask(t, service, due("Ship", 24*time.Hour))
+	build := createTask(t, service, due("Build", 48*time.Hour))
+	design := createTask(t, service, due("Design", 72*time.Hour))
+	createTask(t, service, due("Docs", 12*time.Hour))
+
+	_, _ = service.AddDependency(ctx, ship.ID, build.ID)
+	_, _ = service.AddDependency(ctx, build.ID, design.ID)
+
+	plan, err := service.PlanExecution(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+
+	var order, critical []string
+	for _, task := range plan.Order {
+		order = append(order, task.Title)
+	}
+	for _, task := range plan.CriticalPath {
+		critical = append(critical, task.Title)
+	}
+
+	assert.Equal(t, []string{"Docs", "Design", "Build", "Ship"}, order)
+	assert.Equal(t, []string{"Design", "Build", "Ship"}, critical)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidDuplicate    = errors.New("duplicated task is invalid")
+	ErrInvalidTitlePattern = errors.New("invalid title pattern, only {{title}} and {{date}} are available")
+)
+
+// TaskDuplicateHook is called for every task copied by a deep copy, so data attached to the original can be copied too.
+// When a hook fails, the whole copy is discarded.
+type TaskDuplicateHook func(ctx context.Context, originalID, copyID uuid.UUID) error
+
+// OnTaskDuplicated registers a hook that runs after a deep copy for each copied task, including copied subtasks.
+func (s *TaskService) OnTaskDuplicated(hook TaskDuplicateHook) {
+	s.duplicateHooks = append(s.duplicateHooks, hook)
+}
+
+// DuplicateDefaults returns the duplication options used when a request does not override them.
+func (s *TaskService) DuplicateDefaults() models.DuplicateOptions {
+	return models.DuplicateOptions{
+		Children:      s.hierarchy.DuplicateChildren,
+		KeepAssignees: s.keepAssignees,
+		TitlePattern:  models.DefaultDuplicateTitlePattern,
+	}
+}
+
+// DuplicateTaskWithOptions duplicates a task, and its subtree when opts.Children is set. Copies start
+// over in the initial status of their workflow unless opts.KeepStatus is set, and keep the assignees
+// of their originals only when opts.KeepAssignees is set. Every copy is validated after the options
+// are applied; when one is invalid, nothing is kept and the error wraps ErrInvalidDuplicate.
+func (s *TaskService) DuplicateTaskWithOptions(ctx context.Context, id uuid.UUID, opts models.DuplicateOptions) (result *models.Task, err error) {
+	ctx, finish := s.beginOperation(ctx, models.OperationDuplicate)
+	defer func() { finish(taskID(result), err) }()
+	// A copy discarded after a failure never existed for the history either
+	ctx, keepHistory := holdHistory(ctx)
+	defer func() { keepHistory(err == nil) }()
+
+	log.Printf("Duplicating task: ID=%s, Children=%t, KeepAssignees=%t, KeepStatus=%t, DeepCopy=%t", id, opts.Children, opts.KeepAssignees, opts.KeepStatus, opts.DeepCopy)
+
+	original, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to duplicate task: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	shift := opts.ShiftDueDate
+	if opts.DueDate != nil {
+		shift = opts.DueDate.Sub(original.DueDate)
+	}
+
+	pattern := opts.TitlePattern
+	if pattern == "" {
+		pattern = models.DefaultDuplicateTitlePattern
+	}
+	title, err := utils.RenderPlaceholders(pattern, map[string]string{
+		"title": original.Title,
+		"date":  original.DueDate.Add(shift).Format("2006-01-02"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTitlePattern, err)
+	}
+
+	var (
+		duplicatedTask *models.Task
+		copyIDs        map[uuid.UUID]uuid.UUID
+	)
+	if opts.Children {
+		duplicatedTask, copyIDs, err = s.repo.DuplicateTree(ctx, id)
+	} else {
+		duplicatedTask, err = s.repo.Duplicate(ctx, id)
+	}
+
+	if err != nil {
+		log.Printf("Failed to duplicate task: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+	if copyIDs == nil {
+		copyIDs = map[uuid.UUID]uuid.UUID{id: duplicatedTask.ID}
+	}
+
+	copies := []models.Task{*duplicatedTask}
+	if opts.Children {
+		tree, err := s.GetSubtree(ctx, duplicatedTask.ID)
+		if err != nil {
+			s.discardDuplicate(ctx, duplicatedTask.ID)
+
+			return nil, err
+		}
+		copies = flattenTree(tree)
+	}
+
+	for _, copied := range copies {
+		if err := s.applyDuplicateOptions(ctx, &copied, opts, shift); err != nil {
+			s.discardDuplicate(ctx, duplicatedTask.ID)
+
+			return nil, err
+		}
+
+		if copied.ID == duplicatedTask.ID {
+			copied.Title = title
+			if opts.Overrides.Description != nil {
+				copied.Description = *opts.Overrides.Description
+			}
+			if opts.Overrides.Priority != nil {
+				copied.Priority = *opts.Overrides.Priority
+			}
+		}
+
+		if err := s.validator.ValidateTask(&copied); err != nil {
+			log.Printf("Duplicated task validation failed: ID=%s, Error=%v", copied.ID, err)
+			s.discardDuplicate(ctx, duplicatedTask.ID)
+
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDuplicate, err)
+		}
+
+		copied.Progress = nil
+		if err := s.repo.Update(ctx, &copied); err != nil {
+			log.Printf("Failed to update duplicated task: ID=%s, Error=%v", copied.ID, err)
+			s.discardDuplicate(ctx, duplicatedTask.ID)
+
+			return nil, err
+		}
+		if copied.ID == duplicatedTask.ID {
+			duplicatedTask = &copied
+		}
+	}
+
+	if opts.DeepCopy {
+		for originalID, copyID := range copyIDs {
+			for _, hook := range s.duplicateHooks {
+				if err := hook(ctx, originalID, copyID); err != nil {
+					log.Printf("Failed to deep copy task: ID=%s, Error=%v", originalID, err)
+					s.discardDuplicate(ctx, duplicatedTask.ID)
+
+					return nil, err
+				}
+			}
+		}
+	}
+
+	log.Printf("Task duplicated successfully: OriginalID=%s, DuplicatedID=%s", id, duplicatedTask.ID)
+
+	return duplicatedTask, nil
+}
+
+// applyDuplicateOptions resets the status and assignees of a copy as configured and moves its due date
+func (s *TaskService) applyDuplicateOptions(ctx context.Context, copied *models.Task, opts models.DuplicateOptions, shift time.Duration) error {
+	if opts.KeepStatus {
+		// Dependencies are not copied, so a kept BLOCKED status is re-evaluated
+		if err := s.applyBlockedStatus(ctx, copied, true); err != nil {
+			return err
+		}
+	} else {
+		copied.Status = s.workflowFor(copied.Category).InitialStatus()
+	}
+
+	if !opts.KeepAssignees {
+		copied.Assignees = nil
+	}
+
+	copied.DueDate = copied.DueDate.Add(shift)
+
+	return nil
+}
+
+// discardDuplicate removes a copy, and the subtasks copied with it, after duplication failed. It runs within
+// the duplication, whose operation and held history entries are dropped on failure, so the copy leaves no trace.
+func (s *TaskService) discardDuplicate(ctx context.Context, id uuid.UUID) {
+	if err := s.DeleteTaskWithMode(ctx, id, models.DeleteCascade); err != nil {
+		log.Printf("Failed to remove invalid duplicate: ID=%s, Error=%v", id, err)
+	}
+}
+
+// DuplicateOptionsFor applies a duplicate request to the default duplication options.
+func (s *TaskService) DuplicateOptionsFor(req models.DuplicateTaskRequest) (models.DuplicateOptions, error) {
+	opts := s.DuplicateDefaults()
+
+	if req.Children != nil {
+		opts.Children = *req.Children
+	}
+	if req.KeepAssignees != nil {
+		opts.KeepAssignees = *req.KeepAssignees
+	}
+	if req.TitlePattern != "" {
+		opts.TitlePattern = req.TitlePattern
+	}
+	if req.DueDate != nil && req.ShiftDueDate != "" {
+		return opts, fmt.Errorf("%w: due_date and shift_due_date cannot be combined", ErrInvalidDuplicate)
+	}
+	if req.ShiftDueDate != "" {
+		shift, err := utils.ParseDueOffset(req.ShiftDueDate)
+		if err != nil {
+			return opts, fmt.Errorf("%w: %w", ErrInvalidDuplicate, err)
+		}
+		opts.ShiftDueDate = shift
+	}
+
+	opts.KeepStatus = req.KeepStatus
+	opts.DueDate = req.DueDate
+	opts.DeepCopy = req.DeepCopy
+	opts.Overrides = req.Overrides
+
+	return opts, nil
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateTaskWithOptions(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	estimate, points := 90, 3.0
+	req := newTaskRequest("Weekly report")
+	req.Description = "Summarize the week"
+	req.Status = models.StatusInProgress
+	req.OriginalEstimate = &estimate
+	req.StoryPoints = &points
+	original, err := service.CreateTask(ctx, req)
+	assert.NoError(t, err)
+
+	nextWeek := original.DueDate.AddDate(0, 0, 7)
+	description := "Summarize the next week"
+	priority := models.PriorityHigh
+
+	tests := []struct {
+		name      string
+		opts      func(opts *models.DuplicateOptions)
+		check     func(t *testing.T, copied *models.Task)
+		expectErr error
+	}{
+		{
+			name: "Defaults",
+			opts: func(opts *models.DuplicateOptions) {},
+			check: func(t *testing.T, copied *models.Task) {
+				assert.Equal(t, "Weekly report (Copy)", copied.Title)
+				assert.Equal(t, models.StatusToDo, copied.Status)
+				assert.True(t, copied.DueDate.Equal(original.DueDate))
+				assert.Equal(t, 90, *copied.RemainingEstimate)
+				assert.Equal(t, 3.0, *copied.StoryPoints)
+			},
+		},
+		{
+			name: "Title Pattern And Shift",
+			opts: func(opts *models.DuplicateOptions) {
+				opts.TitlePattern = "{{title}} {{date}}"
+				opts.ShiftDueDate = 7 * 24 * time.Hour
+			},
+			check: func(t *testing.T, copied *models.Task) {
+				assert.Equal(t, "Weekly report "+nextWeek.Format("2006-01-02"), copied.Title)
+				assert.True(t, copied.DueDate.Equal(nextWeek))
+			},
+		},
+		{
+			name: "Keep Status With Overrides",
+			opts: func(opts *models.DuplicateOptions) {
+				opts.KeepStatus = true
+				opts.DueDate = &nextWeek
+				opts.Overrides = models.DuplicateOverrides{Description: &description, Priority: &priority}
+			},
+			check: func(t *testing.T, copied *models.Task) {
+				assert.Equal(t, models.StatusInProgress, copied.Status)
+				assert.True(t, copied.DueDate.Equal(nextWeek))
+				assert.Equal(t, description, copied.Description)
+				assert.Equal(t, priority, copied.Priority)
+			},
+		},
+		{
+			name:      "Unknown Placeholder",
+			opts:      func(opts *models.DuplicateOptions) { opts.TitlePattern = "{{title}} for {{customer}}" },
+			expectErr: ErrInvalidTitlePattern,
+		},
+		{
+			name: "Invalid Override",
+			opts: func(opts *models.DuplicateOptions) {
+				invalid := models.Priority("URGENT")
+				opts.Overrides.Priority = &invalid
+			},
+			expectErr: ErrInvalidDuplicate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := service.DuplicateDefaults()
+			test.opts(&opts)
+
+			copied, err := service.DuplicateTaskWithOptions(ctx, original.ID, opts)
+			if test.expectErr != nil {
+				assert.ErrorIs(t, err, test.expectErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			test.check(t, copied)
+
+			stored, err := service.GetTask(ctx, copied.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, copied.Title, stored.Title)
+		})
+	}
+
+	// Failed duplicates leave nothing behind
+	tasks, err := service.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 4)
+}
+
+func TestDuplicateOverdueTask(t *testing.T) {
+	repo := repository.NewInMemoryTaskRepository()
+	service := NewTaskService(repo)
+	ctx := context.Background()
+
+	root := &models.Task{Title: "Root", DueDate: time.Now().Add(-48 * time.Hour), Priority: models.PriorityMedium, Status: models.StatusDone}
+	assert.NoError(t, repo.Create(ctx, root))
+	child := &models.Task{Title: "Child", DueDate: time.Now().Add(-24 * time.Hour), Priority: models.PriorityLow, Status: models.StatusDone, ParentID: &root.ID}
+	assert.NoError(t, repo.Create(ctx, child))
+
+	opts := service.DuplicateDefaults()
+	opts.Children = true
+	_, err := service.DuplicateTaskWithOptions(ctx, root.ID, opts)
+	assert.ErrorIs(t, err, ErrInvalidDuplicate)
+	assert.ErrorIs(t, err, utils.ErrDueDateInPast)
+
+	tasks, err := service.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+
+	opts.ShiftDueDate = 7 * 24 * time.Hour
+	copied, err := service.DuplicateTaskWithOptions(ctx, root.ID, opts)
+	assert.NoError(t, err)
+	assert.True(t, copied.DueDate.Equal(root.DueDate.Add(opts.ShiftDueDate)))
+
+	tree, err := service.GetSubtree(ctx, copied.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tree.Children, 1)
+	assert.True(t, tree.Children[0].Task.DueDate.Equal(child.DueDate.Add(opts.ShiftDueDate)))
+	assert.Equal(t, models.StatusToDo, tree.Children[0].Task.Status)
+}
+
+func TestDuplicateOptionsFor(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	due := time.Now().Add(72 * time.Hour)
+
+	opts, err := service.DuplicateOptionsFor(models.DuplicateTaskRequest{ShiftDueDate: "+2w", KeepStatus: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, opts.ShiftDueDate)
+	assert.True(t, opts.KeepStatus)
+	assert.Equal(t, models.DefaultDuplicateTitlePattern, opts.TitlePattern)
+
+	_, err = service.DuplicateOptionsFor(models.DuplicateTaskRequest{ShiftDueDate: "next week"})
+	assert.ErrorIs(t, err, utils.ErrInvalidDueOffset)
+
+	_, err = service.DuplicateOptionsFor(models.DuplicateTaskRequest{ShiftDueDate: "+1d", DueDate: &due})
+	assert.ErrorIs(t, err, ErrInvalidDuplicate)
+}
+
+func TestDeepCopy(t *testing.T) {
+	attachments, taskService, _ := newAttachmentService(t)
+	comments := NewCommentService(repository.NewInMemoryCommentRepository(), taskService)
+	ctx := context.Background()
+	author := uuid.New()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Investigate crash"))
+	assert.NoError(t, err)
+	_, err = attachments.Upload(ctx, task.ID, "crash.log", strings.NewReader("panic: boom"))
+	assert.NoError(t, err)
+	root, err := comments.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Seen on prod", AuthorID: author})
+	assert.NoError(t, err)
+	_, err = comments.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Also on staging", ParentID: &root.ID, AuthorID: author})
+	assert.NoError(t, err)
+
+	shallow, err := taskService.DuplicateTask(ctx, task.ID)
+	assert.NoError(t, err)
+	listed, err := attachments.ListAttachments(ctx, shallow.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+
+	opts := taskService.DuplicateDefaults()
+	opts.DeepCopy = true
+	deep, err := taskService.DuplicateTaskWithOptions(ctx, task.ID, opts)
+	assert.NoError(t, err)
+
+	listed, err = attachments.ListAttachments(ctx, deep.ID)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+	assert.Equal(t, "crash.log", listed[0].Filename)
+
+	page, err := comments.ListComments(ctx, deep.ID, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, page.Comments, 1)
+	assert.Equal(t, "Seen on prod", page.Comments[0].Body)
+	assert.Len(t, page.Comments[0].Replies, 1)
+
+	// A failing hook discards the copy along with what earlier hooks copied to it
+	failure := errors.New("storage unavailable")
+	var failedCopy uuid.UUID
+	taskService.OnTaskDuplicated(func(ctx context.Context, originalID, copyID uuid.UUID) error {
+		failedCopy = copyID
+		return failure
+	})
+	_, err = taskService.DuplicateTaskWithOptions(ctx, task.ID, opts)
+	assert.ErrorIs(t, err, failure)
+	_, err = taskService.GetTask(ctx, failedCopy)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	listed, err = attachments.ListAttachments(ctx, failedCopy)
+	assert.Error(t, err)
+	assert.Empty(t, listed)
+
+	// Deleting the original keeps the blob the copy still references
+	listed, err = attachments.ListAttachments(ctx, deep.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, taskService.DeleteTask(ctx, task.ID))
+	_, content, err := attachments.Open(ctx, deep.ID, listed[0].ID)
+	assert.NoError(t, err)
+	content.Close()
+}
+
+func TestFailedDuplicateLeavesNoTrace(t *testing.T) {
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	service := NewTaskService(NewHistoryRecorder(repository.NewInMemoryTaskRepository(), historyRepo))
+	service.SetOperationRepository(repository.NewInMemoryOperationRepository(), 0)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+
+	parent := createTask(t, service, newTaskRequest("Release"))
+	child := newTaskRequest("Notes")
+	child.ParentID = &parent.ID
+	createTask(t, service, child)
+
+	failure := errors.New("storage unavailable")
+	var copyIDs []uuid.UUID
+	service.OnTaskDuplicated(func(ctx context.Context, originalID, copyID uuid.UUID) error {
+		copyIDs = append(copyIDs, copyID)
+		return failure
+	})
+
+	before, err := service.ListOperations(ctx)
+	assert.NoError(t, err)
+
+	opts := service.DuplicateDefaults()
+	opts.Children = true
+	opts.DeepCopy = true
+	_, err = service.DuplicateTaskWithOptions(ctx, parent.ID, opts)
+	assert.ErrorIs(t, err, failure)
+	assert.NotEmpty(t, copyIDs)
+
+	// The discarded copy is neither in the history nor in the operation log
+	for _, copyID := range copyIDs {
+		entries, err := historyRepo.ListByTask(ctx, copyID)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	}
+	after, err := service.ListOperations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, after, len(before))
+
+	// A duplicate that succeeds is recorded
+	opts.DeepCopy = false
+	copied, err := service.DuplicateTaskWithOptions(ctx, parent.ID, opts)
+	assert.NoError(t, err)
+	entries, err := historyRepo.ListByTask(ctx, copied.ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, entries)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// FilterKeys lists the filter parameters accepted by ListTasks.
+var FilterKeys = []string{"title", "category", "project_id", "sprint_id", "milestone_id", "status", "priority", "due_date", "parent_id", "blocked_by", "tags_any", "tags_all", "assignee", "reporter", "unassigned", "overdue", "due_within", "stale"}
+
+var priorityRank = map[models.Priority]int{
+	models.PriorityLow:    0,
+	models.PriorityMedium: 1,
+	models.PriorityHigh:   2,
+}
+
+var statusRank = map[models.Status]int{
+	models.StatusToDo:       0,
+	models.StatusInProgress: 1,
+	models.StatusBlocked:    2,
+	models.StatusDone:       3,
+}
+
+var taskLess = map[string]func(a, b *models.Task) bool{
+	"title":      func(a, b *models.Task) bool { return a.Title < b.Title },
+	"category":   func(a, b *models.Task) bool { return a.Category < b.Category },
+	"due_date":   func(a, b *models.Task) bool { return a.DueDate.Before(b.DueDate) },
+	"priority":   func(a, b *models.Task) bool { return priorityRank[a.Priority] < priorityRank[b.Priority] },
+	"status":     func(a, b *models.Task) bool { return statusRank[a.Status] < statusRank[b.Status] },
+	"created_at": func(a, b *models.Task) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	"updated_at": func(a, b *models.Task) bool { return a.UpdatedAt.Before(b.UpdatedAt) },
+}
+
+// ParseTaskFilters converts raw string parameters into the typed filters understood by the repository.
+// Empty values are skipped; unknown keys and malformed values are rejected. The smart filters
+// overdue, due_within and stale are resolved against the current server time.
+func ParseTaskFilters(params map[string]string) (map[string]interface{}, error) {
+	return parseTaskFilters(params, time.Now())
+}
+
+func parseTaskFilters(params map[string]string, now time.Time) (map[string]interface{}, error) {
+	filters := make(map[string]interface{})
+
+	for key, value := range params {
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case "title", "category":
+			filters[key] = value
+		case "status":
+			filters[key] = models.Status(value)
+		case "priority":
+			filters[key] = models.Priority(value)
+		case "due_date":
+			parsedDate, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid due_date filter: %w", err)
+			}
+			filters[key] = parsedDate
+		case "parent_id", "project_id", "sprint_id", "milestone_id", "blocked_by", "assignee", "reporter":
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s filter: %w", key, err)
+			}
+			filters[key] = id
+		case "tags_any", "tags_all":
+			var tagIDs []uuid.UUID
+			for _, raw := range strings.Split(value, ",") {
+				id, err := uuid.Parse(strings.TrimSpace(raw))
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s filter: %w", key, err)
+				}
+				tagIDs = append(tagIDs, id)
+			}
+			filters[key] = tagIDs
+		case "unassigned":
+			unassigned, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid unassigned filter: %w", err)
+			}
+			filters[key] = unassigned
+		case "overdue":
+			overdue, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid overdue filter: %w", err)
+			}
+			if overdue {
+				filters[key] = now
+			}
+		case "due_within":
+			window, err := ParseRelativeDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid due_within filter: %w", err)
+			}
+			filters[key] = models.TimeRange{From: now, To: now.Add(window)}
+		case "stale":
+			age, err := ParseRelativeDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid stale filter: %w", err)
+			}
+			filters[key] = now.Add(-age)
+		default:
+			field := strings.TrimPrefix(key, models.CustomFieldPrefix)
+			if field == key || field == "" {
+				return nil, fmt.Errorf("unknown filter: %s", key)
+			}
+			customFields, _ := filters["custom_fields"].(map[string]string)
+			if customFields == nil {
+				customFields = make(map[string]string)
+				filters["custom_fields"] = customFields
+			}
+			customFields[field] = value
+		}
+	}
+
+	return filters, nil
+}
+
+// ParseRelativeDuration parses a positive duration such as "48h", "14d" or "2w".
+func ParseRelativeDuration(value string) (time.Duration, error) {
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	var duration time.Duration
+	if unit != 0 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		duration = time.Duration(n) * unit
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		duration = parsed
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %s", value)
+	}
+
+	return duration, nil
+}
+
+// ValidateSort checks a sort expression such as "due_date" or "-priority".
+func ValidateSort(sortBy string) error {
+	if sortBy == "" {
+		return nil
+	}
+
+	if _, ok := lessFunc(strings.TrimPrefix(sortBy, "-")); !ok {
+		return fmt.Errorf("unknown sort field: %s", strings.TrimPrefix(sortBy, "-"))
+	}
+
+	return nil
+}
+
+// lessFunc looks up the ordering of a sort field, including custom fields given as cf.<key>
+func lessFunc(field string) (func(a, b *models.Task) bool, bool) {
+	if key := strings.TrimPrefix(field, models.CustomFieldPrefix); key != field && key != "" {
+		return func(a, b *models.Task) bool {
+			return customFieldLess(a.CustomFields[key], b.CustomFields[key])
+		}, true
+	}
+
+	less, ok := taskLess[field]
+	return less, ok
+}
+
+// customFieldLess orders custom field values: missing values first, then numbers numerically and text ignoring case.
+// Dates are stored as YYYY-MM-DD, so they sort chronologically as text.
+func customFieldLess(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+
+	if x, ok := a.(float64); ok {
+		if y, ok := b.(float64); ok {
+			return x < y
+		}
+	}
+
+	return strings.ToLower(fmt.Sprint(a)) < strings.ToLower(fmt.Sprint(b))
+}
+
+// SortTasks orders tasks in place by the given field; a leading "-" sorts in descending order.
+func SortTasks(tasks []models.Task, sortBy string) error {
+	if err := ValidateSort(sortBy); err != nil {
+		return err
+	}
+
+	if sortBy == "" {
+		return nil
+	}
+
+	descending := strings.HasPrefix(sortBy, "-")
+	less, _ := lessFunc(strings.TrimPrefix(sortBy, "-"))
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if descending {
+			return less(&tasks[j], &tasks[i])
+		}
+		return less(&tasks[i], &tasks[j])
+	})
+
+	return nil
+}
+
+// GroupByKeys lists the task fields that aggregation results can be grouped by.
+var GroupByKeys = []string{"status", "priority", "category"}
+
+// ValidateAggregationOptions checks the group-by fields and histogram interval of an aggregation.
+func ValidateAggregationOptions(opts models.AggregationOptions) error {
+	for _, field := range opts.GroupBy {
+		valid := false
+		for _, key := range GroupByKeys {
+			if field == key {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("unknown group_by field: %s", field)
+		}
+	}
+
+	switch opts.Interval {
+	case "", models.IntervalDay, models.IntervalWeek, models.IntervalMonth:
+		return nil
+	default:
+		return fmt.Errorf("unknown interval: %s", opts.Interval)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTaskFilters(t *testing.T) {
+	now := time.Date(2030, time.January, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		params    map[string]string
+		expected  map[string]interface{}
+		expectErr bool
+	}{
+		{
+			name:     "Plain Filters",
+			params:   map[string]string{"status": "TODO", "category": ""},
+			expected: map[string]interface{}{"status": models.StatusToDo},
+		},
+		{
+			name:     "Overdue",
+			params:   map[string]string{"overdue": "true"},
+			expected: map[string]interface{}{"overdue": now},
+		},
+		{
+			name:     "Overdue False Is Ignored",
+			params:   map[string]string{"overdue": "false"},
+			expected: map[string]interface{}{},
+		},
+		{
+			name:   "Due Within And Stale",
+			params: map[string]string{"due_within": "48h", "stale": "14d", "priority": "HIGH"},
+			expected: map[string]interface{}{
+				"due_within": models.TimeRange{From: now, To: now.Add(48 * time.Hour)},
+				"stale":      now.AddDate(0, 0, -14),
+				"priority":   models.PriorityHigh,
+			},
+		},
+		{
+			name:      "Invalid Duration",
+			params:    map[string]string{"stale": "soon"},
+			expectErr: true,
+		},
+		{
+			name:      "Negative Duration",
+			params:    map[string]string{"due_within": "-2d"},
+			expectErr: true,
+		},
+		{
+			name:      "Unknown Filter",
+			params:    map[string]string{"owner": "me"},
+			expectErr: true,
+		},
+		{
+			name:   "Custom Fields",
+			params: map[string]string{"cf.severity": "high", "cf.customer": "Acme"},
+			expected: map[string]interface{}{
+				"custom_fields": map[string]string{"severity": "high", "customer": "Acme"},
+			},
+		},
+		{
+			name:      "Custom Field Without Key",
+			params:    map[string]string{"cf.": "high"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := parseTaskFilters(test.params, now)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, filters)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrParentNotFound = errors.New("parent task not found")
+	ErrHierarchyCycle = errors.New("parent would create a cycle")
+	ErrHasSubtasks    = errors.New("task has subtasks")
+)
+
+// SetHierarchyOptions changes the default cascade behaviour for deleting and duplicating parent tasks.
+func (s *TaskService) SetHierarchyOptions(opts models.HierarchyOptions) {
+	s.hierarchy = opts
+}
+
+// ListChildren returns the direct subtasks of a task, each with its progress rolled up.
+func (s *TaskService) ListChildren(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	log.Printf("Listing children of task: ID=%s", id)
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	index, err := s.childIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make([]models.Task, 0, len(index[id]))
+	for _, child := range index[id] {
+		child.Progress = s.progress(index, child.ID)
+		children = append(children, child)
+	}
+
+	log.Printf("Listed children successfully: ID=%s, Found %d children", id, len(children))
+
+	return children, nil
+}
+
+// GetSubtree returns a task with all of its descendants nested beneath it.
+func (s *TaskService) GetSubtree(ctx context.Context, id uuid.UUID) (*models.TaskNode, error) {
+	log.Printf("Retrieving subtree of task: ID=%s", id)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.childIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	root := s.buildNode(index, *task)
+
+	return &root, nil
+}
+
+// DeleteTaskWithMode deletes a task, treating its subtasks according to mode.
+func (s *TaskService) DeleteTaskWithMode(ctx context.Context, id uuid.UUID, mode models.DeleteMode) (err error) {
+	ctx, finish := s.beginOperation(ctx, models.OperationDelete)
+	defer func() { finish(id, err) }()
+
+	log.Printf("Deleting task: ID=%s, Mode=%s", id, mode)
+
+	deleted, dependents, err := s.deletionImpact(ctx, id, mode)
+	if err != nil {
+		return err
+	}
+
+	if err := s.keepAttached(ctx, deleted); err != nil {
+		log.Printf("Failed to delete task: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	switch mode {
+	case models.DeleteCascade:
+		_, err = s.repo.DeleteTree(ctx, id)
+	case models.DeleteRestrict:
+		var children []models.Task
+		children, err = s.repo.List(ctx, map[string]interface{}{"parent_id": id})
+		if err == nil && len(children) > 0 {
+			err = fmt.Errorf("%w: %d", ErrHasSubtasks, len(children))
+		}
+		if err == nil {
+			_, err = s.repo.Delete(ctx, id)
+		}
+	case models.DeleteOrphan, "":
+		_, err = s.repo.Delete(ctx, id)
+	default:
+		err = fmt.Errorf("unknown delete mode: %s", mode)
+	}
+
+	if err != nil {
+		log.Printf("Failed to delete task: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	for _, deletedID := range deleted {
+		for _, hook := range s.deleteHooks {
+			hook(ctx, deletedID)
+		}
+	}
+
+	// Tasks that were blocked by a deleted task may now be free to start
+	for _, dependentID := range dependents {
+		if dependent, err := s.repo.GetByID(ctx, dependentID); err == nil {
+			s.refreshBlockedStatus(ctx, *dependent)
+		}
+	}
+
+	log.Printf("Task deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// deletionImpact returns the tasks that deleting id in the given mode removes, and the surviving tasks blocked by any of them
+func (s *TaskService) deletionImpact(ctx context.Context, id uuid.UUID, mode models.DeleteMode) ([]uuid.UUID, []uuid.UUID, error) {
+	deleted := map[uuid.UUID]bool{id: true}
+	removed := []uuid.UUID{id}
+
+	if mode == models.DeleteCascade {
+		index, err := s.childIndex(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i := 0; i < len(removed); i++ {
+			for _, child := range index[removed[i]] {
+				deleted[child.ID] = true
+				removed = append(removed, child.ID)
+			}
+		}
+	}
+
+	tasks, err := s.repo.List(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dependents []uuid.UUID
+	for _, task := range tasks {
+		if deleted[task.ID] {
+			continue
+		}
+		for _, blockerID := range task.BlockedBy {
+			if deleted[blockerID] {
+				dependents = append(dependents, task.ID)
+				break
+			}
+		}
+	}
+
+	return removed, dependents, nil
+}
+
+// DuplicateTaskWithChildren duplicates a task and, when children is true, its whole subtree.
+func (s *TaskService) DuplicateTaskWithChildren(ctx context.Context, id uuid.UUID, children bool) (*models.Task, error) {
+	opts := s.DuplicateDefaults()
+	opts.Children = children
+
+	return s.DuplicateTaskWithOptions(ctx, id, opts)
+}
+
+// flattenTree lists the tasks of a subtree, parents before children
+func flattenTree(node *models.TaskNode) []models.Task {
+	tasks := []models.Task{node.Task}
+	for i := range node.Children {
+		tasks = append(tasks, flattenTree(&node.Children[i])...)
+	}
+
+	return tasks
+}
+
+// checkParent verifies that parentID exists and is not taskID itself or one of its descendants
+func (s *TaskService) checkParent(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for current := parentID; current != nil; {
+		if *current == taskID {
+			return ErrHierarchyCycle
+		}
+		if seen[*current] {
+			return ErrHierarchyCycle
+		}
+		seen[*current] = true
+
+		ancestor, err := s.repo.GetByID(ctx, *current)
+		if err != nil {
+			if current == parentID {
+				return ErrParentNotFound
+			}
+			return err
+		}
+		current = ancestor.ParentID
+	}
+
+	return nil
+}
+
+// childIndex groups every task by its parent ID
+func (s *TaskService) childIndex(ctx context.Context) (map[uuid.UUID][]models.Task, error) {
+	tasks, err := s.repo.List(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[uuid.UUID][]models.Task)
+	for _, task := range tasks {
+		if task.ParentID != nil {
+			index[*task.ParentID] = append(index[*task.ParentID], task)
+		}
+	}
+
+	for _, children := range index {
+		SortTasks(children, "created_at")
+	}
+
+	return index, nil
+}
+
+// progress returns the percentage of finished descendants of a task, or nil when it has none
+func (s *TaskService) progress(index map[uuid.UUID][]models.Task, id uuid.UUID) *float64 {
+	total, done := 0, 0
+
+	queue := []uuid.UUID{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, child := range index[current] {
+			total++
+			if s.isFinished(&child) {
+				done++
+			}
+			queue = append(queue, child.ID)
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	percentage := float64(done) * 100 / float64(total)
+
+	return &percentage
+}
+
+// buildNode nests the descendants of task beneath it, rolling up progress at every level
+func (s *TaskService) buildNode(index map[uuid.UUID][]models.Task, task models.Task) models.TaskNode {
+	task.Progress = s.progress(index, task.ID)
+
+	node := models.TaskNode{Task: task, Children: []models.TaskNode{}}
+	for _, child := range index[task.ID] {
+		node.Children = append(node.Children, s.buildNode(index, child))
+	}
+
+	return node
+}
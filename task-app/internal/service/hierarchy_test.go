@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// seedTree creates root -> child -> grandchild, with the grandchild DONE
+func seedTree(t *testing.T, service *TaskService) (root, child, grandchild *models.Task) {
+	ctx := context.Background()
+	req := func(title string, parentID *uuid.UUID, status models.Status) models.CreateTaskRequest {
+		return models.CreateTaskRequest{
+			Title:    title,
+			DueDate:  time.Now().Add(24 * time.Hour),
+			Priority: models.PriorityMedium,
+			Status:   status,
+			ParentID: parentID,
+		}
+	}
+
+	root, err := service.CreateTask(ctx, req("Root", nil, models.StatusToDo))
+	assert.NoError(t, err)
+	child, err = service.CreateTask(ctx, req("Child", &root.ID, models.StatusInProgress))
+	assert.NoError(t, err)
+	grandchild, err = service.CreateTask(ctx, req("Grandchild", &child.ID, models.StatusDone))
+	assert.NoError(t, err)
+
+	return root, child, grandchild
+}
+
+func TestParentValidation(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+	root, _, grandchild := seedTree(t, service)
+
+	missing := uuid.New()
+	_, err := service.CreateTask(ctx, models.CreateTaskRequest{
+		Title:    "Orphan",
+		DueDate:  time.Now().Add(24 * time.Hour),
+		Priority: models.PriorityLow,
+		Status:   models.StatusToDo,
+		ParentID: &missing,
+	})
+	assert.ErrorIs(t, err, ErrParentNotFound)
+
+	tests := []struct {
+		name     string
+		parentID *uuid.UUID
+		expected error
+	}{
+		{"Self As Parent", &root.ID, ErrHierarchyCycle},
+		{"Descendant As Parent", &grandchild.ID, ErrHierarchyCycle},
+		{"No Parent", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			update := &models.Task{
+				ID:       root.ID,
+				Title:    "Root",
+				DueDate:  time.Now().Add(24 * time.Hour),
+				Priority: models.PriorityMedium,
+				Status:   models.StatusToDo,
+				ParentID: test.parentID,
+			}
+			err := service.UpdateTask(ctx, update)
+			if test.expected != nil {
+				assert.ErrorIs(t, err, test.expected)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubtreeProgress(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+	root, child, _ := seedTree(t, service)
+
+	tree, err := service.GetSubtree(ctx, root.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tree.Children, 1)
+	assert.Len(t, tree.Children[0].Children, 1)
+	assert.InDelta(t, 50.0, *tree.Progress, 0.001)
+	assert.InDelta(t, 100.0, *tree.Children[0].Progress, 0.001)
+	assert.Nil(t, tree.Children[0].Children[0].Progress)
+
+	children, err := service.ListChildren(ctx, root.ID)
+	assert.NoError(t, err)
+	assert.Len(t, children, 1)
+	assert.Equal(t, child.ID, children[0].ID)
+
+	task, err := service.GetTask(ctx, root.ID)
+	assert.NoError(t, err)
+	assert.InDelta(t, 50.0, *task.Progress, 0.001)
+}
+
+func TestDeleteTaskWithMode(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		mode            models.DeleteMode
+		expectErr       bool
+		expectRemaining int
+	}{
+		{"Orphan", models.DeleteOrphan, false, 2},
+		{"Cascade", models.DeleteCascade, false, 0},
+		{"Restrict", models.DeleteRestrict, true, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewTaskService(repository.NewInMemoryTaskRepository())
+			root, child, _ := seedTree(t, service)
+
+			err := service.DeleteTaskWithMode(ctx, root.ID, test.mode)
+			if test.expectErr {
+				assert.ErrorIs(t, err, ErrHasSubtasks)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			tasks, _ := service.ListTasks(ctx, map[string]interface{}{})
+			assert.Len(t, tasks, test.expectRemaining)
+
+			if test.mode == models.DeleteOrphan {
+				orphan, err := service.GetTask(ctx, child.ID)
+				assert.NoError(t, err)
+				assert.Nil(t, orphan.ParentID)
+			}
+		})
+	}
+}
+
+func TestDuplicateTaskWithChildren(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+	root, _, _ := seedTree(t, service)
+
+	duplicated, err := service.DuplicateTaskWithChildren(ctx, root.ID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "Root (Copy)", duplicated.Title)
+
+	tree, err := service.GetSubtree(ctx, duplicated.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tree.Children, 1)
+	assert.Equal(t, "Child", tree.Children[0].Title)
+	assert.Len(t, tree.Children[0].Children, 1)
+
+	tasks, _ := service.ListTasks(ctx, map[string]interface{}{})
+	assert.Len(t, tasks, 6)
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// HistoryRecorder wraps a task repository and records a history entry with field-level changes for every
+// task it creates, updates or deletes. The actor is the authenticated user of the request, if any. Give the
+// recorder to every service that writes tasks so that all mutations end up in the history.
+type HistoryRecorder struct {
+	repository.TaskRepository
+	mu      sync.Mutex
+	history repository.HistoryRepository
+}
+
+func NewHistoryRecorder(repo repository.TaskRepository, history repository.HistoryRepository) *HistoryRecorder {
+	return &HistoryRecorder{TaskRepository: repo, history: history}
+}
+
+// historyKey marks a context whose history entries are held back until the change they belong to is
+// complete; its value is the *heldHistory collecting them
+type historyKey struct{}
+
+// heldHistory collects the history entries of a change that may still be undone
+type heldHistory struct {
+	mu      sync.Mutex
+	records []func()
+}
+
+// holdHistory returns a context whose history entries are held back, and a function that records them
+// when keep is set and drops them otherwise. Contexts that already hold their history keep doing so.
+func holdHistory(ctx context.Context) (context.Context, func(keep bool)) {
+	if _, held := ctx.Value(historyKey{}).(*heldHistory); held {
+		return ctx, func(bool) {}
+	}
+
+	held := &heldHistory{}
+	return context.WithValue(ctx, historyKey{}, held), func(keep bool) {
+		held.mu.Lock()
+		defer held.mu.Unlock()
+
+		if keep {
+			for _, record := range held.records {
+				record()
+			}
+		}
+		held.records = nil
+	}
+}
+
+func (r *HistoryRecorder) Create(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The repository may keep the task it is given, so it gets a copy; otherwise later changes to the
+	// caller's task would alter the stored task without being recorded
+	stored := *task
+	if err := r.TaskRepository.Create(ctx, &stored); err != nil {
+		return err
+	}
+	task.ID, task.CreatedAt, task.UpdatedAt = stored.ID, stored.CreatedAt, stored.UpdatedAt
+
+	r.recordTask(ctx, task.ID, nil)
+
+	return nil
+}
+
+func (r *HistoryRecorder) Update(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	before, err := r.TaskRepository.GetByID(ctx, task.ID)
+	if err != nil {
+		return r.TaskRepository.Update(ctx, task)
+	}
+
+	stored := *task
+	if err := r.TaskRepository.Update(ctx, &stored); err != nil {
+		return err
+	}
+	task.UpdatedAt = stored.UpdatedAt
+
+	r.recordTask(ctx, task.ID, before)
+
+	return nil
+}
+
+func (r *HistoryRecorder) Restore(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *task
+	if err := r.TaskRepository.Restore(ctx, &stored); err != nil {
+		return err
+	}
+	task.UpdatedAt = stored.UpdatedAt
+
+	r.recordTask(ctx, task.ID, nil)
+
+	return nil
+}
+
+func (r *HistoryRecorder) Delete(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.recordTouched(ctx, func() ([]models.Task, error) {
+		return r.TaskRepository.Delete(ctx, id)
+	})
+}
+
+func (r *HistoryRecorder) Duplicate(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	duplicated, err := r.TaskRepository.Duplicate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.record(ctx, duplicated.ID, models.HistoryCreated, nil, duplicated)
+
+	return duplicated, nil
+}
+
+func (r *HistoryRecorder) DuplicateTree(ctx context.Context, id uuid.UUID) (*models.Task, map[uuid.UUID]uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	duplicated, copyIDs, err := r.TaskRepository.DuplicateTree(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, copyID := range copyIDs {
+		r.recordTask(ctx, copyID, nil)
+	}
+
+	return duplicated, copyIDs, nil
+}
+
+func (r *HistoryRecorder) DeleteTree(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.recordTouched(ctx, func() ([]models.Task, error) {
+		return r.TaskRepository.DeleteTree(ctx, id)
+	})
+}
+
+func (r *HistoryRecorder) MergeTag(ctx context.Context, from, into uuid.UUID) ([]models.Task, error) {
+	return r.recordTouched(ctx, func() ([]models.Task, error) {
+		return r.TaskRepository.MergeTag(ctx, from, into)
+	})
+}
+
+func (r *HistoryRecorder) DetachTag(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.recordTouched(ctx, func() ([]models.Task, error) {
+		return r.TaskRepository.DetachTag(ctx, id)
+	})
+}
+
+func (r *HistoryRecorder) UnassignUser(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.recordTouched(ctx, func() ([]models.Task, error) {
+		return r.TaskRepository.UnassignUser(ctx, id)
+	})
+}
+
+// RecordProjectRename records the category change of every task of a renamed project. Tasks take their
+// category from their project, so the rename changes them without passing through the recorder.
+func (r *HistoryRecorder) RecordProjectRename(ctx context.Context, from, to string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks, err := r.TaskRepository.List(ctx, map[string]interface{}{"category": to})
+	if err != nil {
+		log.Printf("Failed to record task history: Error=%v", err)
+		return
+	}
+
+	for i := range tasks {
+		before := tasks[i]
+		before.Category = from
+		r.record(ctx, tasks[i].ID, models.HistoryUpdated, &before, &tasks[i])
+	}
+}
+
+// recordTouched runs a mutation that may touch many tasks and records the tasks it reports, as they were
+// before, against their stored state afterwards; tasks no longer stored were deleted
+func (r *HistoryRecorder) recordTouched(ctx context.Context, mutate func() ([]models.Task, error)) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	touched, err := mutate()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range touched {
+		before := &touched[i]
+		after, err := r.TaskRepository.GetByID(ctx, before.ID)
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			r.record(ctx, before.ID, models.HistoryDeleted, before, nil)
+		case err != nil:
+			log.Printf("Failed to record task history: TaskID=%s, Error=%v", before.ID, err)
+		default:
+			r.record(ctx, before.ID, models.HistoryUpdated, before, after)
+		}
+	}
+
+	return touched, nil
+}
+
+// recordTask records the stored state of a task against its state before the mutation, nil when it was created
+func (r *HistoryRecorder) recordTask(ctx context.Context, id uuid.UUID, before *models.Task) {
+	after, err := r.TaskRepository.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to record task history: TaskID=%s, Error=%v", id, err)
+		return
+	}
+
+	action := models.HistoryUpdated
+	if before == nil {
+		action = models.HistoryCreated
+	}
+
+	r.record(ctx, id, action, before, after)
+}
+
+// record appends a history entry unless nothing tracked changed. Failing to record history is logged
+// rather than failing the mutation, which has already happened.
+func (r *HistoryRecorder) record(ctx context.Context, id uuid.UUID, action models.HistoryAction, before, after *models.Task) {
+	changes, err := utils.DiffTasks(before, after)
+	if err != nil {
+		log.Printf("Failed to record task history: TaskID=%s, Error=%v", id, err)
+		return
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+
+	entry := &models.HistoryEntry{
+		TaskID:  id,
+		Action:  action,
+		At:      time.Now(),
+		Changes: changes,
+	}
+	if userID, ok := auth.UserFromContext(ctx); ok {
+		entry.ActorID = &userID
+	}
+
+	if held, ok := ctx.Value(historyKey{}).(*heldHistory); ok {
+		held.mu.Lock()
+		defer held.mu.Unlock()
+
+		held.records = append(held.records, func() { r.appendEntry(ctx, entry) })
+		return
+	}
+
+	r.appendEntry(ctx, entry)
+}
+
+func (r *HistoryRecorder) appendEntry(ctx context.Context, entry *models.HistoryEntry) {
+	if err := r.history.Append(ctx, entry); err != nil {
+		log.Printf("Failed to record task history: TaskID=%s, Error=%v", entry.TaskID, err)
+	}
+}
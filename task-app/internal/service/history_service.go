@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var ErrTaskNotFoundAt = errors.New("task not found at that time")
+
+// HistoryService reads the change history of tasks recorded by a HistoryRecorder.
+type HistoryService struct {
+	repo        repository.HistoryRepository
+	taskService *TaskService
+}
+
+func NewHistoryService(repo repository.HistoryRepository, taskService *TaskService) *HistoryService {
+	return &HistoryService{
+		repo:        repo,
+		taskService: taskService,
+	}
+}
+
+// ListHistory returns the history of a task, oldest first. When field is set, only entries changing
+// that field are returned, each with just that change. The history of deleted tasks stays available.
+func (s *HistoryService) ListHistory(ctx context.Context, taskID uuid.UUID, field string) ([]models.HistoryEntry, error) {
+	log.Printf("Listing history of task: ID=%s, Field=%s", taskID, field)
+
+	if field != "" {
+		if _, err := utils.ParseFields(field); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil {
+		log.Printf("Failed to list history: ID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+			return nil, err
+		}
+	}
+
+	if field == "" {
+		return entries, nil
+	}
+
+	filtered := make([]models.HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		for _, change := range entry.Changes {
+			if change.Field == field {
+				entry.Changes = []models.FieldChange{change}
+				filtered = append(filtered, entry)
+				break
+			}
+		}
+	}
+
+	return filtered, nil
+}
+
+// TaskAt reconstructs a task as it was at the given time by replaying its history. It fails with
+// ErrTaskNotFoundAt when the task did not exist yet or had already been deleted.
+func (s *HistoryService) TaskAt(ctx context.Context, taskID uuid.UUID, at time.Time) (*models.Task, error) {
+	log.Printf("Reconstructing task: ID=%s, At=%s", taskID, at.Format(time.RFC3339))
+
+	entries, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil {
+		log.Printf("Failed to list history: ID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	var last *models.HistoryEntry
+	for i := range entries {
+		if entries[i].At.After(at) {
+			break
+		}
+		utils.ApplyChanges(fields, entries[i].Changes)
+		last = &entries[i]
+	}
+
+	if last == nil || last.Action == models.HistoryDeleted {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFoundAt, at.Format(time.RFC3339))
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var task models.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	task.ID = taskID
+	task.UpdatedAt = last.At
+
+	return &task, nil
+}
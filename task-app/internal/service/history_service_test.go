@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHistoryServices() (*HistoryService, *TaskService, *HistoryRecorder) {
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	recorder := NewHistoryRecorder(repository.NewInMemoryTaskRepository(), historyRepo)
+	taskService := NewTaskService(recorder)
+
+	return NewHistoryService(historyRepo, taskService), taskService, recorder
+}
+
+func TestTaskHistory(t *testing.T) {
+	service, taskService, _ := newHistoryServices()
+	actor := uuid.New()
+	ctx := auth.WithUser(context.Background(), actor)
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+
+	// Changing the returned task does not bypass the history
+	task.Priority = models.PriorityHigh
+	assert.NoError(t, taskService.UpdateTask(ctx, task))
+	assert.NoError(t, taskService.UpdateTask(ctx, task))
+
+	entries, err := service.ListHistory(ctx, task.ID, "")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, models.HistoryCreated, entries[0].Action)
+	assert.Equal(t, models.HistoryUpdated, entries[1].Action)
+	assert.Equal(t, actor, *entries[1].ActorID)
+	assert.Equal(t, []models.FieldChange{
+		{Field: "priority", Before: json.RawMessage(`"MEDIUM"`), After: json.RawMessage(`"HIGH"`)},
+	}, entries[1].Changes)
+
+	priority, err := service.ListHistory(ctx, task.ID, "priority")
+	assert.NoError(t, err)
+	assert.Len(t, priority, 2)
+	assert.Len(t, priority[0].Changes, 1)
+
+	_, err = service.ListHistory(ctx, task.ID, "colour")
+	assert.ErrorIs(t, err, utils.ErrUnknownField)
+
+	_, err = service.ListHistory(ctx, uuid.New(), "")
+	assert.Error(t, err)
+}
+
+func TestTaskAt(t *testing.T) {
+	service, taskService, _ := newHistoryServices()
+	ctx := context.Background()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+	createdAt := time.Now()
+	time.Sleep(time.Millisecond)
+
+	updated := *task
+	updated.Title = "Deploy to prod"
+	updated.Status = models.StatusInProgress
+	assert.NoError(t, taskService.UpdateTask(ctx, &updated))
+	updatedAt := time.Now()
+	time.Sleep(time.Millisecond)
+
+	assert.NoError(t, taskService.DeleteTask(ctx, task.ID))
+
+	_, err = service.TaskAt(ctx, task.ID, task.CreatedAt.Add(-time.Second))
+	assert.ErrorIs(t, err, ErrTaskNotFoundAt)
+
+	original, err := service.TaskAt(ctx, task.ID, createdAt)
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, original.ID)
+	assert.Equal(t, "Deploy", original.Title)
+	assert.Equal(t, models.StatusToDo, original.Status)
+	assert.True(t, original.DueDate.Equal(task.DueDate))
+
+	changed, err := service.TaskAt(ctx, task.ID, updatedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "Deploy to prod", changed.Title)
+	assert.Equal(t, models.StatusInProgress, changed.Status)
+
+	_, err = service.TaskAt(ctx, task.ID, time.Now())
+	assert.ErrorIs(t, err, ErrTaskNotFoundAt)
+
+	// History outlives the task
+	entries, err := service.ListHistory(ctx, task.ID, "")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, models.HistoryDeleted, entries[2].Action)
+}
+
+func TestBulkChangesAreRecorded(t *testing.T) {
+	service, taskService, recorder := newHistoryServices()
+	tagService := NewTagService(repository.NewInMemoryTagRepository(), recorder)
+	ctx := context.Background()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+	tag, err := tagService.CreateTag(ctx, models.CreateTagRequest{Name: "ops", Color: "#ff0000"})
+	assert.NoError(t, err)
+	_, err = tagService.AttachTag(ctx, task.ID, tag.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, tagService.DeleteTag(ctx, tag.ID))
+
+	tags, err := service.ListHistory(ctx, task.ID, "tags")
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+	assert.Nil(t, tags[1].Changes[0].After)
+}
+
+func TestBulkChangesRecordOnlyTouchedTasks(t *testing.T) {
+	taskRepo := repository.NewInMemoryTaskRepository()
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	recorder := NewHistoryRecorder(taskRepo, historyRepo)
+	taskService := NewTaskService(recorder)
+	service := NewHistoryService(historyRepo, taskService)
+	ctx := context.Background()
+
+	parent := createTask(t, taskService, newTaskRequest("Release"))
+	child := newTaskRequest("Notes")
+	child.ParentID = &parent.ID
+	createTask(t, taskService, child)
+	dependent := createTask(t, taskService, newTaskRequest("Announce"))
+	_, err := taskService.AddDependency(ctx, dependent.ID, parent.ID)
+	assert.NoError(t, err)
+
+	// A change made without the recorder is not attributed to the next bulk change
+	bystander := createTask(t, taskService, newTaskRequest("Unrelated"))
+	changed := *bystander
+	changed.Title = "Changed elsewhere"
+	assert.NoError(t, taskRepo.Update(ctx, &changed))
+
+	assert.NoError(t, taskService.DeleteTaskWithMode(ctx, parent.ID, models.DeleteCascade))
+
+	entries, err := service.ListHistory(ctx, dependent.ID, "blocked_by")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, models.HistoryUpdated, entries[1].Action)
+
+	entries, err = service.ListHistory(ctx, bystander.ID, "")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestProjectRenameIsRecorded(t *testing.T) {
+	projectRepo := repository.NewInMemoryProjectRepository()
+	taskRepo := repository.NewInMemoryTaskRepository()
+	taskRepo.SetProjectNames(projectRepo)
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	recorder := NewHistoryRecorder(taskRepo, historyRepo)
+	taskService := NewTaskService(recorder)
+	taskService.SetProjectRepository(projectRepo)
+	projectService := NewProjectService(projectRepo, recorder, nil)
+	projectService.OnProjectRenamed(recorder.RecordProjectRename)
+	service := NewHistoryService(historyRepo, taskService)
+
+	owner, editor := uuid.New(), uuid.New()
+	project, err := projectService.CreateProject(context.Background(), models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+	req := newTaskRequest("Rotate keys")
+	req.Category = "Ops"
+	task := createTask(t, taskService, req)
+
+	project.Name = "Operations"
+	assert.NoError(t, projectService.UpdateProject(auth.WithUser(context.Background(), owner), project))
+
+	// The next update of the task does not pick up the category change
+	stored, err := taskService.GetTask(context.Background(), task.ID)
+	assert.NoError(t, err)
+	stored.Priority = models.PriorityHigh
+	assert.NoError(t, taskService.UpdateTask(auth.WithUser(context.Background(), editor), stored))
+
+	entries, err := service.ListHistory(context.Background(), task.ID, "category")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, owner, *entries[1].ActorID)
+	assert.JSONEq(t, `"Operations"`, string(entries[1].Changes[0].After))
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTaskAlreadyInMilestone = errors.New("task is already in milestone")
+	ErrTaskNotInMilestone     = errors.New("task is not in milestone")
+)
+
+// MilestoneService manages milestones and the tasks contributing to them. A task belongs to at most one milestone.
+type MilestoneService struct {
+	mu          sync.Mutex
+	repo        repository.MilestoneRepository
+	taskService *TaskService
+	validator   *utils.Validator
+}
+
+func NewMilestoneService(repo repository.MilestoneRepository, taskService *TaskService) *MilestoneService {
+	s := &MilestoneService{
+		repo:        repo,
+		taskService: taskService,
+		validator:   utils.NewValidator(),
+	}
+
+	taskService.OnTaskReplay(s.dropDeletedMilestone)
+
+	return s
+}
+
+// dropDeletedMilestone takes a task that undo or redo writes back out of its milestone when the milestone
+// was deleted
+func (s *MilestoneService) dropDeletedMilestone(ctx context.Context, task *models.Task) error {
+	if task.MilestoneID == nil {
+		return nil
+	}
+
+	_, err := s.repo.GetByID(ctx, *task.MilestoneID)
+	if errors.Is(err, repository.ErrNotFound) {
+		task.MilestoneID = nil
+		return nil
+	}
+
+	return err
+}
+
+func (s *MilestoneService) CreateMilestone(ctx context.Context, req models.CreateMilestoneRequest) (*models.Milestone, error) {
+	milestone := &models.Milestone{
+		Name:        req.Name,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+	}
+
+	log.Printf("Creating milestone: Name=%s, DueDate=%s", milestone.Name, milestone.DueDate)
+
+	if err := s.validator.ValidateMilestone(milestone); err != nil {
+		log.Printf("Milestone validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, milestone); err != nil {
+		log.Printf("Failed to create milestone: Name=%s, Error=%v", milestone.Name, err)
+
+		return nil, err
+	}
+
+	log.Printf("Milestone created successfully: ID=%s", milestone.ID)
+
+	return milestone, nil
+}
+
+func (s *MilestoneService) GetMilestone(ctx context.Context, id uuid.UUID) (*models.Milestone, error) {
+	log.Printf("Fetching milestone: ID=%s", id)
+
+	milestone, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to fetch milestone: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	if err := s.countTasks(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+func (s *MilestoneService) ListMilestones(ctx context.Context) ([]models.Milestone, error) {
+	log.Println("Listing milestones")
+
+	milestones, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list milestones: Error=%v", err)
+
+		return nil, err
+	}
+
+	for i := range milestones {
+		if err := s.countTasks(ctx, &milestones[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return milestones, nil
+}
+
+func (s *MilestoneService) UpdateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	log.Printf("Updating milestone: ID=%s, Name=%s", milestone.ID, milestone.Name)
+
+	if err := s.validator.ValidateMilestone(milestone); err != nil {
+		log.Printf("Milestone validation failed: ID=%s, Error=%v", milestone.ID, err)
+		return err
+	}
+
+	if err := s.repo.Update(ctx, milestone); err != nil {
+		log.Printf("Failed to update milestone: ID=%s, Error=%v", milestone.ID, err)
+
+		return err
+	}
+
+	log.Printf("Milestone updated successfully: ID=%s", milestone.ID)
+
+	return s.countTasks(ctx, milestone)
+}
+
+// DeleteMilestone takes every task out of a milestone and deletes it.
+func (s *MilestoneService) DeleteMilestone(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Deleting milestone: ID=%s", id)
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		log.Printf("Failed to delete milestone: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	tasks, err := s.taskService.repo.List(ctx, map[string]interface{}{"milestone_id": id})
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.MilestoneID = nil
+		if err := s.taskService.repo.Update(ctx, &task); err != nil {
+			log.Printf("Failed to remove task from milestone: TaskID=%s, Error=%v", task.ID, err)
+
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete milestone: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("Milestone deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// ListMilestoneTasks returns the tasks contributing to a milestone.
+func (s *MilestoneService) ListMilestoneTasks(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.taskService.ListTasks(ctx, map[string]interface{}{"milestone_id": id})
+}
+
+// AddTask links a task to a milestone, moving it away from any other milestone.
+func (s *MilestoneService) AddTask(ctx context.Context, milestoneID, taskID uuid.UUID) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Adding task to milestone: MilestoneID=%s, TaskID=%s", milestoneID, taskID)
+
+	if _, err := s.repo.GetByID(ctx, milestoneID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskService.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.MilestoneID != nil && *task.MilestoneID == milestoneID {
+		return nil, ErrTaskAlreadyInMilestone
+	}
+
+	updated := *task
+	updated.MilestoneID = &milestoneID
+
+	if err := s.taskService.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to add task to milestone: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Task added to milestone successfully: MilestoneID=%s, TaskID=%s", milestoneID, taskID)
+
+	return &updated, nil
+}
+
+func (s *MilestoneService) RemoveTask(ctx context.Context, milestoneID, taskID uuid.UUID) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Removing task from milestone: MilestoneID=%s, TaskID=%s", milestoneID, taskID)
+
+	task, err := s.taskService.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.MilestoneID == nil || *task.MilestoneID != milestoneID {
+		return nil, ErrTaskNotInMilestone
+	}
+
+	updated := *task
+	updated.MilestoneID = nil
+
+	if err := s.taskService.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to remove task from milestone: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Task removed from milestone successfully: MilestoneID=%s, TaskID=%s", milestoneID, taskID)
+
+	return &updated, nil
+}
+
+// countTasks fills in how many tasks contribute to a milestone and how many of them are finished
+func (s *MilestoneService) countTasks(ctx context.Context, milestone *models.Milestone) error {
+	tasks, err := s.taskService.repo.List(ctx, map[string]interface{}{"milestone_id": milestone.ID})
+	if err != nil {
+		return err
+	}
+
+	milestone.TotalTasks = len(tasks)
+	milestone.FinishedTasks = 0
+	for _, task := range tasks {
+		if s.taskService.isFinished(&task) {
+			milestone.FinishedTasks++
+		}
+	}
+
+	return nil
+}
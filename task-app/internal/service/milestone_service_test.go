@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMilestoneTasks(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewMilestoneService(repository.NewInMemoryMilestoneRepository(), taskService)
+	ctx := context.Background()
+
+	_, err := service.CreateMilestone(ctx, models.CreateMilestoneRequest{Name: "Beta"})
+	assert.ErrorIs(t, err, utils.ErrMissingMilestoneDate)
+
+	beta, err := service.CreateMilestone(ctx, models.CreateMilestoneRequest{Name: "Beta", DueDate: time.Now().AddDate(0, 1, 0)})
+	assert.NoError(t, err)
+
+	first := createTask(t, taskService, newTaskRequest("Invite testers"))
+	second := createTask(t, taskService, newTaskRequest("Collect feedback"))
+
+	for _, task := range []*models.Task{first, second} {
+		_, err := service.AddTask(ctx, beta.ID, task.ID)
+		assert.NoError(t, err)
+	}
+
+	_, err = service.AddTask(ctx, beta.ID, first.ID)
+	assert.ErrorIs(t, err, ErrTaskAlreadyInMilestone)
+
+	first.Status = models.StatusInProgress
+	assert.NoError(t, taskService.UpdateTask(ctx, first))
+	first.Status = models.StatusDone
+	assert.NoError(t, taskService.UpdateTask(ctx, first))
+
+	milestone, err := service.GetMilestone(ctx, beta.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, milestone.TotalTasks)
+	assert.Equal(t, 1, milestone.FinishedTasks)
+
+	_, err = service.RemoveTask(ctx, beta.ID, second.ID)
+	assert.NoError(t, err)
+	_, err = service.RemoveTask(ctx, beta.ID, second.ID)
+	assert.ErrorIs(t, err, ErrTaskNotInMilestone)
+
+	tasks, err := service.ListMilestoneTasks(ctx, beta.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	assert.NoError(t, service.DeleteMilestone(ctx, beta.ID))
+	stored, err := taskService.GetTask(ctx, first.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, stored.MilestoneID)
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// operationKey marks a context whose task writes belong to an operation being logged; its value is the
+// *operationChanges collecting them
+type operationKey struct{}
+
+// operationChanges collects the tasks written for one operation with their state before its first and after
+// its last write, and the data kept for the tasks it deleted
+type operationChanges struct {
+	mu     sync.Mutex
+	order  []uuid.UUID
+	before map[uuid.UUID]*models.Task
+	after  map[uuid.UUID]*models.Task
+	kept   map[uuid.UUID]map[string]json.RawMessage
+}
+
+func newOperationChanges() *operationChanges {
+	return &operationChanges{
+		before: make(map[uuid.UUID]*models.Task),
+		after:  make(map[uuid.UUID]*models.Task),
+		kept:   make(map[uuid.UUID]map[string]json.RawMessage),
+	}
+}
+
+// changesFrom returns the operation collecting the task writes made with ctx, or nil
+func changesFrom(ctx context.Context) *operationChanges {
+	changes, _ := ctx.Value(operationKey{}).(*operationChanges)
+	return changes
+}
+
+// wrote records a write of a task. before is nil when the task did not exist and after is nil when the
+// write deleted it.
+func (c *operationChanges) wrote(id uuid.UUID, before, after *models.Task) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, seen := c.before[id]; !seen {
+		c.order = append(c.order, id)
+		c.before[id] = before
+	}
+	c.after[id] = after
+}
+
+// keep stores data attached to a task the operation is about to delete
+func (c *operationChanges) keep(id uuid.UUID, name string, data json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.kept[id] == nil {
+		c.kept[id] = make(map[string]json.RawMessage)
+	}
+	c.kept[id][name] = data
+}
+
+// keptFor returns the data kept for a deleted task
+func (c *operationChanges) keptFor(id uuid.UUID) map[string]json.RawMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.kept[id]
+}
+
+// taskChanges lists the written tasks, in the order they were first written, whose state differs between
+// the start and the end of the operation
+func (c *operationChanges) taskChanges() []models.TaskChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var changes []models.TaskChange
+	for _, id := range c.order {
+		before, after := c.before[id], c.after[id]
+		if before == nil && after == nil {
+			continue
+		}
+		if before != nil && after != nil {
+			if diff, err := utils.DiffTasks(before, after); err == nil && len(diff) == 0 {
+				continue
+			}
+		}
+
+		change := models.TaskChange{TaskID: id, Before: before, After: after}
+		if after == nil {
+			change.Attached = c.kept[id]
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// operationRecorder wraps the task repository of a TaskService and reports every task written with a
+// context of a logged operation to that operation, so an operation holds exactly the tasks it wrote and
+// never the changes other requests made meanwhile.
+type operationRecorder struct {
+	repository.TaskRepository
+	mu sync.Mutex
+}
+
+func (r *operationRecorder) Create(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.TaskRepository.Create(ctx, task); err != nil {
+		return err
+	}
+
+	r.wrote(ctx, task.ID, nil)
+
+	return nil
+}
+
+func (r *operationRecorder) Update(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var before *models.Task
+	if changesFrom(ctx) != nil {
+		if existing, err := r.TaskRepository.GetByID(ctx, task.ID); err == nil {
+			before = cloneTask(existing)
+		}
+	}
+
+	if err := r.TaskRepository.Update(ctx, task); err != nil {
+		return err
+	}
+
+	r.wrote(ctx, task.ID, before)
+
+	return nil
+}
+
+func (r *operationRecorder) Restore(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.TaskRepository.Restore(ctx, task); err != nil {
+		return err
+	}
+
+	r.wrote(ctx, task.ID, nil)
+
+	return nil
+}
+
+func (r *operationRecorder) Delete(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.wroteTouched(ctx, func() ([]models.Task, error) { return r.TaskRepository.Delete(ctx, id) })
+}
+
+func (r *operationRecorder) DeleteTree(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.wroteTouched(ctx, func() ([]models.Task, error) { return r.TaskRepository.DeleteTree(ctx, id) })
+}
+
+func (r *operationRecorder) MergeTag(ctx context.Context, from, into uuid.UUID) ([]models.Task, error) {
+	return r.wroteTouched(ctx, func() ([]models.Task, error) { return r.TaskRepository.MergeTag(ctx, from, into) })
+}
+
+func (r *operationRecorder) DetachTag(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.wroteTouched(ctx, func() ([]models.Task, error) { return r.TaskRepository.DetachTag(ctx, id) })
+}
+
+func (r *operationRecorder) UnassignUser(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	return r.wroteTouched(ctx, func() ([]models.Task, error) { return r.TaskRepository.UnassignUser(ctx, id) })
+}
+
+func (r *operationRecorder) Duplicate(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied, err := r.TaskRepository.Duplicate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.wrote(ctx, copied.ID, nil)
+
+	return copied, nil
+}
+
+func (r *operationRecorder) DuplicateTree(ctx context.Context, id uuid.UUID) (*models.Task, map[uuid.UUID]uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied, copies, err := r.TaskRepository.DuplicateTree(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, copyID := range copies {
+		r.wrote(ctx, copyID, nil)
+	}
+
+	return copied, copies, nil
+}
+
+// wroteTouched runs a write that returns the tasks it touched as they were before and reports each of them
+func (r *operationRecorder) wroteTouched(ctx context.Context, write func() ([]models.Task, error)) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	touched, err := write()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range touched {
+		r.wrote(ctx, touched[i].ID, cloneTask(&touched[i]))
+	}
+
+	return touched, nil
+}
+
+// wrote reports a write of a task to the operation of ctx, if any, along with the state the task had before.
+// The state afterwards is read back from the repository; the caller's task may still change.
+func (r *operationRecorder) wrote(ctx context.Context, id uuid.UUID, before *models.Task) {
+	changes := changesFrom(ctx)
+	if changes == nil {
+		return
+	}
+
+	var after *models.Task
+	if stored, err := r.TaskRepository.GetByID(ctx, id); err == nil {
+		after = cloneTask(stored)
+	}
+
+	changes.wrote(id, before, after)
+}
@@ -0,0 +1,575 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOperationUserRequired = errors.New("undo and redo require an authenticated user")
+	ErrNothingToUndo         = errors.New("no operation to undo")
+	ErrNothingToRedo         = errors.New("no operation to redo")
+	ErrOperationConflict     = errors.New("task was changed since the operation")
+)
+
+// SetOperationRepository enables the per-user operation log behind Undo and Redo, keeping the last limit
+// operations of each user. Only operations of authenticated users are logged.
+func (s *TaskService) SetOperationRepository(repo repository.OperationRepository, limit int) {
+	if limit <= 0 {
+		limit = models.DefaultOperationLogSize
+	}
+
+	if _, wrapped := s.repo.(*operationRecorder); !wrapped {
+		s.repo = &operationRecorder{TaskRepository: s.repo}
+	}
+	s.operations = repo
+	s.operationLimit = limit
+}
+
+// TaskKeepHook returns the data of kind attached to a task that a logged operation is about to delete, so
+// that undoing the delete can bring it back. A nil result keeps nothing.
+type TaskKeepHook func(ctx context.Context, id uuid.UUID) (interface{}, error)
+
+// TaskRestoreHook brings back the data a TaskKeepHook kept, once undoing the delete restored the task.
+type TaskRestoreHook func(ctx context.Context, id uuid.UUID, data json.RawMessage) error
+
+type taskKeeper struct {
+	kind    string
+	keep    TaskKeepHook
+	restore TaskRestoreHook
+}
+
+// KeepOnDelete registers hooks that keep the data of kind attached to the tasks a logged operation deletes
+// in the operation log, so undoing the delete restores the data along with the tasks. Delete hooks still
+// remove the data itself.
+func (s *TaskService) KeepOnDelete(kind string, keep TaskKeepHook, restore TaskRestoreHook) {
+	s.keepers = append(s.keepers, taskKeeper{kind: kind, keep: keep, restore: restore})
+}
+
+// TaskReplayHook removes the references of a task that undo or redo is about to write to data that was
+// deleted since the operation, as deleting the data removed them from the tasks that existed then.
+type TaskReplayHook func(ctx context.Context, task *models.Task) error
+
+// OnTaskReplay registers a hook that runs for every task undo or redo is about to write back.
+func (s *TaskService) OnTaskReplay(hook TaskReplayHook) {
+	s.replayHooks = append(s.replayHooks, hook)
+}
+
+// KeptData returns the data of kind kept for deleted tasks in the operation logs of all users, which undo
+// may still restore.
+func (s *TaskService) KeptData(ctx context.Context, kind string) ([]json.RawMessage, error) {
+	if s.operations == nil {
+		return nil, nil
+	}
+
+	operations, err := s.operations.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []json.RawMessage
+	for _, operation := range operations {
+		for _, change := range operation.Changes {
+			if data, ok := change.Attached[kind]; ok {
+				kept = append(kept, data)
+			}
+		}
+	}
+
+	return kept, nil
+}
+
+// keepAttached runs the keep hooks for tasks the operation of ctx, if any, is about to delete
+func (s *TaskService) keepAttached(ctx context.Context, ids []uuid.UUID) error {
+	changes := changesFrom(ctx)
+	if changes == nil {
+		return nil
+	}
+
+	for _, id := range ids {
+		for _, keeper := range s.keepers {
+			data, err := keeper.keep(ctx, id)
+			if err != nil {
+				return fmt.Errorf("keeping %s of task %s: %w", keeper.kind, id, err)
+			}
+			if data == nil {
+				continue
+			}
+
+			raw, err := json.Marshal(data)
+			if err != nil {
+				return err
+			}
+			changes.keep(id, keeper.kind, raw)
+		}
+	}
+
+	return nil
+}
+
+// restoreAttached runs the restore hooks for the data kept for a task that was restored
+func (s *TaskService) restoreAttached(ctx context.Context, id uuid.UUID, attached map[string]json.RawMessage) error {
+	for _, keeper := range s.keepers {
+		data, ok := attached[keeper.kind]
+		if !ok {
+			continue
+		}
+		if err := keeper.restore(ctx, id, data); err != nil {
+			return fmt.Errorf("restoring %s of task %s: %w", keeper.kind, id, err)
+		}
+	}
+
+	return nil
+}
+
+// ListOperations returns the operation log of the authenticated user, newest first.
+func (s *TaskService) ListOperations(ctx context.Context) ([]models.Operation, error) {
+	userID, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrOperationUserRequired
+	}
+
+	if s.operations == nil {
+		return []models.Operation{}, nil
+	}
+
+	operations, err := s.operations.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(operations)-1; i < j; i, j = i+1, j-1 {
+		operations[i], operations[j] = operations[j], operations[i]
+	}
+
+	return operations, nil
+}
+
+// Undo reverts the latest operation of the authenticated user that is not undone yet. It fails with
+// ErrOperationConflict, changing nothing, when any task the operation touched was changed since. When
+// writing a task fails part way, the tasks written before are put back, so a failed undo changes nothing.
+func (s *TaskService) Undo(ctx context.Context) (*models.Operation, error) {
+	return s.replayOperation(ctx, true)
+}
+
+// Redo performs the operation undone last again, under the same conflict rules as Undo.
+func (s *TaskService) Redo(ctx context.Context) (*models.Operation, error) {
+	return s.replayOperation(ctx, false)
+}
+
+func (s *TaskService) replayOperation(ctx context.Context, undo bool) (*models.Operation, error) {
+	userID, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrOperationUserRequired
+	}
+
+	nothing := ErrNothingToRedo
+	if undo {
+		nothing = ErrNothingToUndo
+	}
+	if s.operations == nil {
+		return nil, nothing
+	}
+
+	s.operationMu.Lock()
+	defer s.operationMu.Unlock()
+	ctx = context.WithValue(ctx, operationKey{}, newOperationChanges())
+	ctx, keepHistory := holdHistory(ctx)
+
+	operations, err := s.operations.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Undone operations always form the end of the log: undo takes the last operation that is still
+	// done and redo the first one that is undone
+	var operation *models.Operation
+	for i := range operations {
+		if operations[i].UndoneAt != nil {
+			if !undo {
+				operation = &operations[i]
+			}
+			break
+		}
+		if undo {
+			operation = &operations[i]
+		}
+	}
+	if operation == nil {
+		return nil, nothing
+	}
+
+	log.Printf("Replaying operation: ID=%s, Type=%s, Undo=%t", operation.ID, operation.Type, undo)
+
+	if err := s.applyOperation(ctx, operation, undo); err != nil {
+		log.Printf("Failed to replay operation: ID=%s, Error=%v", operation.ID, err)
+		keepHistory(false)
+
+		return nil, err
+	}
+	keepHistory(true)
+
+	if undo {
+		now := time.Now()
+		operation.UndoneAt = &now
+	} else {
+		operation.UndoneAt = nil
+	}
+	if err := s.operations.Update(ctx, operation); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Operation replayed successfully: ID=%s, Undo=%t", operation.ID, undo)
+
+	return operation, nil
+}
+
+// applyOperation moves every task of an operation from one side of its change to the other, after checking
+// that all of them are still exactly as the operation left them and that the tasks it writes are valid.
+// Tasks take the same paths as regular changes: deleted tasks lose their attached data, restored tasks get
+// back the data kept when they were deleted, and tasks blocked by a changed task are re-evaluated. When a
+// write fails, the tasks already written are put back as they were.
+func (s *TaskService) applyOperation(ctx context.Context, operation *models.Operation, undo bool) error {
+	type step struct {
+		change   *models.TaskChange
+		current  *models.Task
+		target   *models.Task
+		expected *models.Task
+	}
+
+	steps := make([]step, 0, len(operation.Changes))
+	replayed := make(map[uuid.UUID]bool)
+	for i := range operation.Changes {
+		change := &operation.Changes[i]
+		st := step{change: change, expected: change.After, target: change.Before}
+		if !undo {
+			st.expected, st.target = change.Before, change.After
+		}
+
+		if current, err := s.repo.GetByID(ctx, change.TaskID); err == nil {
+			st.current = current
+		}
+		if (st.current != nil) != (st.expected != nil) {
+			return fmt.Errorf("%w: %s", ErrOperationConflict, change.TaskID)
+		}
+		if st.current != nil {
+			// Renaming a project changes the category of its tasks without changing the tasks
+			expected := *st.expected
+			if expected.ProjectID != nil && st.current.ProjectID != nil && *expected.ProjectID == *st.current.ProjectID {
+				expected.Category = st.current.Category
+			}
+			if diff, err := utils.DiffTasks(st.current, &expected); err != nil || len(diff) > 0 {
+				return fmt.Errorf("%w: %s", ErrOperationConflict, change.TaskID)
+			}
+		}
+		if st.current == nil || st.target == nil {
+			replayed[change.TaskID] = st.target != nil
+		}
+
+		steps = append(steps, st)
+	}
+
+	// Every task is checked before anything changes
+	for i := range steps {
+		if steps[i].target == nil {
+			continue
+		}
+
+		task := cloneTask(steps[i].target)
+		if err := s.checkReplay(ctx, steps[i].current, task, replayed); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrOperationConflict, task.ID, err)
+		}
+		steps[i].target = task
+	}
+
+	changes := changesFrom(ctx)
+	write := func() error {
+		// Deletions go first, so the tasks restored or updated afterwards end up exactly as recorded
+		for _, st := range steps {
+			if st.target != nil {
+				continue
+			}
+			if err := s.DeleteTaskWithMode(ctx, st.change.TaskID, models.DeleteOrphan); err != nil {
+				return err
+			}
+		}
+
+		for _, st := range steps {
+			if st.target == nil {
+				continue
+			}
+
+			task := st.target
+			write := func() error { return s.repo.Update(ctx, task) }
+			if st.current == nil {
+				write = func() error { return s.repo.Restore(ctx, task) }
+			}
+			if err := s.storeLinked(ctx, task, write); err != nil {
+				return err
+			}
+
+			if st.current == nil {
+				if err := s.restoreAttached(ctx, task.ID, st.change.Attached); err != nil {
+					log.Printf("Failed to restore attached data: ID=%s, Error=%v", task.ID, err)
+				}
+			}
+		}
+
+		return nil
+	}
+	if err := write(); err != nil {
+		s.revertReplay(ctx, changes)
+
+		return err
+	}
+
+	// The operation now holds the tasks as written, which may have lost references to deleted data, and
+	// the data attached to the deleted tasks is kept again, for a later replay to restore
+	for _, st := range steps {
+		switch {
+		case st.target == nil:
+			st.change.Attached = changes.keptFor(st.change.TaskID)
+		case undo:
+			st.change.Before = cloneTask(st.target)
+		default:
+			st.change.After = cloneTask(st.target)
+		}
+	}
+
+	// Restored and changed tasks are checked against their blockers, which may have changed since, and the
+	// tasks they block are re-evaluated
+	for _, st := range steps {
+		if st.target == nil {
+			continue
+		}
+
+		if task, err := s.repo.GetByID(ctx, st.target.ID); err == nil && len(task.BlockedBy) > 0 {
+			s.refreshBlockedStatus(ctx, *task)
+		}
+		if st.current == nil || st.current.Status != st.target.Status {
+			if err := s.refreshDependents(ctx, st.target.ID); err != nil {
+				log.Printf("Failed to refresh dependent tasks: ID=%s, Error=%v", st.target.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// revertReplay puts every task a failed replay wrote back as it was before the replay. Tasks the replay
+// restored are removed again along with their attached data, and tasks it deleted come back with the data
+// kept when they were deleted.
+func (s *TaskService) revertReplay(ctx context.Context, changes *operationChanges) {
+	written := changes.taskChanges()
+
+	for _, change := range written {
+		if change.Before != nil {
+			continue
+		}
+		if _, err := s.repo.Delete(ctx, change.TaskID); err != nil {
+			log.Printf("Failed to revert replayed task: ID=%s, Error=%v", change.TaskID, err)
+			continue
+		}
+		for _, hook := range s.deleteHooks {
+			hook(ctx, change.TaskID)
+		}
+	}
+
+	for _, change := range written {
+		if change.Before == nil {
+			continue
+		}
+
+		task := cloneTask(change.Before)
+		var err error
+		if change.After == nil {
+			if err = s.repo.Restore(ctx, task); err == nil {
+				err = s.restoreAttached(ctx, task.ID, change.Attached)
+			}
+		} else {
+			err = s.repo.Update(ctx, task)
+		}
+		if err != nil {
+			log.Printf("Failed to revert replayed task: ID=%s, Error=%v", change.TaskID, err)
+		}
+	}
+}
+
+// checkReplay checks a task a replay is about to write like a regular change: its project, assignees and
+// custom fields must still exist, it must be valid, its parent must exist or be restored by the same replay,
+// and a task that exists must be allowed to move to its recorded status under its workflow. References to
+// blocking tasks and other data deleted since are dropped, and a task left without blockers is unblocked.
+func (s *TaskService) checkReplay(ctx context.Context, current, task *models.Task, replayed map[uuid.UUID]bool) error {
+	// The category follows the project, which may have been renamed since
+	if s.projects != nil && task.ProjectID != nil {
+		task.Category = ""
+	}
+	if err := s.applyProject(ctx, task, false); err != nil {
+		return err
+	}
+
+	if err := s.validator.ValidateTask(task); err != nil {
+		return err
+	}
+
+	if task.ParentID != nil && !s.existsAfterReplay(ctx, *task.ParentID, replayed) {
+		return fmt.Errorf("%w: %s", ErrParentNotFound, *task.ParentID)
+	}
+
+	if err := s.checkPeople(ctx, task); err != nil {
+		return err
+	}
+
+	if err := s.applyCustomFields(ctx, task, false); err != nil {
+		return err
+	}
+
+	blockers := make([]uuid.UUID, 0, len(task.BlockedBy))
+	for _, blockerID := range task.BlockedBy {
+		if s.existsAfterReplay(ctx, blockerID, replayed) {
+			blockers = append(blockers, blockerID)
+		}
+	}
+	unblocked := len(task.BlockedBy) > 0 && len(blockers) == 0
+	task.BlockedBy = blockers
+
+	for _, hook := range s.replayHooks {
+		if err := hook(ctx, task); err != nil {
+			return err
+		}
+	}
+
+	if current != nil {
+		if err := s.checkTransition(current, task); err != nil {
+			return err
+		}
+	}
+
+	if unblocked {
+		return s.applyBlockedStatus(ctx, task, true)
+	}
+
+	return nil
+}
+
+// existsAfterReplay reports whether a task exists once a replay is done. replayed tells the tasks the
+// replay restores from those it deletes; any other task must still be stored.
+func (s *TaskService) existsAfterReplay(ctx context.Context, id uuid.UUID, replayed map[uuid.UUID]bool) bool {
+	if exists, ok := replayed[id]; ok {
+		return exists
+	}
+
+	_, err := s.repo.GetByID(ctx, id)
+	return err == nil
+}
+
+// beginOperation starts logging an operation of the authenticated user and returns the function that
+// completes it. The operation holds every task written with the returned context, including writes made by
+// nested calls, and nothing else.
+func (s *TaskService) beginOperation(ctx context.Context, operationType models.OperationType) (context.Context, func(taskID uuid.UUID, err error)) {
+	noop := func(uuid.UUID, error) {}
+	if s.operations == nil || changesFrom(ctx) != nil {
+		return ctx, noop
+	}
+
+	userID, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return ctx, noop
+	}
+
+	changes := newOperationChanges()
+	ctx = context.WithValue(ctx, operationKey{}, changes)
+
+	return ctx, func(taskID uuid.UUID, err error) {
+		if err != nil {
+			return
+		}
+
+		taskChanges := changes.taskChanges()
+		if len(taskChanges) == 0 {
+			return
+		}
+
+		s.operationMu.Lock()
+		defer s.operationMu.Unlock()
+
+		s.logOperation(ctx, &models.Operation{
+			UserID:  userID,
+			Type:    operationType,
+			TaskID:  taskID,
+			Changes: taskChanges,
+			At:      time.Now(),
+		})
+	}
+}
+
+// logOperation appends an operation to the log of its user. Undone operations can no longer be redone
+// once a new operation is logged, and only the latest operations up to the limit are kept.
+func (s *TaskService) logOperation(ctx context.Context, operation *models.Operation) {
+	operations, err := s.operations.ListByUser(ctx, operation.UserID)
+	if err != nil {
+		log.Printf("Failed to log operation: Error=%v", err)
+		return
+	}
+
+	kept := 0
+	for _, existing := range operations {
+		if existing.UndoneAt != nil {
+			if err := s.operations.Delete(ctx, existing.ID); err != nil {
+				log.Printf("Failed to discard undone operation: ID=%s, Error=%v", existing.ID, err)
+			}
+			continue
+		}
+		kept++
+	}
+
+	for i := 0; i < len(operations) && kept >= s.operationLimit; i++ {
+		if operations[i].UndoneAt != nil {
+			continue
+		}
+		if err := s.operations.Delete(ctx, operations[i].ID); err != nil {
+			log.Printf("Failed to trim operation log: ID=%s, Error=%v", operations[i].ID, err)
+		}
+		kept--
+	}
+
+	if err := s.operations.Create(ctx, operation); err != nil {
+		log.Printf("Failed to log operation: Error=%v", err)
+	}
+}
+
+// taskID returns the ID of a task, or the nil UUID when there is no task
+func taskID(task *models.Task) uuid.UUID {
+	if task == nil {
+		return uuid.Nil
+	}
+
+	return task.ID
+}
+
+// cloneTask deep-copies a task through its JSON form
+func cloneTask(task *models.Task) *models.Task {
+	data, err := json.Marshal(task)
+	if err != nil {
+		copied := *task
+		return &copied
+	}
+
+	var copied models.Task
+	if err := json.Unmarshal(data, &copied); err != nil {
+		copied = *task
+	}
+
+	return &copied
+}
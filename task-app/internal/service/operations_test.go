@@ -0,0 +1,425 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOperationService(limit int) *TaskService {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	service.SetOperationRepository(repository.NewInMemoryOperationRepository(), limit)
+
+	return service
+}
+
+func TestUndoRedo(t *testing.T) {
+	service := newOperationService(0)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+
+	updated := *task
+	updated.Priority = models.PriorityHigh
+	assert.NoError(t, service.UpdateTask(ctx, &updated))
+
+	operation, err := service.Undo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationUpdate, operation.Type)
+	stored, err := service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PriorityMedium, stored.Priority)
+
+	operation, err = service.Undo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationCreate, operation.Type)
+	_, err = service.GetTask(ctx, task.ID)
+	assert.Error(t, err)
+
+	_, err = service.Undo(ctx)
+	assert.ErrorIs(t, err, ErrNothingToUndo)
+
+	// Redo brings the task back under its own ID, then reapplies the update
+	_, err = service.Redo(ctx)
+	assert.NoError(t, err)
+	_, err = service.Redo(ctx)
+	assert.NoError(t, err)
+	stored, err = service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PriorityHigh, stored.Priority)
+
+	_, err = service.Redo(ctx)
+	assert.ErrorIs(t, err, ErrNothingToRedo)
+
+	// A new operation discards what could have been redone
+	_, err = service.Undo(ctx)
+	assert.NoError(t, err)
+	_, err = service.DuplicateTask(ctx, task.ID)
+	assert.NoError(t, err)
+	_, err = service.Redo(ctx)
+	assert.ErrorIs(t, err, ErrNothingToRedo)
+
+	operations, err := service.ListOperations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 2)
+	assert.Equal(t, models.OperationDuplicate, operations[0].Type)
+	assert.Equal(t, models.OperationCreate, operations[1].Type)
+}
+
+func TestUndoConflict(t *testing.T) {
+	service := newOperationService(0)
+	alice := auth.WithUser(context.Background(), uuid.New())
+	bob := auth.WithUser(context.Background(), uuid.New())
+
+	task, err := service.CreateTask(alice, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+
+	byAlice := *task
+	byAlice.Priority = models.PriorityHigh
+	assert.NoError(t, service.UpdateTask(alice, &byAlice))
+
+	byBob := byAlice
+	byBob.Description = "Roll out to prod"
+	assert.NoError(t, service.UpdateTask(bob, &byBob))
+
+	_, err = service.Undo(alice)
+	assert.ErrorIs(t, err, ErrOperationConflict)
+
+	stored, err := service.GetTask(alice, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PriorityHigh, stored.Priority)
+	assert.Equal(t, "Roll out to prod", stored.Description)
+
+	// Bob's own change can still be undone
+	_, err = service.Undo(bob)
+	assert.NoError(t, err)
+	_, err = service.Undo(alice)
+	assert.NoError(t, err)
+	stored, err = service.GetTask(alice, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PriorityMedium, stored.Priority)
+}
+
+func TestUndoCascadeDelete(t *testing.T) {
+	service := newOperationService(0)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+	root, child, grandchild := seedTree(t, service)
+
+	assert.NoError(t, service.DeleteTaskWithMode(ctx, root.ID, models.DeleteCascade))
+	tasks, err := service.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	operation, err := service.Undo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationDelete, operation.Type)
+	assert.Len(t, operation.Changes, 3)
+
+	tree, err := service.GetSubtree(ctx, root.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tree.Children, 1)
+	assert.Equal(t, child.ID, tree.Children[0].Task.ID)
+	assert.Equal(t, grandchild.ID, tree.Children[0].Children[0].Task.ID)
+}
+
+func TestOperationLogLimit(t *testing.T) {
+	service := newOperationService(2)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		_, err := service.CreateTask(ctx, newTaskRequest(title))
+		assert.NoError(t, err)
+	}
+
+	operations, err := service.ListOperations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 2)
+
+	// Anonymous changes are not logged and cannot be undone
+	_, err = service.CreateTask(context.Background(), newTaskRequest("Anonymous"))
+	assert.NoError(t, err)
+	_, err = service.Undo(context.Background())
+	assert.ErrorIs(t, err, ErrOperationUserRequired)
+
+	operations, err = service.ListOperations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 2)
+}
+
+func TestOperationHoldsOnlyItsWrites(t *testing.T) {
+	service := newOperationService(0)
+	alice := auth.WithUser(context.Background(), uuid.New())
+	bob := auth.WithUser(context.Background(), uuid.New())
+
+	task, err := service.CreateTask(alice, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+
+	// Bob changes a task while an operation of Alice is in progress
+	ctx, finish := service.beginOperation(alice, models.OperationUpdate)
+	other, err := service.CreateTask(bob, newTaskRequest("Review"))
+	assert.NoError(t, err)
+	updated := *task
+	updated.Priority = models.PriorityHigh
+	assert.NoError(t, service.repo.Update(ctx, &updated))
+	finish(task.ID, nil)
+
+	operations, err := service.ListOperations(alice)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 2)
+	assert.Len(t, operations[0].Changes, 1)
+	assert.Equal(t, task.ID, operations[0].Changes[0].TaskID)
+
+	// Undoing Alice's operation leaves Bob's task alone
+	_, err = service.Undo(alice)
+	assert.NoError(t, err)
+	_, err = service.GetTask(bob, other.ID)
+	assert.NoError(t, err)
+}
+
+func TestUndoDeleteRestoresAttachedData(t *testing.T) {
+	service := newOperationService(0)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+
+	comments := NewCommentService(repository.NewInMemoryCommentRepository(), service)
+	blobs, err := repository.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	attachments := NewAttachmentService(repository.NewInMemoryAttachmentRepository(), blobs, service)
+	worklogs := NewWorklogService(repository.NewInMemoryWorklogRepository(), service)
+	reminders, _ := newReminderService(t, filepath.Join(t.TempDir(), "reminders.json"), service)
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+
+	comment, err := comments.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Ready?"})
+	assert.NoError(t, err)
+	_, err = comments.CreateComment(ctx, task.ID, models.CreateCommentRequest{Body: "Yes", ParentID: &comment.ID})
+	assert.NoError(t, err)
+	_, err = attachments.Upload(ctx, task.ID, "screen.png", bytes.NewReader(append(pngHeader, "pixels"...)))
+	assert.NoError(t, err)
+	start := time.Now().Add(-time.Hour)
+	_, err = worklogs.CreateWorklog(ctx, task.ID, models.CreateWorklogRequest{Start: start, End: start.Add(30 * time.Minute)})
+	assert.NoError(t, err)
+	_, err = reminders.CreateReminder(ctx, task.ID, models.CreateReminderRequest{OffsetMinutes: intPtr(60)})
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.DeleteTask(ctx, task.ID))
+
+	// The blob stays while undo can still bring its attachment back
+	removed, err := attachments.CollectGarbage(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	assertRestored := func() {
+		page, err := comments.ListComments(ctx, task.ID, 0, DefaultCommentPageSize)
+		assert.NoError(t, err)
+		assert.Len(t, page.Comments, 1)
+		assert.Len(t, page.Comments[0].Replies, 1)
+		assert.Equal(t, "Yes", page.Comments[0].Replies[0].Body)
+
+		files, err := attachments.ListAttachments(ctx, task.ID)
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		_, content, err := attachments.Open(ctx, task.ID, files[0].ID)
+		assert.NoError(t, err)
+		data, err := io.ReadAll(content)
+		content.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, append(pngHeader, "pixels"...), data)
+
+		logged, err := worklogs.ListWorklogs(ctx, task.ID)
+		assert.NoError(t, err)
+		assert.Len(t, logged, 1)
+
+		scheduled, err := reminders.ListReminders(ctx, task.ID)
+		assert.NoError(t, err)
+		assert.Len(t, scheduled, 1)
+	}
+
+	_, err = service.Undo(ctx)
+	assert.NoError(t, err)
+	assertRestored()
+
+	// Redoing the delete keeps the data again for the next undo
+	_, err = service.Redo(ctx)
+	assert.NoError(t, err)
+	_, err = service.GetTask(ctx, task.ID)
+	assert.Error(t, err)
+	_, err = service.Undo(ctx)
+	assert.NoError(t, err)
+	assertRestored()
+}
+
+func TestUndoRefreshesDependents(t *testing.T) {
+	service := newOperationService(0)
+	alice := auth.WithUser(context.Background(), uuid.New())
+	bob := auth.WithUser(context.Background(), uuid.New())
+
+	blocker, err := service.CreateTask(alice, newTaskRequest("Build"))
+	assert.NoError(t, err)
+	started := *blocker
+	started.Status = models.StatusInProgress
+	assert.NoError(t, service.UpdateTask(alice, &started))
+	done := started
+	done.Status = models.StatusDone
+	assert.NoError(t, service.UpdateTask(alice, &done))
+
+	dependent, err := service.CreateTask(bob, newTaskRequest("Release"))
+	assert.NoError(t, err)
+	_, err = service.AddDependency(bob, dependent.ID, blocker.ID)
+	assert.NoError(t, err)
+
+	// Reopening the blocker blocks the task that depends on it, as a regular update would
+	_, err = service.Undo(alice)
+	assert.NoError(t, err)
+	stored, err := service.GetTask(bob, dependent.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusBlocked, stored.Status)
+}
+
+func TestUndoChecksWorkflow(t *testing.T) {
+	service := newOperationService(0)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Deploy"))
+	assert.NoError(t, err)
+	done := *task
+	done.Status = models.StatusDone
+	assert.NoError(t, service.UpdateTask(ctx, &done))
+
+	// The default workflow does not allow going back from DONE to TODO
+	_, err = service.Undo(ctx)
+	assert.ErrorIs(t, err, ErrOperationConflict)
+	stored, err := service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusDone, stored.Status)
+}
+
+func TestUndoDropsDeletedReferences(t *testing.T) {
+	service := newOperationService(0)
+	tagService := NewTagService(repository.NewInMemoryTagRepository(), service.repo)
+	service.OnTaskReplay(tagService.DropDeletedTags)
+	alice := auth.WithUser(context.Background(), uuid.New())
+	bob := auth.WithUser(context.Background(), uuid.New())
+
+	blocker := createTask(t, service, newTaskRequest("Build"))
+	task := createTask(t, service, newTaskRequest("Release"))
+	_, err := service.AddDependency(bob, task.ID, blocker.ID)
+	assert.NoError(t, err)
+	tag, err := tagService.CreateTag(bob, models.CreateTagRequest{Name: "backend"})
+	assert.NoError(t, err)
+	_, err = tagService.AttachTag(bob, task.ID, tag.ID)
+	assert.NoError(t, err)
+
+	// The blocker and the tag are deleted while the task is gone
+	assert.NoError(t, service.DeleteTask(alice, task.ID))
+	assert.NoError(t, service.DeleteTask(bob, blocker.ID))
+	assert.NoError(t, tagService.DeleteTag(bob, tag.ID))
+
+	_, err = service.Undo(alice)
+	assert.NoError(t, err)
+	restored, err := service.GetTask(alice, task.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, restored.BlockedBy)
+	assert.Empty(t, restored.Tags)
+	assert.Equal(t, models.StatusToDo, restored.Status)
+	tags, err := tagService.ListTaskTags(alice, task.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	// The operation holds the task as restored, so redoing the delete finds it unchanged
+	_, err = service.Redo(alice)
+	assert.NoError(t, err)
+	_, err = service.GetTask(alice, task.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+// failingRestoreRepository fails to restore one task, to break a replay part way
+type failingRestoreRepository struct {
+	repository.TaskRepository
+	failing uuid.UUID
+}
+
+func (r *failingRestoreRepository) Restore(ctx context.Context, task *models.Task) error {
+	if task.ID == r.failing {
+		return errors.New("storage unavailable")
+	}
+	return r.TaskRepository.Restore(ctx, task)
+}
+
+func TestFailedUndoChangesNothing(t *testing.T) {
+	taskRepo := &failingRestoreRepository{TaskRepository: repository.NewInMemoryTaskRepository()}
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	service := NewTaskService(NewHistoryRecorder(taskRepo, historyRepo))
+	service.SetOperationRepository(repository.NewInMemoryOperationRepository(), 0)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+	root, _, _ := seedTree(t, service)
+
+	assert.NoError(t, service.DeleteTaskWithMode(ctx, root.ID, models.DeleteCascade))
+	operations, err := service.ListOperations(ctx)
+	assert.NoError(t, err)
+	changes := operations[0].Changes
+	assert.Len(t, changes, 3)
+	history, err := historyRepo.ListByTask(ctx, changes[0].TaskID)
+	assert.NoError(t, err)
+
+	// Restoring the second task fails after the first one was restored
+	taskRepo.failing = changes[1].TaskID
+	_, err = service.Undo(ctx)
+	assert.Error(t, err)
+
+	tasks, err := service.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+	operations, err = service.ListOperations(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, operations[0].UndoneAt)
+	entries, err := historyRepo.ListByTask(ctx, changes[0].TaskID)
+	assert.NoError(t, err)
+	assert.Len(t, entries, len(history))
+
+	// Once the storage recovers the undo goes through
+	taskRepo.failing = uuid.Nil
+	_, err = service.Undo(ctx)
+	assert.NoError(t, err)
+	tasks, err = service.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 3)
+}
+
+func TestInstantiateTemplateIsOneOperation(t *testing.T) {
+	service := newOperationService(0)
+	templates := NewTemplateService(repository.NewInMemoryTemplateRepository(), service)
+	ctx := auth.WithUser(context.Background(), uuid.New())
+
+	template, err := templates.CreateTemplate(ctx, onboardingTemplate())
+	assert.NoError(t, err)
+	tree, err := templates.Instantiate(ctx, template.ID, models.InstantiateTemplateRequest{
+		Params: map[string]string{"customer": "Acme", "contact": "Ann"},
+	})
+	assert.NoError(t, err)
+
+	operations, err := service.ListOperations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 1)
+	assert.Equal(t, models.OperationInstantiate, operations[0].Type)
+	assert.Equal(t, tree.Task.ID, operations[0].TaskID)
+	assert.Len(t, operations[0].Changes, 2)
+
+	_, err = service.Undo(ctx)
+	assert.NoError(t, err)
+	tasks, err := service.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProjectNameTaken = errors.New("project name already in use")
+	ErrProjectInUse     = errors.New("project still has tasks")
+)
+
+// ProjectRenameHook is called after a project was renamed, so data keyed by category name can follow.
+type ProjectRenameHook func(ctx context.Context, from, to string)
+
+// ProjectService manages the projects tasks belong to.
+type ProjectService struct {
+	mu          sync.Mutex
+	repo        repository.ProjectRepository
+	taskRepo    repository.TaskRepository
+	users       repository.UserRepository
+	validator   *utils.Validator
+	renameHooks []ProjectRenameHook
+	links       sync.Locker
+}
+
+// NewProjectService creates the service. Owners are checked against users when it is not nil.
+func NewProjectService(repo repository.ProjectRepository, taskRepo repository.TaskRepository, users repository.UserRepository) *ProjectService {
+	return &ProjectService{
+		repo:      repo,
+		taskRepo:  taskRepo,
+		users:     users,
+		validator: utils.NewValidator(),
+	}
+}
+
+// SetProjectLinks sets the lock that keeps tasks from being linked to projects, see TaskService.ProjectLinks.
+// DeleteProject holds it from checking that a project has no tasks until the project is deleted.
+func (s *ProjectService) SetProjectLinks(links sync.Locker) {
+	s.links = links
+}
+
+// OnProjectRenamed registers a hook that runs after every rename.
+func (s *ProjectService) OnProjectRenamed(hook ProjectRenameHook) {
+	s.renameHooks = append(s.renameHooks, hook)
+}
+
+// CreateProject adds a project. Without an explicit owner the authenticated user owns it.
+func (s *ProjectService) CreateProject(ctx context.Context, req models.CreateProjectRequest) (*models.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := &models.Project{
+		Name:            strings.TrimSpace(req.Name),
+		Description:     req.Description,
+		OwnerID:         req.OwnerID,
+		DefaultPriority: req.DefaultPriority,
+	}
+	if project.OwnerID == nil {
+		if userID, ok := auth.UserFromContext(ctx); ok {
+			project.OwnerID = &userID
+		}
+	}
+
+	log.Printf("Creating project: Name=%s", project.Name)
+
+	if err := s.validateProject(ctx, project); err != nil {
+		log.Printf("Project validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, project); err != nil {
+		log.Printf("Failed to create project: Name=%s, Error=%v", project.Name, err)
+
+		return nil, err
+	}
+
+	log.Printf("Project created successfully: ID=%s", project.ID)
+	return project, nil
+}
+
+func (s *ProjectService) GetProject(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	log.Printf("Retrieving project: ID=%s", id)
+
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to retrieve project: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (s *ProjectService) ListProjects(ctx context.Context) ([]models.Project, error) {
+	projects, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list projects: Error=%v", err)
+
+		return nil, err
+	}
+
+	log.Printf("Listed projects successfully: Found %d projects", len(projects))
+
+	return projects, nil
+}
+
+// UpdateProject replaces the details of a project. Tasks reference projects by ID, so a new name
+// applies to all of the project's tasks without changing them.
+func (s *ProjectService) UpdateProject(ctx context.Context, project *models.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project.Name = strings.TrimSpace(project.Name)
+
+	log.Printf("Updating project: ID=%s, Name=%s", project.ID, project.Name)
+
+	existing, err := s.repo.GetByID(ctx, project.ID)
+	if err != nil {
+		log.Printf("Failed to update project: ID=%s, Error=%v", project.ID, err)
+
+		return err
+	}
+
+	if err := s.validateProject(ctx, project); err != nil {
+		log.Printf("Project validation failed: ID=%s, Error=%v", project.ID, err)
+
+		return err
+	}
+
+	if err := s.repo.Update(ctx, project); err != nil {
+		log.Printf("Failed to update project: ID=%s, Error=%v", project.ID, err)
+
+		return err
+	}
+
+	if project.Name != existing.Name {
+		log.Printf("Project renamed: ID=%s, From=%s, To=%s", project.ID, existing.Name, project.Name)
+		for _, hook := range s.renameHooks {
+			hook(ctx, existing.Name, project.Name)
+		}
+	}
+
+	log.Printf("Project updated successfully: ID=%s", project.ID)
+
+	return nil
+}
+
+// DeleteProject removes a project that no task belongs to anymore.
+func (s *ProjectService) DeleteProject(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Deleting project: ID=%s", id)
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		log.Printf("Failed to delete project: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	if s.links != nil {
+		s.links.Lock()
+		defer s.links.Unlock()
+	}
+
+	tasks, err := s.taskRepo.List(ctx, map[string]interface{}{"project_id": id})
+	if err != nil {
+		return err
+	}
+	if len(tasks) > 0 {
+		return fmt.Errorf("%w: %d tasks", ErrProjectInUse, len(tasks))
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// validateProject checks the project fields, that its name is unique ignoring case and that its owner exists
+func (s *ProjectService) validateProject(ctx context.Context, project *models.Project) error {
+	if err := s.validator.ValidateProject(project); err != nil {
+		return err
+	}
+
+	if existing, err := s.repo.GetByName(ctx, project.Name); err == nil && existing.ID != project.ID {
+		return fmt.Errorf("%w: %s", ErrProjectNameTaken, existing.Name)
+	}
+
+	if s.users != nil && project.OwnerID != nil {
+		if _, err := s.users.GetByID(ctx, *project.OwnerID); err != nil {
+			return fmt.Errorf("%w: %s", ErrUserNotFound, *project.OwnerID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newProjectServices wires a task service whose tasks must belong to a project
+func newProjectServices() (*ProjectService, *TaskService, *repository.InMemoryTaskRepository) {
+	projectRepo := repository.NewInMemoryProjectRepository()
+	taskRepo := repository.NewInMemoryTaskRepository()
+	taskRepo.SetProjectNames(projectRepo)
+
+	taskService := NewTaskService(taskRepo)
+	taskService.SetProjectRepository(projectRepo)
+
+	projectService := NewProjectService(projectRepo, taskRepo, nil)
+	projectService.SetProjectLinks(taskService.ProjectLinks())
+
+	return projectService, taskService, taskRepo
+}
+
+func TestCreateProject(t *testing.T) {
+	service, _, _ := newProjectServices()
+	ctx := context.Background()
+
+	_, err := service.CreateProject(ctx, models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		req         models.CreateProjectRequest
+		expectedErr error
+		hasError    bool
+	}{
+		{
+			name: "Valid Project",
+			req:  models.CreateProjectRequest{Name: "Marketing", DefaultPriority: models.PriorityHigh},
+		},
+		{
+			name:        "Duplicate Name Ignoring Case",
+			req:         models.CreateProjectRequest{Name: "ops"},
+			expectedErr: ErrProjectNameTaken,
+			hasError:    true,
+		},
+		{
+			name:     "Empty Name",
+			req:      models.CreateProjectRequest{Name: "  "},
+			hasError: true,
+		},
+		{
+			name:     "Invalid Default Priority",
+			req:      models.CreateProjectRequest{Name: "Sales", DefaultPriority: "URGENT"},
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			project, err := service.CreateProject(ctx, test.req)
+			if test.hasError {
+				assert.Error(t, err)
+				if test.expectedErr != nil {
+					assert.ErrorIs(t, err, test.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, project.ID)
+			}
+		})
+	}
+}
+
+func TestTaskProjects(t *testing.T) {
+	service, taskService, _ := newProjectServices()
+	ctx := context.Background()
+
+	ops, err := service.CreateProject(ctx, models.CreateProjectRequest{Name: "Ops", DefaultPriority: models.PriorityHigh})
+	assert.NoError(t, err)
+
+	t.Run("Category Resolves To Project", func(t *testing.T) {
+		req := newTaskRequest("Rotate keys")
+		req.Category = "ops"
+		task, err := taskService.CreateTask(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, ops.ID, *task.ProjectID)
+		assert.Equal(t, "Ops", task.Category)
+	})
+
+	t.Run("Unknown Project", func(t *testing.T) {
+		req := newTaskRequest("Plan launch")
+		req.Category = "Launch"
+		_, err := taskService.CreateTask(ctx, req)
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+
+		missing := uuid.New()
+		req = newTaskRequest("Plan launch")
+		req.ProjectID = &missing
+		_, err = taskService.CreateTask(ctx, req)
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("Project And Category Mismatch", func(t *testing.T) {
+		_, err := service.CreateProject(ctx, models.CreateProjectRequest{Name: "Sales"})
+		assert.NoError(t, err)
+
+		req := newTaskRequest("Call back")
+		req.Category = "Sales"
+		req.ProjectID = &ops.ID
+		_, err = taskService.CreateTask(ctx, req)
+		assert.ErrorIs(t, err, ErrProjectMismatch)
+	})
+
+	t.Run("Default Priority", func(t *testing.T) {
+		req := newTaskRequest("Patch servers")
+		req.ProjectID = &ops.ID
+		req.Priority = ""
+		task, err := taskService.CreateTask(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, models.PriorityHigh, task.Priority)
+	})
+}
+
+func TestRenameProject(t *testing.T) {
+	service, taskService, _ := newProjectServices()
+	workflowService := NewWorkflowService(repository.NewInMemoryWorkflowRepository(), taskService)
+	service.OnProjectRenamed(workflowService.RenameCategory)
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, models.CreateProjectRequest{Name: "QA"})
+	assert.NoError(t, err)
+
+	_, err = workflowService.CreateWorkflow(ctx, qaWorkflowRequest())
+	assert.NoError(t, err)
+
+	req := newTaskRequest("Test login")
+	req.Category = "QA"
+	task, err := taskService.CreateTask(ctx, req)
+	assert.NoError(t, err)
+
+	project.Name = "Quality"
+	assert.NoError(t, service.UpdateProject(ctx, project))
+
+	stored, err := taskService.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Quality", stored.Category)
+
+	tasks, err := taskService.ListTasks(ctx, map[string]interface{}{"category": "quality"})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	// The workflow bound to the old name follows the project
+	assert.Contains(t, taskService.AllowedTransitions(stored), models.Status("IN_PROGRESS"))
+	stored.Status = "IN_REVIEW"
+	assert.Error(t, taskService.UpdateTask(ctx, stored))
+
+	other, err := service.CreateProject(ctx, models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+	other.Name = "quality"
+	assert.ErrorIs(t, service.UpdateProject(ctx, other), ErrProjectNameTaken)
+}
+
+func TestDeleteProject(t *testing.T) {
+	service, taskService, _ := newProjectServices()
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+
+	req := newTaskRequest("Rotate keys")
+	req.Category = "Ops"
+	task, err := taskService.CreateTask(ctx, req)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, service.DeleteProject(ctx, project.ID), ErrProjectInUse)
+
+	assert.NoError(t, taskService.DeleteTask(ctx, task.ID))
+	assert.NoError(t, service.DeleteProject(ctx, project.ID))
+
+	_, err = service.GetProject(ctx, project.ID)
+	assert.Error(t, err)
+}
+
+func TestDeleteProjectWhileCreatingTasks(t *testing.T) {
+	service, taskService, taskRepo := newProjectServices()
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, models.CreateProjectRequest{Name: "Ops"})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := newTaskRequest("Rotate keys")
+			req.Category = "Ops"
+			taskService.CreateTask(ctx, req)
+		}()
+	}
+	deleteErr := service.DeleteProject(ctx, project.ID)
+	wg.Wait()
+
+	// Either the project survived or no task was linked to it after its check
+	if deleteErr == nil {
+		tasks, err := taskRepo.List(ctx, map[string]interface{}{"project_id": project.ID})
+		assert.NoError(t, err)
+		assert.Empty(t, tasks)
+	} else {
+		assert.ErrorIs(t, deleteErr, ErrProjectInUse)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrProjectMismatch = errors.New("project_id and category refer to different projects")
+)
+
+// SetProjectRepository makes every task belong to a known project, referenced by project_id or by
+// its name in category. Until one is set categories are free-form strings.
+func (s *TaskService) SetProjectRepository(projects repository.ProjectRepository) {
+	s.projects = projects
+}
+
+// applyProject links a task to the project named by its project_id or category and sets the category
+// to the project's name. New tasks without a priority take the project's default priority.
+func (s *TaskService) applyProject(ctx context.Context, task *models.Task, creating bool) error {
+	if s.projects == nil || (task.ProjectID == nil && task.Category == "") {
+		return nil
+	}
+
+	var project *models.Project
+	var err error
+	if task.ProjectID != nil {
+		project, err = s.projects.GetByID(ctx, *task.ProjectID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrProjectNotFound, *task.ProjectID)
+		}
+		if task.Category != "" && !strings.EqualFold(task.Category, project.Name) {
+			return ErrProjectMismatch
+		}
+	} else {
+		project, err = s.projects.GetByName(ctx, task.Category)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrProjectNotFound, task.Category)
+		}
+	}
+
+	task.ProjectID = &project.ID
+	task.Category = project.Name
+	if creating && task.Priority == "" {
+		task.Priority = project.DefaultPriority
+	}
+
+	return nil
+}
+
+// ProjectLinks returns a lock that keeps tasks from being linked to a project while it is held, so a
+// check that a project has no tasks stays true until the project is deleted.
+func (s *TaskService) ProjectLinks() sync.Locker {
+	return &s.projectLinks
+}
+
+// storeLinked runs write, which stores task, once it made sure the task's project still exists.
+func (s *TaskService) storeLinked(ctx context.Context, task *models.Task, write func() error) error {
+	s.projectLinks.RLock()
+	defer s.projectLinks.RUnlock()
+
+	if s.projects != nil && task.ProjectID != nil {
+		if _, err := s.projects.GetByID(ctx, *task.ProjectID); err != nil {
+			return fmt.Errorf("%w: %s", ErrProjectNotFound, *task.ProjectID)
+		}
+	}
+
+	return write()
+}
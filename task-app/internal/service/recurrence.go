@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotRecurring     = errors.New("task has no recurrence rule")
+	ErrOccurrenceExists = errors.New("next occurrence already generated")
+	ErrRecurrenceEnded  = errors.New("recurrence has no further occurrences")
+)
+
+// SetRecurrence attaches a recurrence rule to a task. The series starts over from the task's due date.
+func (s *TaskService) SetRecurrence(ctx context.Context, id uuid.UUID, req models.SetRecurrenceRequest) (*models.Task, error) {
+	log.Printf("Setting recurrence: ID=%s, Frequency=%s, Interval=%d", id, req.Frequency, req.Interval)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &models.Recurrence{
+		Frequency: req.Frequency,
+		Interval:  req.Interval,
+		ByDay:     req.ByDay,
+		Until:     req.Until,
+		Count:     req.Count,
+		Trigger:   req.Trigger,
+		Start:     task.DueDate,
+	}
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	if rule.Trigger == "" {
+		rule.Trigger = models.TriggerCompletion
+	}
+	if task.Recurrence != nil {
+		rule.NextID = task.Recurrence.NextID
+	}
+
+	if err := s.validator.ValidateRecurrence(rule); err != nil {
+		log.Printf("Recurrence validation failed: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	updated := *task
+	updated.Recurrence = rule
+	if err := s.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to set recurrence: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	log.Printf("Recurrence set successfully: ID=%s", id)
+
+	return &updated, nil
+}
+
+// ClearRecurrence removes the recurrence rule of a task; occurrences already generated are kept.
+func (s *TaskService) ClearRecurrence(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	log.Printf("Clearing recurrence: ID=%s", id)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Recurrence == nil {
+		return nil, ErrNotRecurring
+	}
+
+	updated := *task
+	updated.Recurrence = nil
+	if err := s.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to clear recurrence: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// GenerateNextOccurrence duplicates a recurring task with its due date moved to the next occurrence.
+// Occurrences that are already in the past are skipped but still count towards the rule's count.
+func (s *TaskService) GenerateNextOccurrence(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	return s.generateNextOccurrence(ctx, id, time.Now())
+}
+
+// GenerateDueOccurrences generates the next occurrence of every schedule-triggered task that is due at now.
+func (s *TaskService) GenerateDueOccurrences(ctx context.Context, now time.Time) (int, error) {
+	tasks, err := s.repo.List(ctx, map[string]interface{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, task := range tasks {
+		rule := task.Recurrence
+		if rule == nil || rule.Trigger != models.TriggerSchedule || rule.NextID != nil || task.DueDate.After(now) {
+			continue
+		}
+
+		if _, err := s.generateNextOccurrence(ctx, task.ID, now); err != nil {
+			if !errors.Is(err, ErrRecurrenceEnded) && !errors.Is(err, ErrOccurrenceExists) {
+				log.Printf("Failed to generate occurrence: ID=%s, Error=%v", task.ID, err)
+			}
+			continue
+		}
+		generated++
+	}
+
+	return generated, nil
+}
+
+// StartRecurrenceScheduler generates due occurrences every interval until ctx is cancelled.
+func (s *TaskService) StartRecurrenceScheduler(ctx context.Context, every time.Duration) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if generated, err := s.GenerateDueOccurrences(ctx, now); err != nil {
+					log.Printf("Recurrence scheduler failed: Error=%v", err)
+				} else if generated > 0 {
+					log.Printf("Recurrence scheduler generated %d occurrences", generated)
+				}
+			}
+		}
+	}()
+}
+
+func (s *TaskService) generateNextOccurrence(ctx context.Context, id uuid.UUID, now time.Time) (*models.Task, error) {
+	s.recurrenceMu.Lock()
+	defer s.recurrenceMu.Unlock()
+
+	log.Printf("Generating next occurrence: ID=%s", id)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Recurrence == nil {
+		return nil, ErrNotRecurring
+	}
+	if task.Recurrence.NextID != nil {
+		return nil, ErrOccurrenceExists
+	}
+
+	rule := *task.Recurrence
+	occurrence := rule.Occurrence + 1
+	dueDate, ok := utils.NextOccurrence(&rule, task.DueDate, occurrence)
+	for ok && !dueDate.After(now) {
+		occurrence++
+		dueDate, ok = utils.NextOccurrence(&rule, dueDate, occurrence)
+	}
+	if !ok {
+		log.Printf("Recurrence ended: ID=%s, Occurrences=%d", id, occurrence)
+
+		return nil, ErrRecurrenceEnded
+	}
+
+	opts := s.DuplicateDefaults()
+	opts.TitlePattern = "{{title}}"
+	opts.DueDate = &dueDate
+	next, err := s.DuplicateTaskWithOptions(ctx, id, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	nextRule := rule
+	nextRule.ByDay = append([]string(nil), rule.ByDay...)
+	nextRule.Occurrence = occurrence
+	nextRule.NextID = nil
+
+	next.Recurrence = &nextRule
+	next.Progress = nil
+	if err := s.repo.Update(ctx, next); err != nil {
+		log.Printf("Failed to schedule occurrence: ID=%s, Error=%v", next.ID, err)
+
+		return nil, err
+	}
+
+	rule.NextID = &next.ID
+	current := *task
+	current.Recurrence = &rule
+	if err := s.repo.Update(ctx, &current); err != nil {
+		log.Printf("Failed to link occurrence: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	log.Printf("Next occurrence generated: ID=%s, NextID=%s, DueDate=%s", id, next.ID, next.DueDate.Format(time.RFC3339))
+
+	return next, nil
+}
+
+// recurOnCompletion generates the next occurrence of a completion-triggered task once it is finished
+func (s *TaskService) recurOnCompletion(ctx context.Context, task *models.Task) {
+	rule := task.Recurrence
+	if rule == nil || rule.Trigger != models.TriggerCompletion || rule.NextID != nil || !s.isFinished(task) {
+		return
+	}
+
+	if _, err := s.GenerateNextOccurrence(ctx, task.ID); err != nil && !errors.Is(err, ErrRecurrenceEnded) {
+		log.Printf("Failed to generate occurrence: ID=%s, Error=%v", task.ID, err)
+	}
+}
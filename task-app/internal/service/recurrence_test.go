@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurOnCompletion(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Water plants"))
+	assert.NoError(t, err)
+
+	_, err = service.SetRecurrence(ctx, task.ID, models.SetRecurrenceRequest{Frequency: models.FrequencyWeekly})
+	assert.NoError(t, err)
+
+	// Other updates keep the rule
+	stored, _ := service.GetTask(ctx, task.ID)
+	edited := *stored
+	edited.Recurrence = nil
+	edited.Description = "Both balconies"
+	assert.NoError(t, service.UpdateTask(ctx, &edited))
+	stored, _ = service.GetTask(ctx, task.ID)
+	assert.NotNil(t, stored.Recurrence)
+
+	done := *stored
+	done.Status = models.StatusDone
+	assert.NoError(t, service.UpdateTask(ctx, &done))
+
+	stored, _ = service.GetTask(ctx, task.ID)
+	assert.NotNil(t, stored.Recurrence.NextID)
+
+	next, err := service.GetTask(ctx, *stored.Recurrence.NextID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Water plants", next.Title)
+	assert.Equal(t, models.StatusToDo, next.Status)
+	assert.True(t, next.DueDate.Equal(task.DueDate.AddDate(0, 0, 7)))
+	assert.Equal(t, 1, next.Recurrence.Occurrence)
+	assert.Nil(t, next.Recurrence.NextID)
+
+	// Finishing the same occurrence again does not generate another one
+	again := *stored
+	again.Status = models.StatusDone
+	assert.NoError(t, service.UpdateTask(ctx, &again))
+	tasks, _ := service.ListTasks(ctx, map[string]interface{}{})
+	assert.Len(t, tasks, 2)
+
+	_, err = service.GenerateNextOccurrence(ctx, task.ID)
+	assert.ErrorIs(t, err, ErrOccurrenceExists)
+}
+
+func TestScheduledRecurrence(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	task, err := service.CreateTask(ctx, newTaskRequest("Standup"))
+	assert.NoError(t, err)
+
+	_, err = service.SetRecurrence(ctx, task.ID, models.SetRecurrenceRequest{
+		Frequency: models.FrequencyDaily,
+		Count:     2,
+		Trigger:   models.TriggerSchedule,
+	})
+	assert.NoError(t, err)
+
+	generated, err := service.GenerateDueOccurrences(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, generated)
+
+	generated, err = service.GenerateDueOccurrences(ctx, task.DueDate)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, generated)
+
+	stored, _ := service.GetTask(ctx, task.ID)
+	next, _ := service.GetTask(ctx, *stored.Recurrence.NextID)
+	assert.Equal(t, models.StatusToDo, stored.Status)
+	assert.True(t, next.DueDate.Equal(task.DueDate.AddDate(0, 0, 1)))
+
+	// The count of two occurrences is used up
+	generated, err = service.GenerateDueOccurrences(ctx, next.DueDate)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, generated)
+
+	_, err = service.GenerateNextOccurrence(ctx, next.ID)
+	assert.ErrorIs(t, err, ErrRecurrenceEnded)
+
+	_, err = service.ClearRecurrence(ctx, next.ID)
+	assert.NoError(t, err)
+	_, err = service.ClearRecurrence(ctx, next.ID)
+	assert.ErrorIs(t, err, ErrNotRecurring)
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/notify"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxReminderAttempts is how often delivery of a reminder is tried before it is marked FAILED
+	MaxReminderAttempts = 5
+	maxReminderBackoff  = time.Hour
+)
+
+var (
+	ErrReminderNotFound = errors.New("reminder not found")
+	ErrReminderInPast   = errors.New("reminder would fire in the past")
+)
+
+// ReminderService schedules reminders for tasks and delivers them through a notifier once they are due.
+type ReminderService struct {
+	repo        repository.ReminderRepository
+	taskService *TaskService
+	notifier    notify.Notifier
+	validator   *utils.Validator
+
+	// mu keeps delivery runs from overlapping so a reminder is not sent twice by concurrent runs
+	mu sync.Mutex
+}
+
+// NewReminderService creates the service and registers it to remove the reminders of deleted tasks and to
+// keep them for undoing a delete.
+func NewReminderService(repo repository.ReminderRepository, taskService *TaskService, notifier notify.Notifier) *ReminderService {
+	s := &ReminderService{
+		repo:        repo,
+		taskService: taskService,
+		notifier:    notifier,
+		validator:   utils.NewValidator(),
+	}
+	taskService.OnTaskDeleted(s.deleteTaskReminders)
+	taskService.KeepOnDelete("reminders", s.keepTaskReminders, s.restoreTaskReminders)
+
+	return s
+}
+
+// CreateReminder schedules a reminder for a task, either at a fixed time or a number of minutes before its due date.
+func (s *ReminderService) CreateReminder(ctx context.Context, taskID uuid.UUID, req models.CreateReminderRequest) (*models.Reminder, error) {
+	log.Printf("Creating reminder: TaskID=%s", taskID)
+
+	task, err := s.taskService.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateReminder(req, len(existing)); err != nil {
+		log.Printf("Reminder validation failed: %v", err)
+
+		return nil, err
+	}
+
+	reminder := &models.Reminder{
+		TaskID:        taskID,
+		At:            req.At,
+		OffsetMinutes: req.OffsetMinutes,
+		Status:        models.ReminderPending,
+	}
+	schedule(reminder, task)
+
+	if !reminder.FireAt.After(time.Now()) {
+		return nil, ErrReminderInPast
+	}
+
+	if err := s.repo.Create(ctx, reminder); err != nil {
+		log.Printf("Failed to create reminder: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Reminder created successfully: ID=%s, FireAt=%s", reminder.ID, reminder.FireAt.Format(time.RFC3339))
+	return reminder, nil
+}
+
+// ListReminders returns the reminders of a task, the next to fire first.
+func (s *ReminderService) ListReminders(ctx context.Context, taskID uuid.UUID) ([]models.Reminder, error) {
+	log.Printf("Listing reminders: TaskID=%s", taskID)
+
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByTask(ctx, taskID)
+}
+
+func (s *ReminderService) GetReminder(ctx context.Context, taskID, id uuid.UUID) (*models.Reminder, error) {
+	log.Printf("Retrieving reminder: TaskID=%s, ID=%s", taskID, id)
+
+	return s.getReminder(ctx, taskID, id)
+}
+
+func (s *ReminderService) DeleteReminder(ctx context.Context, taskID, id uuid.UUID) error {
+	log.Printf("Deleting reminder: TaskID=%s, ID=%s", taskID, id)
+
+	if _, err := s.getReminder(ctx, taskID, id); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// ListOrphanedReminders returns the reminders whose task no longer exists. Tasks are only kept in memory,
+// so after a restart every stored reminder is orphaned; pending ones are still delivered from their snapshot.
+func (s *ReminderService) ListOrphanedReminders(ctx context.Context) ([]models.Reminder, error) {
+	log.Println("Listing orphaned reminders")
+
+	reminders, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orphaned := make([]models.Reminder, 0)
+	for _, reminder := range reminders {
+		if s.isOrphaned(ctx, &reminder) {
+			orphaned = append(orphaned, reminder)
+		}
+	}
+
+	return orphaned, nil
+}
+
+// DeleteOrphanedReminder removes a reminder whose task no longer exists.
+func (s *ReminderService) DeleteOrphanedReminder(ctx context.Context, id uuid.UUID) error {
+	log.Printf("Deleting orphaned reminder: ID=%s", id)
+
+	reminder, err := s.repo.GetByID(ctx, id)
+	if err != nil || !s.isOrphaned(ctx, reminder) {
+		return ErrReminderNotFound
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// DeliverDue sends every pending reminder whose fire time is not after now and returns how many were sent.
+// Offset reminders are first moved to follow the current due date of their task. Failed deliveries are
+// retried with exponential backoff until MaxReminderAttempts is reached. Reminders of tasks that no longer
+// exist in the task store, for example after a restart, are delivered from their snapshot of the task.
+func (s *ReminderService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, err := s.repo.ListPending(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range pending {
+		if task, err := s.taskService.GetTask(ctx, reminder.TaskID); err == nil {
+			if s.taskService.isFinished(task) {
+				s.cancel(ctx, reminder)
+				continue
+			}
+			s.reschedule(ctx, &reminder, task)
+		}
+
+		if reminder.FireAt.After(now) {
+			continue
+		}
+
+		if s.deliver(ctx, reminder, now) {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// StartScheduler delivers reminders that came due while the service was down and then checks for
+// due reminders every interval until ctx is cancelled.
+func (s *ReminderService) StartScheduler(ctx context.Context, every time.Duration) {
+	run := func(now time.Time) {
+		if sent, err := s.DeliverDue(ctx, now); err != nil {
+			log.Printf("Reminder scheduler failed: Error=%v", err)
+		} else if sent > 0 {
+			log.Printf("Reminder scheduler sent %d reminders", sent)
+		}
+	}
+
+	go func() {
+		run(time.Now())
+
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				run(now)
+			}
+		}
+	}()
+}
+
+// deliver hands a due reminder to the notifier and records the outcome
+func (s *ReminderService) deliver(ctx context.Context, reminder models.Reminder, now time.Time) bool {
+	notification := models.ReminderNotification{
+		ReminderID: reminder.ID,
+		TaskID:     reminder.TaskID,
+		TaskTitle:  reminder.TaskTitle,
+		DueDate:    reminder.DueDate,
+		FireAt:     reminder.FireAt,
+		SentAt:     now,
+	}
+
+	reminder.Attempts++
+	err := s.notifier.Notify(ctx, notification)
+	switch {
+	case err == nil:
+		reminder.Status = models.ReminderSent
+		reminder.SentAt = &now
+		reminder.LastError = ""
+	case reminder.Attempts >= MaxReminderAttempts:
+		log.Printf("Giving up on reminder: ID=%s, Attempts=%d, Error=%v", reminder.ID, reminder.Attempts, err)
+		reminder.Status = models.ReminderFailed
+		reminder.LastError = err.Error()
+	default:
+		log.Printf("Failed to deliver reminder: ID=%s, Attempts=%d, Error=%v", reminder.ID, reminder.Attempts, err)
+		reminder.FireAt = now.Add(reminderBackoff(reminder.Attempts))
+		reminder.LastError = err.Error()
+	}
+
+	if err := s.repo.Update(ctx, &reminder); err != nil {
+		log.Printf("Failed to record reminder delivery: ID=%s, Error=%v", reminder.ID, err)
+	}
+
+	return reminder.Status == models.ReminderSent
+}
+
+// reschedule refreshes the task snapshot of a reminder and, for offset reminders that have not been
+// tried yet, its fire time. The reminder is only written back when something changed.
+func (s *ReminderService) reschedule(ctx context.Context, reminder *models.Reminder, task *models.Task) {
+	before := *reminder
+	if reminder.Attempts > 0 {
+		reminder.TaskTitle = task.Title
+		reminder.DueDate = task.DueDate
+	} else {
+		schedule(reminder, task)
+	}
+
+	if before.TaskTitle == reminder.TaskTitle && before.DueDate.Equal(reminder.DueDate) && before.FireAt.Equal(reminder.FireAt) {
+		return
+	}
+
+	if err := s.repo.Update(ctx, reminder); err != nil {
+		log.Printf("Failed to reschedule reminder: ID=%s, Error=%v", reminder.ID, err)
+	}
+}
+
+func (s *ReminderService) cancel(ctx context.Context, reminder models.Reminder) {
+	log.Printf("Cancelling reminder of finished task: ID=%s, TaskID=%s", reminder.ID, reminder.TaskID)
+
+	reminder.Status = models.ReminderCancelled
+	if err := s.repo.Update(ctx, &reminder); err != nil {
+		log.Printf("Failed to cancel reminder: ID=%s, Error=%v", reminder.ID, err)
+	}
+}
+
+func (s *ReminderService) deleteTaskReminders(ctx context.Context, taskID uuid.UUID) {
+	if _, err := s.repo.DeleteByTask(ctx, taskID); err != nil {
+		log.Printf("Failed to delete reminders of task: TaskID=%s, Error=%v", taskID, err)
+	}
+}
+
+// keepTaskReminders returns the reminders of a task that is about to be deleted
+func (s *ReminderService) keepTaskReminders(ctx context.Context, taskID uuid.UUID) (interface{}, error) {
+	reminders, err := s.repo.ListByTask(ctx, taskID)
+	if err != nil || len(reminders) == 0 {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// restoreTaskReminders brings back the reminders kept for a restored task
+func (s *ReminderService) restoreTaskReminders(ctx context.Context, taskID uuid.UUID, data json.RawMessage) error {
+	var reminders []models.Reminder
+	if err := json.Unmarshal(data, &reminders); err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		restored := reminder
+		restored.TaskID = taskID
+		if err := s.repo.Create(ctx, &restored); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isOrphaned reports whether the task of a reminder no longer exists
+func (s *ReminderService) isOrphaned(ctx context.Context, reminder *models.Reminder) bool {
+	_, err := s.taskService.GetTask(ctx, reminder.TaskID)
+	return errors.Is(err, repository.ErrNotFound)
+}
+
+// getReminder loads a reminder and checks that it belongs to the task
+func (s *ReminderService) getReminder(ctx context.Context, taskID, id uuid.UUID) (*models.Reminder, error) {
+	reminder, err := s.repo.GetByID(ctx, id)
+	if err != nil || reminder.TaskID != taskID {
+		return nil, ErrReminderNotFound
+	}
+
+	return reminder, nil
+}
+
+// schedule sets the fire time of a reminder from its task and takes a snapshot of the task
+func schedule(reminder *models.Reminder, task *models.Task) {
+	if reminder.At != nil {
+		reminder.FireAt = *reminder.At
+	} else {
+		reminder.FireAt = task.DueDate.Add(-time.Duration(*reminder.OffsetMinutes) * time.Minute)
+	}
+	reminder.TaskTitle = task.Title
+	reminder.DueDate = task.DueDate
+}
+
+// reminderBackoff doubles the wait after every failed attempt, starting at one minute
+func reminderBackoff(attempts int) time.Duration {
+	backoff := time.Minute << (attempts - 1)
+	if backoff > maxReminderBackoff {
+		return maxReminderBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingNotifier remembers delivered notifications and fails while err is set
+type recordingNotifier struct {
+	sent []models.ReminderNotification
+	err  error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification models.ReminderNotification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func newReminderService(t *testing.T, path string, taskService *TaskService) (*ReminderService, *recordingNotifier) {
+	repo, err := repository.NewFileReminderRepository(path)
+	assert.NoError(t, err)
+
+	notifier := &recordingNotifier{}
+	return NewReminderService(repo, taskService, notifier), notifier
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestCreateReminder(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service, _ := newReminderService(t, filepath.Join(t.TempDir(), "reminders.json"), taskService)
+	ctx := context.Background()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Renew passport"))
+	assert.NoError(t, err)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		req         models.CreateReminderRequest
+		expectedErr error
+	}{
+		{name: "Offset", req: models.CreateReminderRequest{OffsetMinutes: intPtr(60)}},
+		{name: "Absolute", req: models.CreateReminderRequest{At: &future}},
+		{name: "Neither", req: models.CreateReminderRequest{}, expectedErr: utils.ErrReminderTiming},
+		{name: "Both", req: models.CreateReminderRequest{At: &future, OffsetMinutes: intPtr(5)}, expectedErr: utils.ErrReminderTiming},
+		{name: "Negative Offset", req: models.CreateReminderRequest{OffsetMinutes: intPtr(-5)}, expectedErr: utils.ErrInvalidReminderOffset},
+		{name: "In The Past", req: models.CreateReminderRequest{At: &past}, expectedErr: ErrReminderInPast},
+		{name: "Offset Before Now", req: models.CreateReminderRequest{OffsetMinutes: intPtr(48 * 60)}, expectedErr: ErrReminderInPast},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reminder, err := service.CreateReminder(ctx, task.ID, test.req)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.ReminderPending, reminder.Status)
+			assert.Equal(t, "Renew passport", reminder.TaskTitle)
+		})
+	}
+
+	reminders, err := service.ListReminders(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, reminders, 2)
+	assert.True(t, reminders[0].FireAt.Equal(future))
+	assert.True(t, reminders[1].FireAt.Equal(task.DueDate.Add(-time.Hour)))
+
+	// Deleting the task removes its reminders
+	assert.NoError(t, taskService.DeleteTask(ctx, task.ID))
+	_, err = service.GetReminder(ctx, task.ID, reminders[0].ID)
+	assert.ErrorIs(t, err, ErrReminderNotFound)
+}
+
+func TestDeliverDueReminders(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	path := filepath.Join(t.TempDir(), "reminders.json")
+	service, notifier := newReminderService(t, path, taskService)
+	ctx := context.Background()
+
+	task, err := taskService.CreateTask(ctx, newTaskRequest("Submit report"))
+	assert.NoError(t, err)
+
+	reminder, err := service.CreateReminder(ctx, task.ID, models.CreateReminderRequest{OffsetMinutes: intPtr(30)})
+	assert.NoError(t, err)
+
+	// Moving the due date moves the offset reminder with it
+	stored, _ := taskService.GetTask(ctx, task.ID)
+	moved := *stored
+	moved.DueDate = task.DueDate.Add(24 * time.Hour)
+	assert.NoError(t, taskService.UpdateTask(ctx, &moved))
+
+	sent, err := service.DeliverDue(ctx, task.DueDate)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	fireAt := moved.DueDate.Add(-30 * time.Minute)
+	current, _ := service.GetReminder(ctx, task.ID, reminder.ID)
+	assert.True(t, current.FireAt.Equal(fireAt))
+
+	// A failed delivery is retried after a backoff
+	notifier.err = errors.New("sink unavailable")
+	sent, err = service.DeliverDue(ctx, fireAt)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	current, _ = service.GetReminder(ctx, task.ID, reminder.ID)
+	assert.Equal(t, models.ReminderPending, current.Status)
+	assert.Equal(t, 1, current.Attempts)
+	assert.Equal(t, "sink unavailable", current.LastError)
+	assert.True(t, current.FireAt.Equal(fireAt.Add(time.Minute)))
+
+	// A restarted service picks up the pending reminder from the file, even without the task
+	restarted, notifier := newReminderService(t, path, NewTaskService(repository.NewInMemoryTaskRepository()))
+	sent, err = restarted.DeliverDue(ctx, fireAt.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, notifier.sent, 1)
+	assert.Equal(t, "Submit report", notifier.sent[0].TaskTitle)
+
+	// Sent reminders are not delivered again
+	sent, _ = restarted.DeliverDue(ctx, fireAt.Add(time.Hour))
+	assert.Equal(t, 0, sent)
+
+	// Reminders left without their task can still be listed and removed
+	orphaned, err := restarted.ListOrphanedReminders(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, orphaned, 1)
+	assert.Equal(t, reminder.ID, orphaned[0].ID)
+
+	assert.ErrorIs(t, service.DeleteOrphanedReminder(ctx, reminder.ID), ErrReminderNotFound)
+	assert.NoError(t, restarted.DeleteOrphanedReminder(ctx, reminder.ID))
+	orphaned, _ = restarted.ListOrphanedReminders(ctx)
+	assert.Empty(t, orphaned)
+}
+
+func TestReminderGivesUp(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service, notifier := newReminderService(t, filepath.Join(t.TempDir(), "reminders.json"), taskService)
+	ctx := context.Background()
+
+	task, _ := taskService.CreateTask(ctx, newTaskRequest("Call back"))
+	failing, err := service.CreateReminder(ctx, task.ID, models.CreateReminderRequest{OffsetMinutes: intPtr(0)})
+	assert.NoError(t, err)
+
+	notifier.err = errors.New("sink unavailable")
+	now := task.DueDate
+	for attempt := 0; attempt < MaxReminderAttempts; attempt++ {
+		_, err := service.DeliverDue(ctx, now)
+		assert.NoError(t, err)
+		now = now.Add(maxReminderBackoff)
+	}
+
+	current, _ := service.GetReminder(ctx, task.ID, failing.ID)
+	assert.Equal(t, models.ReminderFailed, current.Status)
+	assert.Equal(t, MaxReminderAttempts, current.Attempts)
+
+	// Reminders of finished tasks are cancelled instead of sent
+	notifier.err = nil
+	finished, _ := service.CreateReminder(ctx, task.ID, models.CreateReminderRequest{OffsetMinutes: intPtr(10)})
+	stored, _ := taskService.GetTask(ctx, task.ID)
+	done := *stored
+	done.Status = models.StatusDone
+	assert.NoError(t, taskService.UpdateTask(ctx, &done))
+
+	sent, _ := service.DeliverDue(ctx, task.DueDate)
+	assert.Equal(t, 0, sent)
+	current, _ = service.GetReminder(ctx, task.ID, finished.ID)
+	assert.Equal(t, models.ReminderCancelled, current.Status)
+}
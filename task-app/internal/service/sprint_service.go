@@ -0,0 +1,503 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSprintClosed        = errors.New("sprint has ended")
+	ErrSprintStarted       = errors.New("sprint dates cannot change once the sprint started")
+	ErrTaskAlreadyInSprint = errors.New("task is already in sprint")
+	ErrTaskNotInSprint     = errors.New("task is not in sprint")
+)
+
+// SprintService plans tasks into sprints. Every task entering or leaving a sprint is recorded as a scope
+// change, so the work committed at the start of a sprint can be compared with what happened after.
+type SprintService struct {
+	mu          sync.Mutex
+	repo        repository.SprintRepository
+	taskService *TaskService
+	validator   *utils.Validator
+}
+
+func NewSprintService(repo repository.SprintRepository, taskService *TaskService) *SprintService {
+	s := &SprintService{
+		repo:        repo,
+		taskService: taskService,
+		validator:   utils.NewValidator(),
+	}
+
+	taskService.OnTaskDeleted(s.deleteTaskFromSprints)
+	taskService.KeepOnDelete("sprints", s.keepTaskSprints, s.restoreTaskSprints)
+	taskService.OnTaskReplay(s.dropDeletedSprint)
+
+	return s
+}
+
+// CreateSprint creates a sprint, lasting two weeks unless an end date is given.
+func (s *SprintService) CreateSprint(ctx context.Context, req models.CreateSprintRequest) (*models.Sprint, error) {
+	sprint := &models.Sprint{
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+	if sprint.EndDate.IsZero() && !sprint.StartDate.IsZero() {
+		sprint.EndDate = sprint.StartDate.Add(models.DefaultSprintLength)
+	}
+
+	log.Printf("Creating sprint: Name=%s, Start=%s, End=%s", sprint.Name, sprint.StartDate, sprint.EndDate)
+
+	if err := s.validator.ValidateSprint(sprint); err != nil {
+		log.Printf("Sprint validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, sprint); err != nil {
+		log.Printf("Failed to create sprint: Name=%s, Error=%v", sprint.Name, err)
+
+		return nil, err
+	}
+
+	log.Printf("Sprint created successfully: ID=%s", sprint.ID)
+
+	return withSprintState(sprint), nil
+}
+
+func (s *SprintService) GetSprint(ctx context.Context, id uuid.UUID) (*models.Sprint, error) {
+	log.Printf("Fetching sprint: ID=%s", id)
+
+	sprint, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to fetch sprint: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return withSprintState(sprint), nil
+}
+
+func (s *SprintService) ListSprints(ctx context.Context) ([]models.Sprint, error) {
+	log.Println("Listing sprints")
+
+	sprints, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list sprints: Error=%v", err)
+
+		return nil, err
+	}
+
+	for i := range sprints {
+		withSprintState(&sprints[i])
+	}
+
+	return sprints, nil
+}
+
+// UpdateSprint replaces the details of a sprint. The dates of an active or closed sprint are fixed, so
+// the scope committed at its start keeps its meaning.
+func (s *SprintService) UpdateSprint(ctx context.Context, sprint *models.Sprint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Updating sprint: ID=%s, Name=%s", sprint.ID, sprint.Name)
+
+	if sprint.EndDate.IsZero() && !sprint.StartDate.IsZero() {
+		sprint.EndDate = sprint.StartDate.Add(models.DefaultSprintLength)
+	}
+
+	if err := s.validator.ValidateSprint(sprint); err != nil {
+		log.Printf("Sprint validation failed: ID=%s, Error=%v", sprint.ID, err)
+		return err
+	}
+
+	existing, err := s.repo.GetByID(ctx, sprint.ID)
+	if err != nil {
+		log.Printf("Failed to update sprint: ID=%s, Error=%v", sprint.ID, err)
+
+		return err
+	}
+
+	datesChanged := !sprint.StartDate.Equal(existing.StartDate) || !sprint.EndDate.Equal(existing.EndDate)
+	if datesChanged && existing.StateAt(time.Now()) != models.SprintPlanned {
+		log.Printf("Sprint dates are fixed: ID=%s, State=%s", sprint.ID, existing.StateAt(time.Now()))
+
+		return ErrSprintStarted
+	}
+
+	if err := s.repo.Update(ctx, sprint); err != nil {
+		log.Printf("Failed to update sprint: ID=%s, Error=%v", sprint.ID, err)
+
+		return err
+	}
+
+	withSprintState(sprint)
+
+	log.Printf("Sprint updated successfully: ID=%s", sprint.ID)
+
+	return nil
+}
+
+// DeleteSprint takes every task out of a sprint and deletes it along with its scope changes.
+func (s *SprintService) DeleteSprint(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Deleting sprint: ID=%s", id)
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		log.Printf("Failed to delete sprint: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	tasks, err := s.taskService.repo.List(ctx, map[string]interface{}{"sprint_id": id})
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.SprintID = nil
+		if err := s.taskService.repo.Update(ctx, &task); err != nil {
+			log.Printf("Failed to remove task from sprint: TaskID=%s, Error=%v", task.ID, err)
+
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete sprint: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("Sprint deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// ListSprintTasks returns the tasks currently planned into a sprint.
+func (s *SprintService) ListSprintTasks(ctx context.Context, id uuid.UUID) ([]models.Task, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.taskService.ListTasks(ctx, map[string]interface{}{"sprint_id": id})
+}
+
+// AddTask plans a task into a sprint that has not ended. A task can only be in one sprint, so a task
+// still in another sprint, e.g. one carried over from the previous sprint, is moved.
+func (s *SprintService) AddTask(ctx context.Context, sprintID, taskID uuid.UUID) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Adding task to sprint: SprintID=%s, TaskID=%s", sprintID, taskID)
+
+	sprint, err := s.repo.GetByID(ctx, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if sprint.StateAt(now) == models.SprintClosed {
+		return nil, ErrSprintClosed
+	}
+
+	task, err := s.taskService.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.SprintID != nil && *task.SprintID == sprintID {
+		return nil, ErrTaskAlreadyInSprint
+	}
+
+	updated := *task
+	updated.SprintID = &sprintID
+
+	if err := s.taskService.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to add task to sprint: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	if task.SprintID != nil {
+		s.recordScopeChange(ctx, *task.SprintID, task, models.ScopeRemoved, now)
+	}
+	s.recordScopeChange(ctx, sprintID, task, models.ScopeAdded, now)
+
+	log.Printf("Task added to sprint successfully: SprintID=%s, TaskID=%s", sprintID, taskID)
+
+	return &updated, nil
+}
+
+// RemoveTask takes a task out of a sprint that has not ended.
+func (s *SprintService) RemoveTask(ctx context.Context, sprintID, taskID uuid.UUID) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Removing task from sprint: SprintID=%s, TaskID=%s", sprintID, taskID)
+
+	sprint, err := s.repo.GetByID(ctx, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if sprint.StateAt(now) == models.SprintClosed {
+		return nil, ErrSprintClosed
+	}
+
+	task, err := s.taskService.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.SprintID == nil || *task.SprintID != sprintID {
+		return nil, ErrTaskNotInSprint
+	}
+
+	updated := *task
+	updated.SprintID = nil
+
+	if err := s.taskService.repo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to remove task from sprint: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	s.recordScopeChange(ctx, sprintID, task, models.ScopeRemoved, now)
+
+	log.Printf("Task removed from sprint successfully: SprintID=%s, TaskID=%s", sprintID, taskID)
+
+	return &updated, nil
+}
+
+// Summary reports the work committed when the sprint started, the scope added and removed while it ran,
+// and how much of its current scope is completed. Committed, added and removed work is measured with the
+// estimates tasks had when they entered or left the sprint; before the sprint starts, the committed work
+// is its current plan.
+func (s *SprintService) Summary(ctx context.Context, id uuid.UUID) (*models.SprintSummary, error) {
+	log.Printf("Summarizing sprint: ID=%s", id)
+
+	sprint, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.repo.ListScopeChanges(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.SprintSummary{
+		Sprint:       *withSprintState(sprint),
+		ScopeChanges: []models.SprintScopeChange{},
+	}
+
+	// Replay the changes up to the start to find the committed scope; later ones until the end are scope changes
+	committed := make(map[uuid.UUID]models.SprintScopeChange)
+	for _, change := range changes {
+		switch {
+		case !change.At.After(sprint.StartDate):
+			if change.Type == models.ScopeAdded {
+				committed[change.TaskID] = change
+			} else {
+				delete(committed, change.TaskID)
+			}
+		case change.At.Before(sprint.EndDate):
+			summary.ScopeChanges = append(summary.ScopeChanges, change)
+			if change.Type == models.ScopeAdded {
+				summary.Added.Add(change.StoryPoints, change.EstimateMinutes)
+			} else {
+				summary.Removed.Add(change.StoryPoints, change.EstimateMinutes)
+			}
+		}
+	}
+	for _, change := range committed {
+		summary.Committed.Add(change.StoryPoints, change.EstimateMinutes)
+	}
+
+	tasks, err := s.taskService.repo.List(ctx, map[string]interface{}{"sprint_id": id})
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if s.taskService.isFinished(&task) {
+			summary.Completed.Add(task.StoryPoints, task.OriginalEstimate)
+			continue
+		}
+
+		remaining := task.RemainingEstimate
+		if remaining == nil {
+			remaining = task.OriginalEstimate
+		}
+		summary.Remaining.Add(task.StoryPoints, remaining)
+	}
+
+	log.Printf("Sprint summarized: ID=%s, Committed=%d tasks, Added=%d, Removed=%d, Completed=%d",
+		id, summary.Committed.Tasks, summary.Added.Tasks, summary.Removed.Tasks, summary.Completed.Tasks)
+
+	return summary, nil
+}
+
+// recordScopeChange logs a task entering or leaving a sprint with the task's current size
+func (s *SprintService) recordScopeChange(ctx context.Context, sprintID uuid.UUID, task *models.Task, changeType models.ScopeChangeType, at time.Time) {
+	change := &models.SprintScopeChange{
+		SprintID:        sprintID,
+		TaskID:          task.ID,
+		TaskTitle:       task.Title,
+		Type:            changeType,
+		StoryPoints:     task.StoryPoints,
+		EstimateMinutes: task.OriginalEstimate,
+		At:              at,
+	}
+
+	if err := s.repo.AddScopeChange(ctx, change); err != nil {
+		log.Printf("Failed to record scope change: SprintID=%s, TaskID=%s, Error=%v", sprintID, task.ID, err)
+	}
+}
+
+// deleteTaskFromSprints records a deleted task as removed from the sprint it was last added to. The task
+// is gone, so its size is taken from that last change.
+func (s *SprintService) deleteTaskFromSprints(ctx context.Context, taskID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scope, err := s.scopeOf(ctx, taskID)
+	if err != nil {
+		log.Printf("Failed to list sprints of deleted task: TaskID=%s, Error=%v", taskID, err)
+		return
+	}
+
+	now := time.Now()
+	for _, last := range scope {
+		removed := last
+		removed.Type = models.ScopeRemoved
+		removed.At = now
+		if err := s.repo.AddScopeChange(ctx, &removed); err != nil {
+			log.Printf("Failed to record scope change: SprintID=%s, TaskID=%s, Error=%v", last.SprintID, taskID, err)
+		}
+	}
+}
+
+// dropDeletedSprint takes a task that undo or redo writes back out of its sprint when the sprint was deleted
+func (s *SprintService) dropDeletedSprint(ctx context.Context, task *models.Task) error {
+	if task.SprintID == nil {
+		return nil
+	}
+
+	_, err := s.repo.GetByID(ctx, *task.SprintID)
+	if errors.Is(err, repository.ErrNotFound) {
+		task.SprintID = nil
+		return nil
+	}
+
+	return err
+}
+
+// keepTaskSprints returns the sprints whose scope includes a task that is about to be deleted
+func (s *SprintService) keepTaskSprints(ctx context.Context, taskID uuid.UUID) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scope, err := s.scopeOf(ctx, taskID)
+	if err != nil || len(scope) == 0 {
+		return nil, err
+	}
+
+	sprintIDs := make([]uuid.UUID, 0, len(scope))
+	for _, last := range scope {
+		sprintIDs = append(sprintIDs, last.SprintID)
+	}
+
+	return sprintIDs, nil
+}
+
+// restoreTaskSprints adds a restored task back to the scope of the sprints it was in. A task whose sprint
+// closed or was deleted meanwhile leaves the sprint instead.
+func (s *SprintService) restoreTaskSprints(ctx context.Context, taskID uuid.UUID, data json.RawMessage) error {
+	var sprintIDs []uuid.UUID
+	if err := json.Unmarshal(data, &sprintIDs); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, err := s.taskService.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, sprintID := range sprintIDs {
+		sprint, err := s.repo.GetByID(ctx, sprintID)
+		if err == nil && sprint.StateAt(now) != models.SprintClosed {
+			s.recordScopeChange(ctx, sprintID, task, models.ScopeAdded, now)
+			continue
+		}
+
+		if task.SprintID != nil && *task.SprintID == sprintID {
+			updated := *task
+			updated.SprintID = nil
+			if err := s.taskService.repo.Update(ctx, &updated); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// scopeOf returns the last scope change of a task in every sprint that is not closed and whose scope
+// includes the task; the caller must hold the lock
+func (s *SprintService) scopeOf(ctx context.Context, taskID uuid.UUID) ([]models.SprintScopeChange, error) {
+	sprints, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var scope []models.SprintScopeChange
+	now := time.Now()
+	for _, sprint := range sprints {
+		if sprint.StateAt(now) == models.SprintClosed {
+			continue
+		}
+
+		changes, err := s.repo.ListScopeChanges(ctx, sprint.ID)
+		if err != nil {
+			continue
+		}
+
+		var last *models.SprintScopeChange
+		for i := range changes {
+			if changes[i].TaskID == taskID {
+				last = &changes[i]
+			}
+		}
+		if last != nil && last.Type == models.ScopeAdded {
+			scope = append(scope, *last)
+		}
+	}
+
+	return scope, nil
+}
+
+// withSprintState fills in the state of a sprint from the current time
+func withSprintState(sprint *models.Sprint) *models.Sprint {
+	sprint.State = sprint.StateAt(time.Now())
+
+	return sprint
+}
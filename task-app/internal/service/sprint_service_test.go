@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSprint(t *testing.T) {
+	service := NewSprintService(repository.NewInMemorySprintRepository(), NewTaskService(repository.NewInMemoryTaskRepository()))
+	ctx := context.Background()
+	start := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		req         models.CreateSprintRequest
+		expectedErr error
+	}{
+		{"Valid Sprint", models.CreateSprintRequest{Name: "Sprint 1", StartDate: start, EndDate: start.Add(7 * 24 * time.Hour)}, nil},
+		{"Default Length", models.CreateSprintRequest{Name: "Sprint 2", StartDate: start}, nil},
+		{"Empty Name", models.CreateSprintRequest{Name: " ", StartDate: start}, utils.ErrEmptySprintName},
+		{"Missing Start", models.CreateSprintRequest{Name: "Sprint 3"}, utils.ErrMissingSprintStart},
+		{"End Before Start", models.CreateSprintRequest{Name: "Sprint 4", StartDate: start, EndDate: start.Add(-time.Hour)}, utils.ErrSprintEndBeforeStart},
+		{"Too Long", models.CreateSprintRequest{Name: "Sprint 5", StartDate: start, EndDate: start.AddDate(0, 3, 0)}, utils.ErrSprintTooLong},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sprint, err := service.CreateSprint(ctx, test.req)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.SprintPlanned, sprint.State)
+			if test.req.EndDate.IsZero() {
+				assert.Equal(t, start.Add(models.DefaultSprintLength), sprint.EndDate)
+			}
+		})
+	}
+}
+
+func TestUpdateSprintDates(t *testing.T) {
+	service := NewSprintService(repository.NewInMemorySprintRepository(), NewTaskService(repository.NewInMemoryTaskRepository()))
+	ctx := context.Background()
+
+	planned, err := service.CreateSprint(ctx, models.CreateSprintRequest{Name: "Next", StartDate: time.Now().Add(24 * time.Hour)})
+	assert.NoError(t, err)
+	active, err := service.CreateSprint(ctx, models.CreateSprintRequest{Name: "Current", StartDate: time.Now().Add(-24 * time.Hour)})
+	assert.NoError(t, err)
+
+	planned.StartDate = planned.StartDate.Add(24 * time.Hour)
+	planned.EndDate = planned.EndDate.Add(24 * time.Hour)
+	assert.NoError(t, service.UpdateSprint(ctx, planned))
+
+	active.Goal = "Ship it"
+	assert.NoError(t, service.UpdateSprint(ctx, active))
+
+	active.EndDate = active.EndDate.Add(7 * 24 * time.Hour)
+	assert.ErrorIs(t, service.UpdateSprint(ctx, active), ErrSprintStarted)
+}
+
+func TestSprintScope(t *testing.T) {
+	sprintRepo := repository.NewInMemorySprintRepository()
+	taskRepo := repository.NewInMemoryTaskRepository()
+	taskService := NewTaskService(taskRepo)
+	service := NewSprintService(sprintRepo, taskService)
+	ctx := context.Background()
+
+	start := time.Now().Add(-2 * 24 * time.Hour)
+	sprint, err := service.CreateSprint(ctx, models.CreateSprintRequest{Name: "Sprint 1", StartDate: start})
+	assert.NoError(t, err)
+	assert.Equal(t, models.SprintActive, sprint.State)
+
+	sized := func(title string, points float64, minutes int) *models.Task {
+		req := newTaskRequest(title)
+		req.StoryPoints, req.OriginalEstimate = floatPtr(points), intPtr(minutes)
+		return createTask(t, taskService, req)
+	}
+
+	// Plan two tasks before the start, as sprint planning would have
+	committed := []*models.Task{
+		sized("Login page", 3, 120),
+		sized("Signup page", 5, 240),
+	}
+	for _, task := range committed {
+		task.SprintID = &sprint.ID
+		assert.NoError(t, taskRepo.Update(ctx, task))
+		assert.NoError(t, sprintRepo.AddScopeChange(ctx, &models.SprintScopeChange{
+			SprintID:        sprint.ID,
+			TaskID:          task.ID,
+			Type:            models.ScopeAdded,
+			StoryPoints:     task.StoryPoints,
+			EstimateMinutes: task.OriginalEstimate,
+			At:              start.Add(-time.Hour),
+		}))
+	}
+
+	// Scope changes after the start
+	added := sized("Password reset", 2, 60)
+	_, err = service.AddTask(ctx, sprint.ID, added.ID)
+	assert.NoError(t, err)
+
+	_, err = service.AddTask(ctx, sprint.ID, added.ID)
+	assert.ErrorIs(t, err, ErrTaskAlreadyInSprint)
+
+	_, err = service.RemoveTask(ctx, sprint.ID, committed[1].ID)
+	assert.NoError(t, err)
+
+	_, err = service.RemoveTask(ctx, sprint.ID, committed[1].ID)
+	assert.ErrorIs(t, err, ErrTaskNotInSprint)
+
+	// Updating a task keeps it in its sprint
+	done, err := taskService.GetTask(ctx, committed[0].ID)
+	assert.NoError(t, err)
+	done.SprintID = nil
+	done.Status = models.StatusInProgress
+	assert.NoError(t, taskService.UpdateTask(ctx, done))
+	done.Status = models.StatusDone
+	assert.NoError(t, taskService.UpdateTask(ctx, done))
+
+	tasks, err := service.ListSprintTasks(ctx, sprint.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+
+	summary, err := service.Summary(ctx, sprint.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SprintWork{Tasks: 2, StoryPoints: 8, EstimateMinutes: 360}, summary.Committed)
+	assert.Equal(t, models.SprintWork{Tasks: 1, StoryPoints: 2, EstimateMinutes: 60}, summary.Added)
+	assert.Equal(t, models.SprintWork{Tasks: 1, StoryPoints: 5, EstimateMinutes: 240}, summary.Removed)
+	assert.Equal(t, models.SprintWork{Tasks: 1, StoryPoints: 3, EstimateMinutes: 120}, summary.Completed)
+	assert.Equal(t, models.SprintWork{Tasks: 1, StoryPoints: 2, EstimateMinutes: 60}, summary.Remaining)
+	assert.Len(t, summary.ScopeChanges, 2)
+
+	// Deleting a task in the sprint removes it from the scope
+	assert.NoError(t, taskService.DeleteTask(ctx, added.ID))
+	summary, err = service.Summary(ctx, sprint.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, summary.Removed.Tasks)
+	assert.Zero(t, summary.Remaining.Tasks)
+}
+
+func TestMoveTaskBetweenSprints(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewSprintService(repository.NewInMemorySprintRepository(), taskService)
+	ctx := context.Background()
+
+	first, err := service.CreateSprint(ctx, models.CreateSprintRequest{Name: "Sprint 1", StartDate: time.Now().Add(-time.Hour)})
+	assert.NoError(t, err)
+	second, err := service.CreateSprint(ctx, models.CreateSprintRequest{Name: "Sprint 2", StartDate: first.EndDate})
+	assert.NoError(t, err)
+	closed, err := service.CreateSprint(ctx, models.CreateSprintRequest{Name: "Sprint 0", StartDate: time.Now().AddDate(0, 0, -20)})
+	assert.NoError(t, err)
+	assert.Equal(t, models.SprintClosed, closed.State)
+
+	task := createTask(t, taskService, newTaskRequest("Carry over"))
+
+	_, err = service.AddTask(ctx, closed.ID, task.ID)
+	assert.ErrorIs(t, err, ErrSprintClosed)
+
+	_, err = service.AddTask(ctx, first.ID, task.ID)
+	assert.NoError(t, err)
+
+	moved, err := service.AddTask(ctx, second.ID, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, second.ID, *moved.SprintID)
+
+	summary, err := service.Summary(ctx, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.Added.Tasks)
+	assert.Equal(t, 1, summary.Removed.Tasks)
+
+	// The second sprint has not started, so the task is part of its plan
+	summary, err = service.Summary(ctx, second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.Committed.Tasks)
+	assert.Empty(t, summary.ScopeChanges)
+
+	assert.NoError(t, service.DeleteSprint(ctx, second.ID))
+	stored, err := taskService.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, stored.SprintID)
+}
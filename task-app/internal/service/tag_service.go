@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTagNameTaken  = errors.New("tag name already in use")
+	ErrTagMergeSelf  = errors.New("tag cannot be merged into itself")
+	ErrTagNotOnTask  = errors.New("tag is not attached to task")
+	ErrTagAlreadySet = errors.New("tag is already attached to task")
+)
+
+// TagService manages tags and their association with tasks. Tasks reference tags by ID,
+// so renaming a tag is reflected on every task without touching them.
+type TagService struct {
+	mu        sync.Mutex
+	repo      repository.TagRepository
+	taskRepo  repository.TaskRepository
+	validator *utils.Validator
+	links     sync.Locker
+}
+
+func NewTagService(repo repository.TagRepository, taskRepo repository.TaskRepository) *TagService {
+	return &TagService{
+		repo:      repo,
+		taskRepo:  taskRepo,
+		validator: utils.NewValidator(),
+	}
+}
+
+// SetTagLinks sets the lock held while tags are attached to or removed from tasks, see TaskService.TagLinks.
+func (s *TagService) SetTagLinks(links sync.Locker) {
+	s.links = links
+}
+
+func (s *TagService) CreateTag(ctx context.Context, req models.CreateTagRequest) (*models.Tag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tag := &models.Tag{
+		Name:        req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+	}
+
+	log.Printf("Creating tag: Name=%s", tag.Name)
+
+	if err := s.validateTag(ctx, tag); err != nil {
+		log.Printf("Tag validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, tag); err != nil {
+		log.Printf("Failed to create tag: Name=%s, Error=%v", tag.Name, err)
+
+		return nil, err
+	}
+
+	log.Printf("Tag created successfully: ID=%s", tag.ID)
+	return tag, nil
+}
+
+func (s *TagService) GetTag(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	log.Printf("Retrieving tag: ID=%s", id)
+
+	tag, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to retrieve tag: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) ListTags(ctx context.Context) ([]models.Tag, error) {
+	tags, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list tags: Error=%v", err)
+
+		return nil, err
+	}
+
+	log.Printf("Listed tags successfully: Found %d tags", len(tags))
+
+	return tags, nil
+}
+
+// UpdateTag changes a tag's name, color or description.
+func (s *TagService) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Updating tag: ID=%s, Name=%s", tag.ID, tag.Name)
+
+	if err := s.validateTag(ctx, tag); err != nil {
+		log.Printf("Tag validation failed: ID=%s, Error=%v", tag.ID, err)
+		return err
+	}
+
+	if err := s.repo.Update(ctx, tag); err != nil {
+		log.Printf("Failed to update tag: ID=%s, Error=%v", tag.ID, err)
+
+		return err
+	}
+
+	log.Printf("Tag updated successfully: ID=%s", tag.ID)
+
+	return nil
+}
+
+// DeleteTag detaches a tag from every task and deletes it.
+func (s *TagService) DeleteTag(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Deleting tag: ID=%s", id)
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		log.Printf("Failed to delete tag: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	if s.links != nil {
+		s.links.Lock()
+		defer s.links.Unlock()
+	}
+
+	if _, err := s.taskRepo.DetachTag(ctx, id); err != nil {
+		log.Printf("Failed to detach tag from tasks: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete tag: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("Tag deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// MergeTag moves every task tagged with from over to into, in a single repository operation, and deletes from.
+func (s *TagService) MergeTag(ctx context.Context, from, into uuid.UUID) (*models.Tag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Merging tag: From=%s, Into=%s", from, into)
+
+	if from == into {
+		return nil, ErrTagMergeSelf
+	}
+
+	if _, err := s.repo.GetByID(ctx, from); err != nil {
+		return nil, err
+	}
+
+	target, err := s.repo.GetByID(ctx, into)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.links != nil {
+		s.links.Lock()
+		defer s.links.Unlock()
+	}
+
+	updated, err := s.taskRepo.MergeTag(ctx, from, into)
+	if err != nil {
+		log.Printf("Failed to merge tag: From=%s, Error=%v", from, err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Delete(ctx, from); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Tag merged successfully: From=%s, Into=%s, Updated %d tasks", from, into, len(updated))
+
+	return target, nil
+}
+
+// ListTaskTags returns the tags attached to a task.
+func (s *TagService) ListTaskTags(ctx context.Context, taskID uuid.UUID) ([]models.Tag, error) {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]models.Tag, 0, len(task.Tags))
+	for _, tagID := range task.Tags {
+		tag, err := s.repo.GetByID(ctx, tagID)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	return tags, nil
+}
+
+func (s *TagService) AttachTag(ctx context.Context, taskID, tagID uuid.UUID) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Attaching tag: TaskID=%s, TagID=%s", taskID, tagID)
+
+	if _, err := s.repo.GetByID(ctx, tagID); err != nil {
+		return nil, err
+	}
+
+	if s.links != nil {
+		s.links.Lock()
+		defer s.links.Unlock()
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range task.Tags {
+		if existing == tagID {
+			return nil, ErrTagAlreadySet
+		}
+	}
+
+	updated := *task
+	updated.Tags = append(append([]uuid.UUID{}, task.Tags...), tagID)
+
+	if err := s.taskRepo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to attach tag: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Tag attached successfully: TaskID=%s, TagID=%s", taskID, tagID)
+
+	return &updated, nil
+}
+
+func (s *TagService) DetachTag(ctx context.Context, taskID, tagID uuid.UUID) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Detaching tag: TaskID=%s, TagID=%s", taskID, tagID)
+
+	if s.links != nil {
+		s.links.Lock()
+		defer s.links.Unlock()
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *task
+	updated.Tags = make([]uuid.UUID, 0, len(task.Tags))
+	for _, existing := range task.Tags {
+		if existing != tagID {
+			updated.Tags = append(updated.Tags, existing)
+		}
+	}
+
+	if len(updated.Tags) == len(task.Tags) {
+		return nil, ErrTagNotOnTask
+	}
+
+	if err := s.taskRepo.Update(ctx, &updated); err != nil {
+		log.Printf("Failed to detach tag: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Tag detached successfully: TaskID=%s, TagID=%s", taskID, tagID)
+
+	return &updated, nil
+}
+
+// DropDeletedTags removes the tags deleted since from a task that undo or redo writes back, as deleting
+// them removed them from the tasks that existed then. Register it with TaskService.OnTaskReplay.
+func (s *TagService) DropDeletedTags(ctx context.Context, task *models.Task) error {
+	if len(task.Tags) == 0 {
+		return nil
+	}
+
+	tags := make([]uuid.UUID, 0, len(task.Tags))
+	for _, tagID := range task.Tags {
+		_, err := s.repo.GetByID(ctx, tagID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		tags = append(tags, tagID)
+	}
+	task.Tags = tags
+
+	return nil
+}
+
+// validateTag checks the tag fields and that no other tag has the same name
+func (s *TagService) validateTag(ctx context.Context, tag *models.Tag) error {
+	if err := s.validator.ValidateTag(tag); err != nil {
+		return err
+	}
+
+	if existing, err := s.repo.GetByName(ctx, tag.Name); err == nil && existing.ID != tag.ID {
+		return fmt.Errorf("%w: %s", ErrTagNameTaken, tag.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTag(t *testing.T) {
+	service := NewTagService(repository.NewInMemoryTagRepository(), repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	_, err := service.CreateTag(ctx, models.CreateTagRequest{Name: "backend", Color: "#336699"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		req         models.CreateTagRequest
+		expectedErr error
+		hasError    bool
+	}{
+		{
+			name: "Valid Tag",
+			req:  models.CreateTagRequest{Name: "frontend"},
+		},
+		{
+			name:        "Duplicate Name Ignoring Case",
+			req:         models.CreateTagRequest{Name: "Backend"},
+			expectedErr: ErrTagNameTaken,
+			hasError:    true,
+		},
+		{
+			name:     "Empty Name",
+			req:      models.CreateTagRequest{Name: "  "},
+			hasError: true,
+		},
+		{
+			name:     "Invalid Color",
+			req:      models.CreateTagRequest{Name: "ops", Color: "red"},
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tag, err := service.CreateTag(ctx, test.req)
+			if test.hasError {
+				assert.Error(t, err)
+				if test.expectedErr != nil {
+					assert.ErrorIs(t, err, test.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, tag.ID)
+			}
+		})
+	}
+}
+
+func TestTaskTags(t *testing.T) {
+	taskRepo := repository.NewInMemoryTaskRepository()
+	taskService := NewTaskService(taskRepo)
+	service := NewTagService(repository.NewInMemoryTagRepository(), taskRepo)
+	ctx := context.Background()
+
+	backend, err := service.CreateTag(ctx, models.CreateTagRequest{Name: "backend"})
+	assert.NoError(t, err)
+	urgent, err := service.CreateTag(ctx, models.CreateTagRequest{Name: "urgent"})
+	assert.NoError(t, err)
+
+	api := createTask(t, taskService, newTaskRequest("API"))
+	db := createTask(t, taskService, newTaskRequest("Database"))
+
+	_, err = service.AttachTag(ctx, api.ID, backend.ID)
+	assert.NoError(t, err)
+	_, err = service.AttachTag(ctx, api.ID, urgent.ID)
+	assert.NoError(t, err)
+	_, err = service.AttachTag(ctx, db.ID, backend.ID)
+	assert.NoError(t, err)
+
+	_, err = service.AttachTag(ctx, db.ID, backend.ID)
+	assert.ErrorIs(t, err, ErrTagAlreadySet)
+	_, err = service.AttachTag(ctx, db.ID, uuid.New())
+	assert.Error(t, err)
+
+	tags, err := service.ListTaskTags(ctx, api.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+
+	tests := []struct {
+		name     string
+		params   map[string]string
+		expected int
+	}{
+		{name: "Any Backend", params: map[string]string{"tags_any": backend.ID.String()}, expected: 2},
+		{name: "Any Of Both", params: map[string]string{"tags_any": backend.ID.String() + "," + urgent.ID.String()}, expected: 2},
+		{name: "All Of Both", params: map[string]string{"tags_all": backend.ID.String() + "," + urgent.ID.String()}, expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := ParseTaskFilters(test.params)
+			assert.NoError(t, err)
+
+			tasks, err := taskService.ListTasks(ctx, filters)
+			assert.NoError(t, err)
+			assert.Len(t, tasks, test.expected)
+		})
+	}
+
+	// Regular updates keep the tags of a task
+	stored, err := taskService.GetTask(ctx, db.ID)
+	assert.NoError(t, err)
+	stored.Title = "Database schema"
+	assert.NoError(t, taskService.UpdateTask(ctx, stored))
+	stored, err = taskService.GetTask(ctx, db.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{backend.ID}, stored.Tags)
+
+	_, err = service.DetachTag(ctx, db.ID, backend.ID)
+	assert.NoError(t, err)
+	_, err = service.DetachTag(ctx, db.ID, backend.ID)
+	assert.ErrorIs(t, err, ErrTagNotOnTask)
+}
+
+func TestMergeAndDeleteTag(t *testing.T) {
+	taskRepo := repository.NewInMemoryTaskRepository()
+	taskService := NewTaskService(taskRepo)
+	service := NewTagService(repository.NewInMemoryTagRepository(), taskRepo)
+	ctx := context.Background()
+
+	bug, err := service.CreateTag(ctx, models.CreateTagRequest{Name: "bug"})
+	assert.NoError(t, err)
+	defect, err := service.CreateTag(ctx, models.CreateTagRequest{Name: "defect"})
+	assert.NoError(t, err)
+
+	both := createTask(t, taskService, newTaskRequest("Both"))
+	onlyDefect := createTask(t, taskService, newTaskRequest("Only defect"))
+	for _, attach := range []struct{ task, tag uuid.UUID }{
+		{both.ID, bug.ID}, {both.ID, defect.ID}, {onlyDefect.ID, defect.ID},
+	} {
+		_, err := service.AttachTag(ctx, attach.task, attach.tag)
+		assert.NoError(t, err)
+	}
+
+	_, err = service.MergeTag(ctx, defect.ID, defect.ID)
+	assert.ErrorIs(t, err, ErrTagMergeSelf)
+
+	target, err := service.MergeTag(ctx, defect.ID, bug.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, bug.ID, target.ID)
+
+	_, err = service.GetTag(ctx, defect.ID)
+	assert.Error(t, err)
+
+	for _, id := range []uuid.UUID{both.ID, onlyDefect.ID} {
+		task, err := taskService.GetTask(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{bug.ID}, task.Tags)
+	}
+
+	assert.NoError(t, service.DeleteTag(ctx, bug.ID))
+
+	task, err := taskService.GetTask(ctx, both.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, task.Tags)
+}
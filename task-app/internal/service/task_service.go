@@ -2,45 +2,171 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sync"
+	"task-app/internal/auth"
 	"task-app/internal/models"
 	"task-app/internal/repository"
 	"task-app/pkg/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type TaskService struct {
-	repo      repository.TaskRepository
-	validator *utils.Validator
+	repo           repository.TaskRepository
+	users          repository.UserRepository
+	projects       repository.ProjectRepository
+	projectLinks   sync.RWMutex
+	customFields   repository.CustomFieldRepository
+	validator      *utils.Validator
+	hierarchy      models.HierarchyOptions
+	keepAssignees  bool
+	deleteHooks    []TaskDeleteHook
+	duplicateHooks []TaskDuplicateHook
+	keepers        []taskKeeper
+	replayHooks    []TaskReplayHook
+
+	checklistAutoComplete bool
+	checklistMu           sync.Mutex // held while a task's checklist or tags are read and written back
+	recurrenceMu          sync.Mutex
+
+	operations     repository.OperationRepository
+	operationLimit int
+	operationMu    sync.Mutex
 }
 
+// TaskDeleteHook is called for every task removed by a delete, so data attached to the task can be cleaned up.
+type TaskDeleteHook func(ctx context.Context, id uuid.UUID)
+
 func NewTaskService(repo repository.TaskRepository) *TaskService {
 	return &TaskService{
-		repo:      repo,
-		validator: utils.NewValidator(),
+		repo:          repo,
+		validator:     utils.NewValidator(),
+		hierarchy:     models.HierarchyOptions{OnDelete: models.DeleteOrphan},
+		keepAssignees: true,
+	}
+}
+
+// SetWorkflow replaces the default workflow enforced for categories without a workflow of their own.
+func (s *TaskService) SetWorkflow(workflow *utils.Workflow) {
+	s.validator.Workflows().SetDefault(workflow)
+}
+
+// OnTaskDeleted registers a hook that runs after each task is deleted, including subtasks removed by a cascade.
+func (s *TaskService) OnTaskDeleted(hook TaskDeleteHook) {
+	s.deleteHooks = append(s.deleteHooks, hook)
+}
+
+// Workflows returns the registry that binds workflows to categories.
+func (s *TaskService) Workflows() *utils.WorkflowRegistry {
+	return s.validator.Workflows()
+}
+
+// AllowedTransitions lists the statuses a task may move to next under its category's workflow.
+func (s *TaskService) AllowedTransitions(task *models.Task) []models.Status {
+	return s.workflowFor(task.Category).NextStatuses(task)
+}
+
+func (s *TaskService) workflowFor(category string) *utils.Workflow {
+	return s.validator.Workflows().ForCategory(category)
+}
+
+// isFinished reports whether a task is in a terminal status of its category's workflow
+func (s *TaskService) isFinished(task *models.Task) bool {
+	return s.workflowFor(task.Category).IsTerminal(task.Status)
+}
+
+// withFinished returns a copy of filters that lets the repository tell finished tasks by their workflow
+func (s *TaskService) withFinished(filters map[string]interface{}) map[string]interface{} {
+	scoped := make(map[string]interface{}, len(filters)+1)
+	for key, value := range filters {
+		scoped[key] = value
 	}
+	scoped["finished"] = models.FinishedFunc(s.isFinished)
+
+	return scoped
 }
 
-func (s *TaskService) CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error) {
+// clearRemainingWork zeroes the remaining estimate of a finished task that has been estimated
+func (s *TaskService) clearRemainingWork(task *models.Task) {
+	if !s.isFinished(task) || (task.OriginalEstimate == nil && task.RemainingEstimate == nil) {
+		return
+	}
+
+	remaining := 0
+	task.RemainingEstimate = &remaining
+}
+
+func (s *TaskService) CreateTask(ctx context.Context, req models.CreateTaskRequest) (created *models.Task, err error) {
+	ctx, finish := s.beginOperation(ctx, models.OperationCreate)
+	defer func() { finish(taskID(created), err) }()
+
 	task := &models.Task{
-		Title:       req.Title,
-		Description: req.Description,
-		Category:    req.Category,
-		DueDate:     req.DueDate,
-		Priority:    req.Priority,
-		Status:      req.Status,
+		Title:             req.Title,
+		Description:       req.Description,
+		Category:          req.Category,
+		ProjectID:         req.ProjectID,
+		DueDate:           req.DueDate,
+		Priority:          req.Priority,
+		Status:            req.Status,
+		ParentID:          req.ParentID,
+		Assignees:         req.Assignees,
+		ReporterID:        req.ReporterID,
+		OriginalEstimate:  req.OriginalEstimate,
+		RemainingEstimate: req.RemainingEstimate,
+		StoryPoints:       req.StoryPoints,
+		CustomFields:      req.CustomFields,
+	}
+
+	// Until work starts, all of the original estimate remains
+	if task.RemainingEstimate == nil && task.OriginalEstimate != nil {
+		remaining := *task.OriginalEstimate
+		task.RemainingEstimate = &remaining
+	}
+
+	// Without an explicit reporter the authenticated user reports the task
+	if task.ReporterID == nil {
+		if userID, ok := auth.UserFromContext(ctx); ok {
+			task.ReporterID = &userID
+		}
 	}
 
 	log.Printf("Creating task: Title=%s, Category=%s, Status=%s", task.Title, task.Category, task.Status)
 
+	if err := s.applyProject(ctx, task, true); err != nil {
+		log.Printf("Invalid project: %v", err)
+
+		return nil, err
+	}
+
 	if err := s.validator.ValidateTask(task); err != nil {
 		log.Printf("Task validation failed: %v", err)
 
 		return nil, err
 	}
+	s.clearRemainingWork(task)
+
+	if err := s.checkParent(ctx, task.ID, task.ParentID); err != nil {
+		log.Printf("Invalid parent task: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.checkPeople(ctx, task); err != nil {
+		log.Printf("Invalid assignees or reporter: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.applyCustomFields(ctx, task, true); err != nil {
+		log.Printf("Invalid custom fields: %v", err)
 
-	err := s.repo.Create(ctx, task)
+		return nil, err
+	}
+
+	err = s.storeLinked(ctx, task, func() error { return s.repo.Create(ctx, task) })
 	if err != nil {
 		log.Printf("Failed to create task: Title=%s, Category=%s, Error=%v", task.Title, task.Category, err)
 
@@ -62,48 +188,141 @@ func (s *TaskService) GetTask(ctx context.Context, id uuid.UUID) (*models.Task,
 	}
 
 	log.Printf("Task retrieved successfully: ID=%s, Title=%s", task.ID, task.Title)
-	return task, nil
+
+	index, err := s.childIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *task
+	result.Progress = s.progress(index, task.ID)
+	result.AllowedTransitions = s.AllowedTransitions(task)
+	result.ChecklistCompletion = checklistCompletion(task.Checklist)
+
+	return &result, nil
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, task *models.Task) error {
+func (s *TaskService) UpdateTask(ctx context.Context, task *models.Task) (err error) {
+	ctx, finish := s.beginOperation(ctx, models.OperationUpdate)
+	defer func() { finish(task.ID, err) }()
+
 	log.Printf("Updating task: ID=%s, Title=%s, Category=%s, Status=%s", task.ID, task.Title, task.Category, task.Status)
 
+	// Progress, allowed transitions and checklist completion are derived and never stored
+	task.Progress = nil
+	task.AllowedTransitions = nil
+	task.ChecklistCompletion = nil
+
+	if err := s.applyProject(ctx, task, false); err != nil {
+		log.Printf("Invalid project: ID=%s, Error=%v", task.ID, err)
+		return err
+	}
+
 	if err := s.validator.ValidateTask(task); err != nil {
 		log.Printf("Task validation failed: ID=%s, Error=%v", task.ID, err)
 		return err
 	}
 
-	err := s.repo.Update(ctx, task)
+	if err := s.checkParent(ctx, task.ID, task.ParentID); err != nil {
+		log.Printf("Invalid parent task: ID=%s, Error=%v", task.ID, err)
+		return err
+	}
+
+	// The checklist and tags copied below must not be changed until the task is written back
+	s.checklistMu.Lock()
+	defer s.checklistMu.Unlock()
+
+	existing, err := s.repo.GetByID(ctx, task.ID)
 	if err != nil {
 		log.Printf("Failed to update task: ID=%s, Error=%v", task.ID, err)
 
 		return err
 	}
 
-	log.Printf("Task updated successfully: ID=%s, Title=%s", task.ID, task.Title)
+	// Dependencies, tags, checklists, recurrence, sprints and milestones are managed through their own endpoints
+	task.BlockedBy = existing.BlockedBy
+	task.Tags = existing.Tags
+	task.Checklist = existing.Checklist
+	task.Recurrence = existing.Recurrence
+	task.SprintID = existing.SprintID
+	task.MilestoneID = existing.MilestoneID
+	if task.ReporterID == nil {
+		task.ReporterID = existing.ReporterID
+	}
 
-	return nil
-}
+	if err := s.checkPeople(ctx, task); err != nil {
+		log.Printf("Invalid assignees or reporter: ID=%s, Error=%v", task.ID, err)
+		return err
+	}
 
-func (s *TaskService) DeleteTask(ctx context.Context, id uuid.UUID) error {
-	log.Printf("Deleting task: ID=%s", id)
+	task.CustomFields = mergeCustomFields(existing, task)
+	if err := s.applyCustomFields(ctx, task, false); err != nil {
+		log.Printf("Invalid custom fields: ID=%s, Error=%v", task.ID, err)
+		return err
+	}
+	previousStatus := existing.Status
+
+	if err := s.checkTransition(existing, task); err != nil {
+		log.Printf("Status transition rejected: ID=%s, Error=%v", task.ID, err)
+		return err
+	}
 
-	err := s.repo.Delete(ctx, id)
+	if err := s.applyBlockedStatus(ctx, task, false); err != nil {
+		return err
+	}
+
+	if task.Status != previousStatus {
+		s.clearRemainingWork(task)
+	}
 
+	err = s.storeLinked(ctx, task, func() error { return s.repo.Update(ctx, task) })
 	if err != nil {
-		log.Printf("Failed to delete task: ID=%s, Error=%v", id, err)
+		log.Printf("Failed to update task: ID=%s, Error=%v", task.ID, err)
 
 		return err
 	}
 
-	log.Printf("Task deleted successfully: ID=%s", id)
+	if task.Status != previousStatus {
+		s.statusChanged(ctx, task)
+	}
+
+	log.Printf("Task updated successfully: ID=%s, Title=%s", task.ID, task.Title)
 
 	return nil
 }
 
+// checkTransition checks a status change against the workflow of the task's new category. A task moving to a
+// category whose workflow lacks its current status enters that workflow and must start in its initial status.
+func (s *TaskService) checkTransition(existing, task *models.Task) error {
+	workflow := s.workflowFor(task.Category)
+	if task.Category == existing.Category || workflow.HasStatus(existing.Status) {
+		return workflow.CheckTransition(existing.Status, task)
+	}
+
+	if task.Status != workflow.InitialStatus() {
+		return fmt.Errorf("%w: %s -> %s: %s starts in %s", utils.ErrTransitionNotAllowed, existing.Status, task.Status,
+			workflow.Name, workflow.InitialStatus())
+	}
+
+	return nil
+}
+
+// statusChanged re-evaluates the tasks blocked by a task and continues its recurrence after its status changed
+func (s *TaskService) statusChanged(ctx context.Context, task *models.Task) {
+	if err := s.refreshDependents(ctx, task.ID); err != nil {
+		log.Printf("Failed to refresh dependent tasks: ID=%s, Error=%v", task.ID, err)
+	}
+
+	s.recurOnCompletion(ctx, task)
+}
+
+func (s *TaskService) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	return s.DeleteTaskWithMode(ctx, id, s.hierarchy.OnDelete)
+}
+
 func (s *TaskService) ListTasks(ctx context.Context, filters map[string]interface{}) ([]models.Task, error) {
 	log.Printf("Listing tasks with filters: %+v", filters)
-	tasks, err := s.repo.List(ctx, filters)
+	tasks, err := s.repo.List(ctx, s.withFinished(filters))
 
 	if err != nil {
 		log.Printf("Failed to list tasks: Error=%v", err)
@@ -111,23 +330,40 @@ func (s *TaskService) ListTasks(ctx context.Context, filters map[string]interfac
 		return nil, err
 	}
 
+	for i := range tasks {
+		tasks[i].ChecklistCompletion = checklistCompletion(tasks[i].Checklist)
+	}
+
 	log.Printf("Listed tasks successfully: Found %d tasks", len(tasks))
 
 	return tasks, nil
 }
 
 func (s *TaskService) DuplicateTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
-	log.Printf("Duplicating task: ID=%s", id)
+	return s.DuplicateTaskWithOptions(ctx, id, s.DuplicateDefaults())
+}
 
-	duplicatedTask, err := s.repo.Duplicate(ctx, id)
+func (s *TaskService) AggregateTasks(ctx context.Context, filters map[string]interface{}, opts models.AggregationOptions) (*models.TaskStats, error) {
+	log.Printf("Aggregating tasks with filters: %+v, GroupBy=%v, Interval=%s", filters, opts.GroupBy, opts.Interval)
+
+	if err := ValidateAggregationOptions(opts); err != nil {
+		log.Printf("Invalid aggregation options: %v", err)
+
+		return nil, err
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
 
+	stats, err := s.repo.Aggregate(ctx, s.withFinished(filters), opts)
 	if err != nil {
-		log.Printf("Failed to duplicate task: ID=%s, Error=%v", id, err)
+		log.Printf("Failed to aggregate tasks: Error=%v", err)
 
 		return nil, err
 	}
 
-	log.Printf("Task duplicated successfully: OriginalID=%s, DuplicatedID=%s", id, duplicatedTask.ID)
+	log.Printf("Aggregated tasks successfully: Total=%d", stats.Total)
 
-	return duplicatedTask, nil
+	return stats, nil
 }
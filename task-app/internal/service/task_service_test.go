@@ -7,6 +7,7 @@ import (
 
 	"task-app/internal/models"
 	"task-app/internal/repository"
+	"task-app/pkg/utils"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -262,3 +263,153 @@ func TestDuplicateTask(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateTasks(t *testing.T) {
+	repo := repository.NewInMemoryTaskRepository()
+	service := NewTaskService(repo)
+	ctx := context.Background()
+
+	_ = repo.Create(ctx, &models.Task{
+		Title:    "Counted Task",
+		DueDate:  time.Now().Add(24 * time.Hour),
+		Priority: models.PriorityHigh,
+		Status:   models.StatusToDo,
+	})
+
+	tests := []struct {
+		name      string
+		opts      models.AggregationOptions
+		expectErr bool
+	}{
+		{
+			name:      "Valid Options",
+			opts:      models.AggregationOptions{GroupBy: []string{"status"}, Interval: models.IntervalWeek},
+			expectErr: false,
+		},
+		{
+			name:      "Unknown Group By Field",
+			opts:      models.AggregationOptions{GroupBy: []string{"title"}},
+			expectErr: true,
+		},
+		{
+			name:      "Unknown Interval",
+			opts:      models.AggregationOptions{Interval: "fortnight"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats, err := service.AggregateTasks(ctx, map[string]interface{}{}, test.opts)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, stats.Total)
+				assert.Equal(t, 1, stats.ByPriority[models.PriorityHigh])
+			}
+		})
+	}
+}
+
+func TestUpdateTaskWorkflow(t *testing.T) {
+	repo := repository.NewInMemoryTaskRepository()
+	service := NewTaskService(repo)
+	ctx := context.Background()
+
+	task := &models.Task{
+		Title:    "Workflow Task",
+		DueDate:  time.Now().Add(24 * time.Hour),
+		Priority: models.PriorityMedium,
+		Status:   models.StatusDone,
+	}
+	_ = repo.Create(ctx, task)
+
+	withStatus := func(status models.Status) *models.Task {
+		updated := *task
+		updated.Status = status
+		return &updated
+	}
+
+	err := service.UpdateTask(ctx, withStatus(models.StatusToDo))
+	assert.ErrorIs(t, err, utils.ErrTransitionNotAllowed)
+
+	retrieved, err := service.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusDone, retrieved.Status)
+	assert.Equal(t, []models.Status{models.StatusInProgress}, retrieved.AllowedTransitions)
+
+	assert.NoError(t, service.UpdateTask(ctx, withStatus(models.StatusInProgress)))
+
+	workflow := utils.NewDefaultWorkflow()
+	workflow.GuardLeaving(models.StatusInProgress, utils.RequireDescription)
+	service.SetWorkflow(workflow)
+
+	err = service.UpdateTask(ctx, withStatus(models.StatusDone))
+	assert.ErrorIs(t, err, utils.ErrTransitionGuard)
+}
+
+func TestTaskEstimates(t *testing.T) {
+	service := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	minutes := func(value int) *int { return &value }
+	points := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		name            string
+		original        *int
+		remaining       *int
+		storyPoints     *float64
+		expectRemaining *int
+		expectedErr     error
+	}{
+		{name: "Remaining Defaults To Original", original: minutes(240), expectRemaining: minutes(240)},
+		{name: "Explicit Remaining", original: minutes(240), remaining: minutes(30), expectRemaining: minutes(30)},
+		{name: "Story Points Only", storyPoints: points(5)},
+		{name: "Negative Estimate", original: minutes(-1), expectedErr: utils.ErrInvalidEstimate},
+		{name: "Too Many Story Points", storyPoints: points(101), expectedErr: utils.ErrInvalidStoryPoints},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := models.CreateTaskRequest{
+				Title:             test.name,
+				DueDate:           time.Now().Add(24 * time.Hour),
+				Priority:          models.PriorityMedium,
+				Status:            models.StatusToDo,
+				OriginalEstimate:  test.original,
+				RemainingEstimate: test.remaining,
+				StoryPoints:       test.storyPoints,
+			}
+
+			task, err := service.CreateTask(ctx, req)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectRemaining, task.RemainingEstimate)
+			assert.Equal(t, test.storyPoints, task.StoryPoints)
+		})
+	}
+
+	task, err := service.CreateTask(ctx, models.CreateTaskRequest{
+		Title:            "Migrate database",
+		DueDate:          time.Now().Add(24 * time.Hour),
+		Priority:         models.PriorityHigh,
+		Status:           models.StatusInProgress,
+		OriginalEstimate: minutes(480),
+	})
+	assert.NoError(t, err)
+
+	// Finishing a task leaves no remaining work
+	done := *task
+	done.Status = models.StatusDone
+	assert.NoError(t, service.UpdateTask(ctx, &done))
+
+	stored, _ := service.GetTask(ctx, task.ID)
+	assert.Equal(t, minutes(0), stored.RemainingEstimate)
+	assert.Equal(t, minutes(480), stored.OriginalEstimate)
+}
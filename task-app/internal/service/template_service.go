@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var ErrTemplateNameTaken = errors.New("template name already in use")
+
+// TemplateService manages task templates and creates tasks from them.
+type TemplateService struct {
+	mu          sync.Mutex
+	repo        repository.TemplateRepository
+	taskService *TaskService
+	validator   *utils.Validator
+}
+
+func NewTemplateService(repo repository.TemplateRepository, taskService *TaskService) *TemplateService {
+	return &TemplateService{
+		repo:        repo,
+		taskService: taskService,
+		validator:   utils.NewValidator(),
+	}
+}
+
+func (s *TemplateService) CreateTemplate(ctx context.Context, req models.CreateTemplateRequest) (*models.TaskTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	template := &models.TaskTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		Task:        req.Task,
+	}
+
+	return s.createTemplate(ctx, template)
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, id uuid.UUID) (*models.TaskTemplate, error) {
+	log.Printf("Fetching template: ID=%s", id)
+
+	template, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to fetch template: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return withVariables(template), nil
+}
+
+func (s *TemplateService) ListTemplates(ctx context.Context) ([]models.TaskTemplate, error) {
+	log.Println("Listing templates")
+
+	templates, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list templates: Error=%v", err)
+
+		return nil, err
+	}
+
+	for i := range templates {
+		withVariables(&templates[i])
+	}
+
+	return templates, nil
+}
+
+func (s *TemplateService) UpdateTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Updating template: ID=%s, Name=%s", template.ID, template.Name)
+
+	if err := s.validateTemplate(ctx, template); err != nil {
+		log.Printf("Template validation failed: ID=%s, Error=%v", template.ID, err)
+		return err
+	}
+
+	if err := s.repo.Update(ctx, template); err != nil {
+		log.Printf("Failed to update template: ID=%s, Error=%v", template.ID, err)
+
+		return err
+	}
+
+	withVariables(template)
+
+	log.Printf("Template updated successfully: ID=%s", template.ID)
+
+	return nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	log.Printf("Deleting template: ID=%s", id)
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete template: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("Template deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// SaveTaskAsTemplate creates a template from an existing task, and from its subtasks when requested.
+// Due dates become offsets from now, rounded up to whole days.
+func (s *TemplateService) SaveTaskAsTemplate(ctx context.Context, taskID uuid.UUID, req models.SaveAsTemplateRequest) (*models.TaskTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Saving task as template: TaskID=%s, Name=%s, Subtasks=%t", taskID, req.Name, req.IncludeSubtasks)
+
+	node := &models.TaskNode{}
+	if req.IncludeSubtasks {
+		tree, err := s.taskService.GetSubtree(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		node = tree
+	} else {
+		task, err := s.taskService.GetTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		node.Task = *task
+	}
+
+	template := &models.TaskTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		Task:        templateTaskFrom(node, time.Now()),
+	}
+
+	return s.createTemplate(ctx, template)
+}
+
+// Instantiate creates the tasks of a template, filling in its placeholders from req.Params. Every
+// placeholder needs a parameter and every parameter must be used by the template. When any task
+// cannot be created, the tasks already created for the template are deleted again. All tasks of the
+// template are logged as one operation, so a single undo removes them.
+func (s *TemplateService) Instantiate(ctx context.Context, id uuid.UUID, req models.InstantiateTemplateRequest) (node *models.TaskNode, err error) {
+	ctx, finish := s.taskService.beginOperation(ctx, models.OperationInstantiate)
+	defer func() {
+		var rootID uuid.UUID
+		if node != nil {
+			rootID = node.Task.ID
+		}
+		finish(rootID, err)
+	}()
+
+	log.Printf("Instantiating template: ID=%s", id)
+
+	template, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool, len(template.Variables))
+	for _, name := range template.Variables {
+		used[name] = true
+	}
+	for name := range req.Params {
+		if !used[name] {
+			return nil, fmt.Errorf("%w: %s", utils.ErrUnknownTemplateParam, name)
+		}
+	}
+
+	start := time.Now()
+	if req.Start != nil {
+		start = *req.Start
+	}
+
+	// Render everything first, so a missing parameter is reported before any task is created
+	rendered, err := renderTemplateTask(template.Task, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := s.createFromTemplate(ctx, rendered, req.ParentID, start)
+	if err != nil {
+		log.Printf("Failed to instantiate template: ID=%s, Error=%v", id, err)
+		if root != nil {
+			if cleanupErr := s.taskService.DeleteTaskWithMode(ctx, root.ID, models.DeleteCascade); cleanupErr != nil {
+				log.Printf("Failed to remove partially instantiated template: ID=%s, Error=%v", root.ID, cleanupErr)
+			}
+		}
+
+		return nil, err
+	}
+
+	log.Printf("Template instantiated successfully: ID=%s, TaskID=%s", id, root.ID)
+
+	return s.taskService.GetSubtree(ctx, root.ID)
+}
+
+// createFromTemplate creates a rendered template task and its subtasks. It returns the task it created
+// even on failure, so the caller can remove everything created so far.
+func (s *TemplateService) createFromTemplate(ctx context.Context, task models.TemplateTask, parentID *uuid.UUID, start time.Time) (*models.Task, error) {
+	offset, err := utils.ParseDueOffset(task.DueIn)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.taskService.CreateTask(ctx, models.CreateTaskRequest{
+		Title:            task.Title,
+		Description:      task.Description,
+		Category:         task.Category,
+		DueDate:          start.Add(offset),
+		Priority:         task.Priority,
+		Status:           s.taskService.workflowFor(task.Category).InitialStatus(),
+		ParentID:         parentID,
+		Assignees:        task.Assignees,
+		OriginalEstimate: task.OriginalEstimate,
+		StoryPoints:      task.StoryPoints,
+		CustomFields:     task.CustomFields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, text := range task.Checklist {
+		if _, err := s.taskService.AddChecklistItem(ctx, created.ID, models.AddChecklistItemRequest{Text: text}); err != nil {
+			return created, err
+		}
+	}
+
+	for _, subtask := range task.Subtasks {
+		if _, err := s.createFromTemplate(ctx, subtask, &created.ID, start); err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+func (s *TemplateService) createTemplate(ctx context.Context, template *models.TaskTemplate) (*models.TaskTemplate, error) {
+	log.Printf("Creating template: Name=%s", template.Name)
+
+	if err := s.validateTemplate(ctx, template); err != nil {
+		log.Printf("Template validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, template); err != nil {
+		log.Printf("Failed to create template: Name=%s, Error=%v", template.Name, err)
+
+		return nil, err
+	}
+
+	log.Printf("Template created successfully: ID=%s", template.ID)
+
+	return withVariables(template), nil
+}
+
+// validateTemplate checks the template and that its name is unique ignoring case
+func (s *TemplateService) validateTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	if err := s.validator.ValidateTemplate(template); err != nil {
+		return err
+	}
+
+	if existing, err := s.repo.GetByName(ctx, template.Name); err == nil && existing.ID != template.ID {
+		return fmt.Errorf("%w: %s", ErrTemplateNameTaken, template.Name)
+	}
+
+	return nil
+}
+
+// withVariables fills in the placeholders a template uses
+func withVariables(template *models.TaskTemplate) *models.TaskTemplate {
+	template.Variables = utils.TemplateVariables(&template.Task)
+
+	return template
+}
+
+// renderTemplateTask fills in the placeholders of a template task and its subtasks
+func renderTemplateTask(task models.TemplateTask, params map[string]string) (models.TemplateTask, error) {
+	rendered := task
+
+	for _, field := range []*string{&rendered.Title, &rendered.Description, &rendered.Category} {
+		text, err := utils.RenderPlaceholders(*field, params)
+		if err != nil {
+			return rendered, err
+		}
+		*field = text
+	}
+
+	rendered.Checklist = make([]string, len(task.Checklist))
+	for i, item := range task.Checklist {
+		text, err := utils.RenderPlaceholders(item, params)
+		if err != nil {
+			return rendered, err
+		}
+		rendered.Checklist[i] = text
+	}
+
+	if task.CustomFields != nil {
+		rendered.CustomFields = make(map[string]interface{}, len(task.CustomFields))
+		for key, value := range task.CustomFields {
+			text, ok := value.(string)
+			if !ok {
+				rendered.CustomFields[key] = value
+				continue
+			}
+			filled, err := utils.RenderPlaceholders(text, params)
+			if err != nil {
+				return rendered, err
+			}
+			rendered.CustomFields[key] = filled
+		}
+	}
+
+	rendered.Subtasks = make([]models.TemplateTask, len(task.Subtasks))
+	for i, subtask := range task.Subtasks {
+		child, err := renderTemplateTask(subtask, params)
+		if err != nil {
+			return rendered, err
+		}
+		rendered.Subtasks[i] = child
+	}
+
+	return rendered, nil
+}
+
+// templateTaskFrom turns a task and its subtasks into a template task with due dates relative to now
+func templateTaskFrom(node *models.TaskNode, now time.Time) models.TemplateTask {
+	task := node.Task
+
+	days := int(math.Ceil(task.DueDate.Sub(now).Hours() / 24))
+	if days < 1 {
+		days = 1
+	}
+
+	template := models.TemplateTask{
+		Title:            task.Title,
+		Description:      task.Description,
+		Category:         task.Category,
+		DueIn:            fmt.Sprintf("+%dd", days),
+		Priority:         task.Priority,
+		Assignees:        task.Assignees,
+		OriginalEstimate: task.OriginalEstimate,
+		StoryPoints:      task.StoryPoints,
+		CustomFields:     task.CustomFields,
+	}
+
+	for _, item := range task.Checklist {
+		template.Checklist = append(template.Checklist, item.Text)
+	}
+
+	for i := range node.Children {
+		template.Subtasks = append(template.Subtasks, templateTaskFrom(&node.Children[i], now))
+	}
+
+	return template
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func onboardingTemplate() models.CreateTemplateRequest {
+	return models.CreateTemplateRequest{
+		Name: "Onboarding",
+		Task: models.TemplateTask{
+			Title:     "Onboard {{customer}}",
+			Category:  "Work",
+			DueIn:     "+5d",
+			Priority:  models.PriorityHigh,
+			Checklist: []string{"Send welcome mail to {{contact}}"},
+			Subtasks: []models.TemplateTask{
+				{Title: "Create account for {{customer}}", Category: "Work", DueIn: "+1d", Priority: models.PriorityMedium},
+			},
+		},
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	service := NewTemplateService(repository.NewInMemoryTemplateRepository(), NewTaskService(repository.NewInMemoryTaskRepository()))
+	ctx := context.Background()
+
+	template, err := service.CreateTemplate(ctx, onboardingTemplate())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"contact", "customer"}, template.Variables)
+
+	duplicate := onboardingTemplate()
+	duplicate.Name = "ONBOARDING"
+	_, err = service.CreateTemplate(ctx, duplicate)
+	assert.ErrorIs(t, err, ErrTemplateNameTaken)
+
+	invalid := onboardingTemplate()
+	invalid.Name = "Broken"
+	invalid.Task.DueIn = "soon"
+	_, err = service.CreateTemplate(ctx, invalid)
+	assert.ErrorIs(t, err, utils.ErrInvalidDueOffset)
+
+	template.Task.Title = "Welcome {{customer}}"
+	assert.NoError(t, service.UpdateTemplate(ctx, template))
+
+	templates, err := service.ListTemplates(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, templates, 1)
+	assert.Equal(t, "Welcome {{customer}}", templates[0].Task.Title)
+
+	assert.NoError(t, service.DeleteTemplate(ctx, template.ID))
+	_, err = service.GetTemplate(ctx, template.ID)
+	assert.Error(t, err)
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewTemplateService(repository.NewInMemoryTemplateRepository(), taskService)
+	ctx := context.Background()
+
+	template, err := service.CreateTemplate(ctx, onboardingTemplate())
+	assert.NoError(t, err)
+
+	_, err = service.Instantiate(ctx, template.ID, models.InstantiateTemplateRequest{Params: map[string]string{"customer": "Acme"}})
+	assert.ErrorIs(t, err, utils.ErrMissingTemplateParam)
+
+	_, err = service.Instantiate(ctx, template.ID, models.InstantiateTemplateRequest{
+		Params: map[string]string{"customer": "Acme", "contact": "Ann", "region": "EU"},
+	})
+	assert.ErrorIs(t, err, utils.ErrUnknownTemplateParam)
+
+	start := time.Date(2030, 3, 1, 9, 0, 0, 0, time.UTC)
+	tree, err := service.Instantiate(ctx, template.ID, models.InstantiateTemplateRequest{
+		Params: map[string]string{"customer": "Acme", "contact": "Ann"},
+		Start:  &start,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Onboard Acme", tree.Task.Title)
+	assert.Equal(t, models.StatusToDo, tree.Task.Status)
+	assert.True(t, tree.Task.DueDate.Equal(start.AddDate(0, 0, 5)))
+	assert.Len(t, tree.Task.Checklist, 1)
+	assert.Equal(t, "Send welcome mail to Ann", tree.Task.Checklist[0].Text)
+	assert.Len(t, tree.Children, 1)
+	assert.Equal(t, "Create account for Acme", tree.Children[0].Task.Title)
+	assert.True(t, tree.Children[0].Task.DueDate.Equal(start.AddDate(0, 0, 1)))
+
+	tasks, err := taskService.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
+
+func TestInstantiateTemplateRollsBack(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewTemplateService(repository.NewInMemoryTemplateRepository(), taskService)
+	ctx := context.Background()
+
+	req := onboardingTemplate()
+	req.Task.Subtasks[0].Category = "{{team}}"
+	template, err := service.CreateTemplate(ctx, req)
+	assert.NoError(t, err)
+
+	// "Ops 42" is not a valid category, so the subtask fails after its parent was created
+	_, err = service.Instantiate(ctx, template.ID, models.InstantiateTemplateRequest{
+		Params: map[string]string{"customer": "Acme", "contact": "Ann", "team": "Ops 42"},
+	})
+	assert.Error(t, err)
+
+	tasks, err := taskService.ListTasks(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestSaveTaskAsTemplate(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewTemplateService(repository.NewInMemoryTemplateRepository(), taskService)
+	ctx := context.Background()
+
+	req := newTaskRequest("Release")
+	req.StoryPoints = floatPtr(3)
+	parent := createTask(t, taskService, req)
+	_, err := taskService.AddChecklistItem(ctx, parent.ID, models.AddChecklistItemRequest{Text: "Tag the build"})
+	assert.NoError(t, err)
+	child, err := taskService.CreateTask(ctx, models.CreateTaskRequest{
+		Title:    "Write release notes",
+		Category: "Work",
+		DueDate:  time.Now().Add(72 * time.Hour),
+		Priority: models.PriorityLow,
+		Status:   models.StatusToDo,
+		ParentID: &parent.ID,
+	})
+	assert.NoError(t, err)
+
+	template, err := service.SaveTaskAsTemplate(ctx, parent.ID, models.SaveAsTemplateRequest{Name: "Release"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Release", template.Task.Title)
+	assert.Equal(t, "+1d", template.Task.DueIn)
+	assert.Equal(t, []string{"Tag the build"}, template.Task.Checklist)
+	assert.Equal(t, 3.0, *template.Task.StoryPoints)
+	assert.Empty(t, template.Task.Subtasks)
+
+	deep, err := service.SaveTaskAsTemplate(ctx, parent.ID, models.SaveAsTemplateRequest{Name: "Release with notes", IncludeSubtasks: true})
+	assert.NoError(t, err)
+	assert.Len(t, deep.Task.Subtasks, 1)
+	assert.Equal(t, child.Title, deep.Task.Subtasks[0].Title)
+	assert.Equal(t, "+3d", deep.Task.Subtasks[0].DueIn)
+
+	_, err = service.SaveTaskAsTemplate(ctx, parent.ID, models.SaveAsTemplateRequest{Name: "release"})
+	assert.ErrorIs(t, err, ErrTemplateNameTaken)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var ErrEmailTaken = errors.New("email already in use")
+
+// UserService manages the users that report and work on tasks.
+type UserService struct {
+	mu        sync.Mutex
+	repo      repository.UserRepository
+	taskRepo  repository.TaskRepository
+	validator *utils.Validator
+}
+
+func NewUserService(repo repository.UserRepository, taskRepo repository.TaskRepository) *UserService {
+	return &UserService{
+		repo:      repo,
+		taskRepo:  taskRepo,
+		validator: utils.NewValidator(),
+	}
+}
+
+func (s *UserService) CreateUser(ctx context.Context, req models.CreateUserRequest) (*models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := &models.User{
+		Name:  req.Name,
+		Email: req.Email,
+	}
+
+	log.Printf("Creating user: Name=%s, Email=%s", user.Name, user.Email)
+
+	if err := s.validateUser(ctx, user); err != nil {
+		log.Printf("User validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, user); err != nil {
+		log.Printf("Failed to create user: Email=%s, Error=%v", user.Email, err)
+
+		return nil, err
+	}
+
+	log.Printf("User created successfully: ID=%s", user.ID)
+	return user, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	log.Printf("Retrieving user: ID=%s", id)
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to retrieve user: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) ListUsers(ctx context.Context) ([]models.User, error) {
+	users, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list users: Error=%v", err)
+
+		return nil, err
+	}
+
+	log.Printf("Listed users successfully: Found %d users", len(users))
+
+	return users, nil
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Updating user: ID=%s, Email=%s", user.ID, user.Email)
+
+	if err := s.validateUser(ctx, user); err != nil {
+		log.Printf("User validation failed: ID=%s, Error=%v", user.ID, err)
+		return err
+	}
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		log.Printf("Failed to update user: ID=%s, Error=%v", user.ID, err)
+
+		return err
+	}
+
+	log.Printf("User updated successfully: ID=%s", user.ID)
+
+	return nil
+}
+
+// DeleteUser removes a user from every task it is assigned to or reported and deletes it.
+func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Deleting user: ID=%s", id)
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		log.Printf("Failed to delete user: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	if _, err := s.taskRepo.UnassignUser(ctx, id); err != nil {
+		log.Printf("Failed to unassign user from tasks: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete user: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("User deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// validateUser checks the user fields and that no other user has the same email address
+func (s *UserService) validateUser(ctx context.Context, user *models.User) error {
+	if err := s.validator.ValidateUser(user); err != nil {
+		return err
+	}
+
+	if existing, err := s.repo.GetByEmail(ctx, user.Email); err == nil && existing.ID != user.ID {
+		return fmt.Errorf("%w: %s", ErrEmailTaken, user.Email)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTaskRequest(title string) models.CreateTaskRequest {
+	return models.CreateTaskRequest{
+		Title:    title,
+		DueDate:  time.Now().Add(24 * time.Hour),
+		Priority: models.PriorityMedium,
+		Status:   models.StatusToDo,
+	}
+}
+
+// createTask creates a task from req, failing the test if it is rejected
+func createTask(t *testing.T, service *TaskService, req models.CreateTaskRequest) *models.Task {
+	task, err := service.CreateTask(context.Background(), req)
+	assert.NoError(t, err)
+
+	return task
+}
+
+func TestCreateUser(t *testing.T) {
+	service := NewUserService(repository.NewInMemoryUserRepository(), repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	_, err := service.CreateUser(ctx, models.CreateUserRequest{Name: "Jane Doe", Email: "jane@example.com"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		req         models.CreateUserRequest
+		expectedErr error
+		hasError    bool
+	}{
+		{
+			name: "Valid User",
+			req:  models.CreateUserRequest{Name: "John Doe", Email: "john@example.com"},
+		},
+		{
+			name:        "Duplicate Email Ignoring Case",
+			req:         models.CreateUserRequest{Name: "Jane", Email: "JANE@example.com"},
+			expectedErr: ErrEmailTaken,
+			hasError:    true,
+		},
+		{
+			name:     "Empty Name",
+			req:      models.CreateUserRequest{Email: "nobody@example.com"},
+			hasError: true,
+		},
+		{
+			name:     "Invalid Email",
+			req:      models.CreateUserRequest{Name: "Jim", Email: "jim"},
+			hasError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user, err := service.CreateUser(ctx, test.req)
+			if test.hasError {
+				assert.Error(t, err)
+				if test.expectedErr != nil {
+					assert.ErrorIs(t, err, test.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, user.ID)
+			}
+		})
+	}
+}
+
+func TestTaskAssignees(t *testing.T) {
+	taskRepo := repository.NewInMemoryTaskRepository()
+	userRepo := repository.NewInMemoryUserRepository()
+	taskService := NewTaskService(taskRepo)
+	taskService.SetUserRepository(userRepo)
+	userService := NewUserService(userRepo, taskRepo)
+	ctx := context.Background()
+
+	jane, err := userService.CreateUser(ctx, models.CreateUserRequest{Name: "Jane", Email: "jane@example.com"})
+	assert.NoError(t, err)
+	john, err := userService.CreateUser(ctx, models.CreateUserRequest{Name: "John", Email: "john@example.com"})
+	assert.NoError(t, err)
+
+	req := newTaskRequest("Shared")
+	req.Assignees = []uuid.UUID{jane.ID, john.ID}
+	shared, err := taskService.CreateTask(auth.WithUser(ctx, john.ID), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &john.ID, shared.ReporterID)
+
+	req = newTaskRequest("Jane only")
+	req.Assignees = []uuid.UUID{jane.ID}
+	_, err = taskService.CreateTask(ctx, req)
+	assert.NoError(t, err)
+
+	_, err = taskService.CreateTask(ctx, newTaskRequest("Nobody"))
+	assert.NoError(t, err)
+
+	req = newTaskRequest("Unknown assignee")
+	req.Assignees = []uuid.UUID{uuid.New()}
+	_, err = taskService.CreateTask(ctx, req)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	req = newTaskRequest("Assigned twice")
+	req.Assignees = []uuid.UUID{jane.ID, jane.ID}
+	_, err = taskService.CreateTask(ctx, req)
+	assert.ErrorIs(t, err, ErrDuplicateAssignee)
+
+	tests := []struct {
+		name     string
+		params   map[string]string
+		expected int
+	}{
+		{name: "Assigned To Jane", params: map[string]string{"assignee": jane.ID.String()}, expected: 2},
+		{name: "Assigned To John", params: map[string]string{"assignee": john.ID.String()}, expected: 1},
+		{name: "Reported By John", params: map[string]string{"reporter": john.ID.String()}, expected: 1},
+		{name: "Unassigned", params: map[string]string{"unassigned": "true"}, expected: 1},
+		{name: "Assigned", params: map[string]string{"unassigned": "false"}, expected: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := ParseTaskFilters(test.params)
+			assert.NoError(t, err)
+
+			tasks, err := taskService.ListTasks(ctx, filters)
+			assert.NoError(t, err)
+			assert.Len(t, tasks, test.expected)
+		})
+	}
+
+	mine, err := taskService.ListAssignedTasks(ctx, john.ID, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, mine, 1)
+	assert.Equal(t, shared.ID, mine[0].ID)
+
+	// Updates without a reporter keep the original one
+	stored, err := taskService.GetTask(ctx, shared.ID)
+	assert.NoError(t, err)
+	stored.ReporterID = nil
+	assert.NoError(t, taskService.UpdateTask(ctx, stored))
+	stored, err = taskService.GetTask(ctx, shared.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, &john.ID, stored.ReporterID)
+
+	assert.NoError(t, userService.DeleteUser(ctx, john.ID))
+	stored, err = taskService.GetTask(ctx, shared.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{jane.ID}, stored.Assignees)
+	assert.Nil(t, stored.ReporterID)
+}
+
+func TestDuplicateTaskAssignees(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	ctx := context.Background()
+
+	assignee := uuid.New()
+	req := newTaskRequest("Original")
+	req.Assignees = []uuid.UUID{assignee}
+	original, err := taskService.CreateTask(ctx, req)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		keep     *bool
+		expected []uuid.UUID
+	}{
+		{name: "Default Keeps Assignees", expected: []uuid.UUID{assignee}},
+		{name: "Keep", keep: boolPtr(true), expected: []uuid.UUID{assignee}},
+		{name: "Clear", keep: boolPtr(false), expected: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := taskService.DuplicateDefaults()
+			if test.keep != nil {
+				opts.KeepAssignees = *test.keep
+			}
+
+			duplicated, err := taskService.DuplicateTaskWithOptions(ctx, original.ID, opts)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, duplicated.Assignees)
+
+			stored, err := taskService.GetTask(ctx, duplicated.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, stored.Assignees)
+		})
+	}
+
+	taskService.SetKeepAssignees(false)
+	duplicated, err := taskService.DuplicateTask(ctx, original.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, duplicated.Assignees)
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
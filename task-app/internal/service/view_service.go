@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type ViewService struct {
+	repo        repository.ViewRepository
+	taskService *TaskService
+	validator   *utils.Validator
+}
+
+func NewViewService(repo repository.ViewRepository, taskService *TaskService) *ViewService {
+	return &ViewService{
+		repo:        repo,
+		taskService: taskService,
+		validator:   utils.NewValidator(),
+	}
+}
+
+func (s *ViewService) CreateView(ctx context.Context, req models.CreateViewRequest) (*models.View, error) {
+	view := &models.View{
+		Name:    req.Name,
+		Filters: req.Filters,
+		Sort:    req.Sort,
+		Columns: req.Columns,
+	}
+
+	log.Printf("Creating view: Name=%s", view.Name)
+
+	if err := s.validateView(view); err != nil {
+		log.Printf("View validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, view); err != nil {
+		log.Printf("Failed to create view: Name=%s, Error=%v", view.Name, err)
+
+		return nil, err
+	}
+
+	log.Printf("View created successfully: ID=%s", view.ID)
+	return view, nil
+}
+
+func (s *ViewService) GetView(ctx context.Context, id uuid.UUID) (*models.View, error) {
+	log.Printf("Retrieving view: ID=%s", id)
+
+	view, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to retrieve view: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *ViewService) UpdateView(ctx context.Context, view *models.View) error {
+	log.Printf("Updating view: ID=%s, Name=%s", view.ID, view.Name)
+
+	if err := s.validateView(view); err != nil {
+		log.Printf("View validation failed: ID=%s, Error=%v", view.ID, err)
+		return err
+	}
+
+	if err := s.repo.Update(ctx, view); err != nil {
+		log.Printf("Failed to update view: ID=%s, Error=%v", view.ID, err)
+
+		return err
+	}
+
+	log.Printf("View updated successfully: ID=%s", view.ID)
+
+	return nil
+}
+
+func (s *ViewService) DeleteView(ctx context.Context, id uuid.UUID) error {
+	log.Printf("Deleting view: ID=%s", id)
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete view: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	log.Printf("View deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+func (s *ViewService) ListViews(ctx context.Context) ([]models.View, error) {
+	views, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list views: Error=%v", err)
+
+		return nil, err
+	}
+
+	log.Printf("Listed views successfully: Found %d views", len(views))
+
+	return views, nil
+}
+
+// ExecuteView runs the saved filters of a view against the task store and applies its sort order.
+func (s *ViewService) ExecuteView(ctx context.Context, id uuid.UUID) (*models.View, []models.Task, error) {
+	log.Printf("Executing view: ID=%s", id)
+
+	view, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to retrieve view: ID=%s, Error=%v", id, err)
+
+		return nil, nil, err
+	}
+
+	filters, err := ParseTaskFilters(view.Filters)
+	if err != nil {
+		log.Printf("Invalid filters on view: ID=%s, Error=%v", id, err)
+
+		return nil, nil, err
+	}
+
+	tasks, err := s.taskService.ListTasks(ctx, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := SortTasks(tasks, view.Sort); err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("View executed successfully: ID=%s, Found %d tasks", id, len(tasks))
+
+	return view, tasks, nil
+}
+
+func (s *ViewService) validateView(view *models.View) error {
+	if err := s.validator.ValidateView(view); err != nil {
+		return err
+	}
+
+	if _, err := ParseTaskFilters(view.Filters); err != nil {
+		return err
+	}
+
+	return ValidateSort(view.Sort)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateView(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewViewService(repository.NewInMemoryViewRepository(), taskService)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		req       models.CreateViewRequest
+		expectErr bool
+	}{
+		{
+			name: "Valid View",
+			req: models.CreateViewRequest{
+				Name:    "My view",
+				Filters: map[string]string{"status": "TODO"},
+				Sort:    "-due_date",
+				Columns: []string{"id", "title"},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Empty Name",
+			req:       models.CreateViewRequest{Name: ""},
+			expectErr: true,
+		},
+		{
+			name:      "Unknown Filter",
+			req:       models.CreateViewRequest{Name: "Bad filter", Filters: map[string]string{"owner": "me"}},
+			expectErr: true,
+		},
+		{
+			name:      "Unknown Sort Field",
+			req:       models.CreateViewRequest{Name: "Bad sort", Sort: "colour"},
+			expectErr: true,
+		},
+		{
+			name:      "Unknown Column",
+			req:       models.CreateViewRequest{Name: "Bad column", Columns: []string{"colour"}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			view, err := service.CreateView(ctx, test.req)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.req.Name, view.Name)
+			}
+		})
+	}
+}
+
+func TestExecuteView(t *testing.T) {
+	repo := repository.NewInMemoryTaskRepository()
+	taskService := NewTaskService(repo)
+	service := NewViewService(repository.NewInMemoryViewRepository(), taskService)
+	ctx := context.Background()
+
+	for i, priority := range []models.Priority{models.PriorityHigh, models.PriorityLow, models.PriorityHigh} {
+		_ = repo.Create(ctx, &models.Task{
+			Title:    "Task",
+			DueDate:  time.Now().Add(time.Duration(i+1) * time.Hour),
+			Priority: priority,
+			Status:   models.StatusToDo,
+		})
+	}
+
+	view, err := service.CreateView(ctx, models.CreateViewRequest{
+		Name:    "High priority, latest first",
+		Filters: map[string]string{"priority": "HIGH"},
+		Sort:    "-due_date",
+	})
+	assert.NoError(t, err)
+
+	_, tasks, err := service.ExecuteView(ctx, view.ID)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.True(t, tasks[0].DueDate.After(tasks[1].DueDate))
+}
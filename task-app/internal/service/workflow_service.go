@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCategoryAlreadyBound = errors.New("category is already bound to another workflow")
+	ErrStatusInUse          = errors.New("tasks are in a status their workflow would no longer have")
+)
+
+// WorkflowService manages custom workflows and keeps their category bindings in sync with task validation.
+type WorkflowService struct {
+	mu          sync.Mutex
+	repo        repository.WorkflowRepository
+	registry    *utils.WorkflowRegistry
+	taskService *TaskService
+	validator   *utils.Validator
+}
+
+func NewWorkflowService(repo repository.WorkflowRepository, taskService *TaskService) *WorkflowService {
+	return &WorkflowService{
+		repo:        repo,
+		registry:    taskService.Workflows(),
+		taskService: taskService,
+		validator:   utils.NewValidator(),
+	}
+}
+
+func (s *WorkflowService) CreateWorkflow(ctx context.Context, req models.CreateWorkflowRequest) (*models.WorkflowDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	def := &models.WorkflowDefinition{
+		Name:        req.Name,
+		Statuses:    req.Statuses,
+		Terminal:    req.Terminal,
+		Transitions: req.Transitions,
+		Categories:  req.Categories,
+	}
+
+	log.Printf("Creating workflow: Name=%s, Categories=%v", def.Name, def.Categories)
+
+	if err := s.validateWorkflow(ctx, def); err != nil {
+		log.Printf("Workflow validation failed: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.checkStatusesInUse(ctx, nil, def); err != nil {
+		log.Printf("Workflow would strand tasks: %v", err)
+
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, def); err != nil {
+		log.Printf("Failed to create workflow: Name=%s, Error=%v", def.Name, err)
+
+		return nil, err
+	}
+
+	s.bind(def)
+
+	log.Printf("Workflow created successfully: ID=%s", def.ID)
+	return def, nil
+}
+
+func (s *WorkflowService) GetWorkflow(ctx context.Context, id uuid.UUID) (*models.WorkflowDefinition, error) {
+	log.Printf("Retrieving workflow: ID=%s", id)
+
+	def, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to retrieve workflow: ID=%s, Error=%v", id, err)
+
+		return nil, err
+	}
+
+	return def, nil
+}
+
+func (s *WorkflowService) UpdateWorkflow(ctx context.Context, def *models.WorkflowDefinition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Updating workflow: ID=%s, Name=%s", def.ID, def.Name)
+
+	existing, err := s.repo.GetByID(ctx, def.ID)
+	if err != nil {
+		log.Printf("Failed to update workflow: ID=%s, Error=%v", def.ID, err)
+		return err
+	}
+
+	if err := s.validateWorkflow(ctx, def); err != nil {
+		log.Printf("Workflow validation failed: ID=%s, Error=%v", def.ID, err)
+		return err
+	}
+
+	if err := s.checkStatusesInUse(ctx, existing, def); err != nil {
+		log.Printf("Workflow update would strand tasks: ID=%s, Error=%v", def.ID, err)
+		return err
+	}
+
+	previousCategories := existing.Categories
+	if err := s.repo.Update(ctx, def); err != nil {
+		log.Printf("Failed to update workflow: ID=%s, Error=%v", def.ID, err)
+
+		return err
+	}
+
+	for _, category := range previousCategories {
+		s.registry.Unbind(category)
+	}
+	s.bind(def)
+
+	log.Printf("Workflow updated successfully: ID=%s", def.ID)
+
+	return nil
+}
+
+func (s *WorkflowService) DeleteWorkflow(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Printf("Deleting workflow: ID=%s", id)
+
+	def, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to delete workflow: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	if err := s.checkStatusesInUse(ctx, def, nil); err != nil {
+		log.Printf("Workflow deletion would strand tasks: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete workflow: ID=%s, Error=%v", id, err)
+
+		return err
+	}
+
+	// Categories of a deleted workflow fall back to the default workflow
+	for _, category := range def.Categories {
+		s.registry.Unbind(category)
+	}
+
+	log.Printf("Workflow deleted successfully: ID=%s", id)
+
+	return nil
+}
+
+// RenameCategory moves the workflow bound to a category over to its new name, e.g. after a project was renamed.
+func (s *WorkflowService) RenameCategory(ctx context.Context, from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflows, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to rename workflow category: Error=%v", err)
+		return
+	}
+
+	for _, def := range workflows {
+		renamed := false
+		for i, category := range def.Categories {
+			if strings.EqualFold(category, from) {
+				def.Categories[i] = to
+				renamed = true
+			}
+		}
+		if !renamed {
+			continue
+		}
+
+		if err := s.repo.Update(ctx, &def); err != nil {
+			log.Printf("Failed to rename workflow category: ID=%s, Error=%v", def.ID, err)
+			return
+		}
+
+		s.registry.Unbind(from)
+		s.bind(&def)
+
+		log.Printf("Workflow category renamed: ID=%s, From=%s, To=%s", def.ID, from, to)
+	}
+}
+
+func (s *WorkflowService) ListWorkflows(ctx context.Context) ([]models.WorkflowDefinition, error) {
+	workflows, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list workflows: Error=%v", err)
+
+		return nil, err
+	}
+
+	log.Printf("Listed workflows successfully: Found %d workflows", len(workflows))
+
+	return workflows, nil
+}
+
+// validateWorkflow checks the definition and that none of its categories belongs to another workflow
+func (s *WorkflowService) validateWorkflow(ctx context.Context, def *models.WorkflowDefinition) error {
+	if err := s.validator.ValidateWorkflow(def); err != nil {
+		return err
+	}
+
+	workflows, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range workflows {
+		if other.ID == def.ID {
+			continue
+		}
+		for _, bound := range other.Categories {
+			for _, category := range def.Categories {
+				if strings.EqualFold(bound, category) {
+					return fmt.Errorf("%w: %s (%s)", ErrCategoryAlreadyBound, category, other.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkStatusesInUse makes sure that replacing existing, nil for a new workflow, with def, nil when the workflow
+// is deleted, leaves every task of their categories in a status of the workflow it ends up with. Tasks in a
+// status their workflow lacks could never change their status again.
+func (s *WorkflowService) checkStatusesInUse(ctx context.Context, existing, def *models.WorkflowDefinition) error {
+	var categories []string
+	var workflow *utils.Workflow
+	if existing != nil {
+		categories = append(categories, existing.Categories...)
+	}
+	if def != nil {
+		categories = append(categories, def.Categories...)
+		workflow = utils.NewWorkflowFromDefinition(def)
+	}
+
+	for _, category := range categories {
+		next := s.registry.Default()
+		if def != nil && containsCategory(def.Categories, category) {
+			next = workflow
+		}
+
+		tasks, err := s.taskService.ListTasks(ctx, map[string]interface{}{"category": category})
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			if !next.HasStatus(task.Status) {
+				return fmt.Errorf("%w: %s tasks in %s", ErrStatusInUse, category, task.Status)
+			}
+		}
+	}
+
+	return nil
+}
+
+// containsCategory reports whether categories holds category, ignoring case
+func containsCategory(categories []string, category string) bool {
+	for _, candidate := range categories {
+		if strings.EqualFold(candidate, category) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *WorkflowService) bind(def *models.WorkflowDefinition) {
+	workflow := utils.NewWorkflowFromDefinition(def)
+	for _, category := range def.Categories {
+		s.registry.Bind(category, workflow)
+	}
+}
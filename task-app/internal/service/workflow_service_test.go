@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func qaWorkflowRequest() models.CreateWorkflowRequest {
+	return models.CreateWorkflowRequest{
+		Name:     "QA",
+		Statuses: []models.Status{"TODO", "IN_PROGRESS", "IN_REVIEW", "VERIFIED"},
+		Terminal: []models.Status{"VERIFIED"},
+		Transitions: []models.StatusTransition{
+			{From: "TODO", To: "IN_PROGRESS"},
+			{From: "IN_PROGRESS", To: "IN_REVIEW"},
+			{From: "IN_REVIEW", To: "VERIFIED"},
+			{From: "IN_REVIEW", To: "IN_PROGRESS"},
+		},
+		Categories: []string{"QA"},
+	}
+}
+
+func TestCreateWorkflow(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewWorkflowService(repository.NewInMemoryWorkflowRepository(), taskService)
+	ctx := context.Background()
+
+	_, err := service.CreateWorkflow(ctx, qaWorkflowRequest())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		modify   func(req *models.CreateWorkflowRequest)
+		expected error
+	}{
+		{"Category Already Bound", func(req *models.CreateWorkflowRequest) { req.Categories = []string{"Ops", "QA"} }, ErrCategoryAlreadyBound},
+		{"Empty Name", func(req *models.CreateWorkflowRequest) { req.Name = "" }, utils.ErrEmptyWorkflowName},
+		{"Unknown Terminal Status", func(req *models.CreateWorkflowRequest) { req.Terminal = []models.Status{"SHIPPED"} }, utils.ErrUnknownWorkflowStatus},
+		{"Lowercase Status", func(req *models.CreateWorkflowRequest) { req.Statuses = append(req.Statuses, "on_hold") }, utils.ErrInvalidWorkflowStatus},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := qaWorkflowRequest()
+			req.Categories = []string{"Ops"}
+			test.modify(&req)
+
+			_, err := service.CreateWorkflow(ctx, req)
+			assert.ErrorIs(t, err, test.expected)
+		})
+	}
+}
+
+func TestCategoryWorkflowEnforcement(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewWorkflowService(repository.NewInMemoryWorkflowRepository(), taskService)
+	ctx := context.Background()
+
+	workflow, err := service.CreateWorkflow(ctx, qaWorkflowRequest())
+	assert.NoError(t, err)
+
+	newTask := func(category string, status models.Status) (*models.Task, error) {
+		return taskService.CreateTask(ctx, models.CreateTaskRequest{
+			Title:    "Category Task",
+			Category: category,
+			DueDate:  time.Now().Add(24 * time.Hour),
+			Priority: models.PriorityMedium,
+			Status:   status,
+		})
+	}
+
+	_, err = newTask("Dev", "IN_REVIEW")
+	assert.ErrorIs(t, err, utils.ErrInvalidStatus)
+
+	_, err = newTask("QA", models.StatusDone)
+	assert.ErrorIs(t, err, utils.ErrInvalidStatus)
+
+	task, err := newTask("QA", "IN_PROGRESS")
+	assert.NoError(t, err)
+
+	retrieved, err := taskService.GetTask(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Status{"IN_REVIEW"}, retrieved.AllowedTransitions)
+
+	skip := *task
+	skip.Status = "VERIFIED"
+	assert.ErrorIs(t, taskService.UpdateTask(ctx, &skip), utils.ErrTransitionNotAllowed)
+
+	// Changing category and status at once is checked against the workflow of the new category
+	done, err := newTask("Dev", models.StatusDone)
+	assert.NoError(t, err)
+	reopened := *done
+	reopened.Category = "Ops"
+	reopened.Status = models.StatusToDo
+	assert.ErrorIs(t, taskService.UpdateTask(ctx, &reopened), utils.ErrTransitionNotAllowed)
+
+	moved := *done
+	moved.Category = "QA"
+	moved.Status = "IN_PROGRESS"
+	assert.ErrorIs(t, taskService.UpdateTask(ctx, &moved), utils.ErrTransitionNotAllowed)
+	moved.Status = models.StatusToDo
+	assert.NoError(t, taskService.UpdateTask(ctx, &moved))
+
+	// Once the workflow is deleted the category falls back to the default statuses
+	assert.NoError(t, service.DeleteWorkflow(ctx, workflow.ID))
+	_, err = newTask("QA", models.StatusDone)
+	assert.NoError(t, err)
+}
+
+func TestCustomTerminalStatusUnblocksDependents(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewWorkflowService(repository.NewInMemoryWorkflowRepository(), taskService)
+	ctx := context.Background()
+
+	_, err := service.CreateWorkflow(ctx, qaWorkflowRequest())
+	assert.NoError(t, err)
+
+	review, err := taskService.CreateTask(ctx, models.CreateTaskRequest{
+		Title: "Review", Category: "QA", DueDate: time.Now().Add(24 * time.Hour), Priority: models.PriorityHigh, Status: "IN_REVIEW",
+	})
+	assert.NoError(t, err)
+	release := createTask(t, taskService, newTaskRequest("Release"))
+
+	_, err = taskService.AddDependency(ctx, release.ID, review.ID)
+	assert.NoError(t, err)
+
+	verified := *review
+	verified.Status = "VERIFIED"
+	assert.NoError(t, taskService.UpdateTask(ctx, &verified))
+
+	current, _ := taskService.GetTask(ctx, release.ID)
+	assert.Equal(t, models.StatusToDo, current.Status)
+}
+
+func TestCustomTerminalStatusIsNotOverdue(t *testing.T) {
+	taskRepo := repository.NewInMemoryTaskRepository()
+	taskService := NewTaskService(taskRepo)
+	service := NewWorkflowService(repository.NewInMemoryWorkflowRepository(), taskService)
+	ctx := context.Background()
+
+	_, err := service.CreateWorkflow(ctx, qaWorkflowRequest())
+	assert.NoError(t, err)
+
+	// Tasks past their due date can only be stored directly
+	for _, status := range []models.Status{"IN_REVIEW", "VERIFIED"} {
+		assert.NoError(t, taskRepo.Create(ctx, &models.Task{
+			Title: "Review", Category: "QA", DueDate: time.Now().Add(-time.Hour), Priority: models.PriorityHigh, Status: status,
+		}))
+	}
+
+	filters, err := ParseTaskFilters(map[string]string{"overdue": "true"})
+	assert.NoError(t, err)
+	overdue, err := taskService.ListTasks(ctx, filters)
+	assert.NoError(t, err)
+	assert.Len(t, overdue, 1)
+	assert.Equal(t, models.Status("IN_REVIEW"), overdue[0].Status)
+
+	stats, err := taskService.AggregateTasks(ctx, map[string]interface{}{}, models.AggregationOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Overdue)
+}
+
+func TestWorkflowStatusesInUse(t *testing.T) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	service := NewWorkflowService(repository.NewInMemoryWorkflowRepository(), taskService)
+	ctx := context.Background()
+
+	workflow, err := service.CreateWorkflow(ctx, qaWorkflowRequest())
+	assert.NoError(t, err)
+
+	// Categories match the workflow ignoring case, like project names
+	req := newTaskRequest("Review")
+	req.Category = "qa"
+	req.Status = "IN_REVIEW"
+	task := createTask(t, taskService, req)
+
+	reduced := *workflow
+	reduced.Statuses = []models.Status{"TODO", "IN_PROGRESS", "VERIFIED"}
+	reduced.Transitions = []models.StatusTransition{{From: "TODO", To: "IN_PROGRESS"}, {From: "IN_PROGRESS", To: "VERIFIED"}}
+	assert.ErrorIs(t, service.UpdateWorkflow(ctx, &reduced), ErrStatusInUse)
+	assert.ErrorIs(t, service.DeleteWorkflow(ctx, workflow.ID), ErrStatusInUse)
+
+	_, err = service.CreateWorkflow(ctx, models.CreateWorkflowRequest{
+		Name:        "Review",
+		Statuses:    []models.Status{"TODO", "DONE"},
+		Terminal:    []models.Status{"DONE"},
+		Transitions: []models.StatusTransition{{From: "TODO", To: "DONE"}},
+		Categories:  []string{"QA"},
+	})
+	assert.ErrorIs(t, err, ErrCategoryAlreadyBound)
+
+	// Once no task uses the status any more it can go
+	verified := *task
+	verified.Status = "VERIFIED"
+	assert.NoError(t, taskService.UpdateTask(ctx, &verified))
+	assert.NoError(t, service.UpdateWorkflow(ctx, &reduced))
+}
@@ -0,0 +1,434 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWorklogNotFound     = errors.New("worklog not found")
+	ErrWorklogAuthRequired = errors.New("time tracking requires an authenticated user")
+	ErrNotWorklogOwner     = errors.New("only the user who logged the time can delete it")
+	ErrTimerRunning        = errors.New("user already has a running timer")
+	ErrNoRunningTimer      = errors.New("no running timer for this user on the task")
+	ErrWorklogOverlap      = errors.New("worklog overlaps time the user already logged")
+	ErrInvalidReportRange  = errors.New("report range must end after it starts")
+)
+
+// WorklogService tracks the time users spend on tasks, through timers and manual worklog entries.
+type WorklogService struct {
+	repo        repository.WorklogRepository
+	taskService *TaskService
+	validator   *utils.Validator
+
+	// mu makes the overlap checks and the writes that depend on them atomic
+	mu sync.Mutex
+}
+
+// NewWorklogService creates the service and registers it to remove the worklogs of deleted tasks and to keep
+// them for undoing a delete.
+func NewWorklogService(repo repository.WorklogRepository, taskService *TaskService) *WorklogService {
+	s := &WorklogService{
+		repo:        repo,
+		taskService: taskService,
+		validator:   utils.NewValidator(),
+	}
+	taskService.OnTaskDeleted(s.deleteTaskWorklogs)
+	taskService.KeepOnDelete("worklogs", s.keepTaskWorklogs, s.restoreTaskWorklogs)
+
+	return s
+}
+
+// StartTimer starts tracking time on a task. A user can only run one timer at a time, on any task.
+func (s *WorklogService) StartTimer(ctx context.Context, taskID uuid.UUID, req models.StartTimerRequest) (*models.Worklog, error) {
+	userID, err := worklogUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Starting timer: TaskID=%s, UserID=%s", taskID, userID)
+
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	worklog := &models.Worklog{
+		TaskID: taskID,
+		UserID: userID,
+		Start:  time.Now(),
+		Note:   req.Note,
+	}
+	if err := s.validator.ValidateWorklog(worklog); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	running, err := s.runningTimer(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		log.Printf("Timer already running: UserID=%s, TaskID=%s", userID, running.TaskID)
+
+		return nil, ErrTimerRunning
+	}
+
+	if err := s.repo.Create(ctx, worklog); err != nil {
+		log.Printf("Failed to start timer: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Timer started successfully: ID=%s", worklog.ID)
+	return worklog, nil
+}
+
+// StopTimer stops the user's running timer on a task and records its duration.
+func (s *WorklogService) StopTimer(ctx context.Context, taskID uuid.UUID) (*models.Worklog, error) {
+	userID, err := worklogUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Stopping timer: TaskID=%s, UserID=%s", taskID, userID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	worklog, err := s.runningTimer(ctx, userID, &taskID)
+	if err != nil {
+		return nil, err
+	}
+	if worklog == nil {
+		return nil, ErrNoRunningTimer
+	}
+
+	end := time.Now()
+	worklog.End = &end
+	worklog.DurationSeconds = int64(end.Sub(worklog.Start) / time.Second)
+	if err := s.repo.Update(ctx, worklog); err != nil {
+		log.Printf("Failed to stop timer: ID=%s, Error=%v", worklog.ID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Timer stopped successfully: ID=%s, Duration=%ds", worklog.ID, worklog.DurationSeconds)
+	return worklog, nil
+}
+
+// CreateWorklog records time spent on a task after the fact. The entry may not overlap other time of the same user.
+func (s *WorklogService) CreateWorklog(ctx context.Context, taskID uuid.UUID, req models.CreateWorklogRequest) (*models.Worklog, error) {
+	userID, err := worklogUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Creating worklog: TaskID=%s, UserID=%s", taskID, userID)
+
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	end := req.End
+	worklog := &models.Worklog{
+		TaskID:          taskID,
+		UserID:          userID,
+		Start:           req.Start,
+		End:             &end,
+		DurationSeconds: int64(req.End.Sub(req.Start) / time.Second),
+		Note:            req.Note,
+	}
+	if err := s.validator.ValidateWorklog(worklog); err != nil {
+		log.Printf("Worklog validation failed: %v", err)
+
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.repo.List(ctx, models.WorklogFilter{UserID: &userID, From: req.Start, To: req.End})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		log.Printf("Worklog overlaps existing worklog: ID=%s", existing[0].ID)
+
+		return nil, ErrWorklogOverlap
+	}
+
+	if err := s.repo.Create(ctx, worklog); err != nil {
+		log.Printf("Failed to create worklog: TaskID=%s, Error=%v", taskID, err)
+
+		return nil, err
+	}
+
+	log.Printf("Worklog created successfully: ID=%s", worklog.ID)
+	return worklog, nil
+}
+
+// ListWorklogs returns the worklogs of a task, earliest first.
+func (s *WorklogService) ListWorklogs(ctx context.Context, taskID uuid.UUID) ([]models.Worklog, error) {
+	log.Printf("Listing worklogs: TaskID=%s", taskID)
+
+	if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, models.WorklogFilter{TaskID: &taskID})
+}
+
+func (s *WorklogService) DeleteWorklog(ctx context.Context, taskID, id uuid.UUID) error {
+	log.Printf("Deleting worklog: TaskID=%s, ID=%s", taskID, id)
+
+	worklog, err := s.repo.GetByID(ctx, id)
+	if err != nil || worklog.TaskID != taskID {
+		return ErrWorklogNotFound
+	}
+
+	userID, err := worklogUser(ctx)
+	if err != nil {
+		return err
+	}
+	if userID != worklog.UserID {
+		return ErrNotWorklogOwner
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// TaskTime totals the time logged on a task, counting running timers up to now.
+func (s *WorklogService) TaskTime(ctx context.Context, taskID uuid.UUID) (*models.TaskTime, error) {
+	log.Printf("Totalling time: TaskID=%s", taskID)
+
+	task, err := s.taskService.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	worklogs, err := s.repo.List(ctx, models.WorklogFilter{TaskID: &taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	total := models.TaskTime{TaskID: task.ID, Title: task.Title, Category: task.Category}
+	now := time.Now()
+	for _, worklog := range worklogs {
+		total.TotalSeconds += loggedSeconds(worklog, time.Time{}, time.Time{}, now)
+		if worklog.Running() {
+			total.Running++
+		}
+	}
+
+	return &total, nil
+}
+
+// Report totals the time logged within [filter.From, filter.To) per task and per category. When
+// category is set only tasks in that category are included.
+func (s *WorklogService) Report(ctx context.Context, filter models.WorklogFilter, category string) (*models.TimeReport, error) {
+	log.Printf("Building time report: From=%s, To=%s, Category=%q", filter.From, filter.To, category)
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, ErrInvalidReportRange
+	}
+
+	worklogs, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.TimeReport{
+		Tasks:      []models.TaskTime{},
+		Categories: []models.CategoryTime{},
+	}
+	if !filter.From.IsZero() {
+		report.From = &filter.From
+	}
+	if !filter.To.IsZero() {
+		report.To = &filter.To
+	}
+
+	now := time.Now()
+	byTask := make(map[uuid.UUID]*models.TaskTime)
+	byCategory := make(map[string]int64)
+	for _, worklog := range worklogs {
+		total, ok := byTask[worklog.TaskID]
+		if !ok {
+			task, err := s.taskService.GetTask(ctx, worklog.TaskID)
+			if err != nil {
+				return nil, err
+			}
+			total = &models.TaskTime{TaskID: task.ID, Title: task.Title, Category: task.Category}
+			byTask[task.ID] = total
+		}
+		if category != "" && total.Category != category {
+			continue
+		}
+
+		seconds := loggedSeconds(worklog, filter.From, filter.To, now)
+		total.TotalSeconds += seconds
+		if worklog.Running() {
+			total.Running++
+		}
+		byCategory[total.Category] += seconds
+		report.TotalSeconds += seconds
+	}
+
+	for _, total := range byTask {
+		if category == "" || total.Category == category {
+			report.Tasks = append(report.Tasks, *total)
+		}
+	}
+	sort.Slice(report.Tasks, func(i, j int) bool {
+		if report.Tasks[i].TotalSeconds != report.Tasks[j].TotalSeconds {
+			return report.Tasks[i].TotalSeconds > report.Tasks[j].TotalSeconds
+		}
+		return report.Tasks[i].Title < report.Tasks[j].Title
+	})
+
+	for name, seconds := range byCategory {
+		report.Categories = append(report.Categories, models.CategoryTime{Category: name, TotalSeconds: seconds})
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	return report, nil
+}
+
+// ParseReportRange reads the from and to bounds of a time report. Both accept RFC 3339 times or
+// plain dates; a plain to date includes that whole day.
+func ParseReportRange(from, to string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+
+	if from != "" {
+		if start, err = parseReportTime(from); err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid from date: " + from)
+		}
+	}
+
+	if to != "" {
+		if end, err = parseReportTime(to); err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid to date: " + to)
+		}
+		if _, dateOnly := time.Parse(time.DateOnly, to); dateOnly == nil {
+			end = end.AddDate(0, 0, 1)
+		}
+	}
+
+	return start, end, nil
+}
+
+func parseReportTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.DateOnly, value); err == nil {
+		return parsed, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// runningTimer finds the running timer of a user, optionally only on one task
+func (s *WorklogService) runningTimer(ctx context.Context, userID uuid.UUID, taskID *uuid.UUID) (*models.Worklog, error) {
+	worklogs, err := s.repo.List(ctx, models.WorklogFilter{UserID: &userID, TaskID: taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, worklog := range worklogs {
+		if worklog.Running() {
+			return &worklog, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *WorklogService) deleteTaskWorklogs(ctx context.Context, taskID uuid.UUID) {
+	if _, err := s.repo.DeleteByTask(ctx, taskID); err != nil {
+		log.Printf("Failed to delete worklogs of task: TaskID=%s, Error=%v", taskID, err)
+	}
+}
+
+// keepTaskWorklogs returns the worklogs of a task that is about to be deleted. Running timers are kept as
+// stopped at the deletion, so a restored task cannot start a second timer for their users.
+func (s *WorklogService) keepTaskWorklogs(ctx context.Context, taskID uuid.UUID) (interface{}, error) {
+	worklogs, err := s.repo.List(ctx, models.WorklogFilter{TaskID: &taskID})
+	if err != nil || len(worklogs) == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range worklogs {
+		if worklogs[i].Running() {
+			worklogs[i].End = &now
+			worklogs[i].DurationSeconds = int64(now.Sub(worklogs[i].Start) / time.Second)
+		}
+	}
+
+	return worklogs, nil
+}
+
+// restoreTaskWorklogs brings back the worklogs kept for a restored task
+func (s *WorklogService) restoreTaskWorklogs(ctx context.Context, taskID uuid.UUID, data json.RawMessage) error {
+	var worklogs []models.Worklog
+	if err := json.Unmarshal(data, &worklogs); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, worklog := range worklogs {
+		restored := worklog
+		restored.TaskID = taskID
+		if err := s.repo.Create(ctx, &restored); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// worklogUser returns the authenticated user, whose time is tracked
+func worklogUser(ctx context.Context) (uuid.UUID, error) {
+	userID, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return uuid.Nil, ErrWorklogAuthRequired
+	}
+
+	return userID, nil
+}
+
+// loggedSeconds is the part of a worklog inside [from, to), with running timers ending at now. Zero bounds are open.
+func loggedSeconds(worklog models.Worklog, from, to, now time.Time) int64 {
+	start, end := worklog.Start, now
+	if worklog.End != nil {
+		end = *worklog.End
+	}
+	if !from.IsZero() && start.Before(from) {
+		start = from
+	}
+	if !to.IsZero() && end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+
+	return int64(end.Sub(start) / time.Second)
+}
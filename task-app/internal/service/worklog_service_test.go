@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-app/internal/auth"
+	"task-app/internal/models"
+	"task-app/internal/repository"
+	"task-app/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWorklogService() (*WorklogService, *TaskService) {
+	taskService := NewTaskService(repository.NewInMemoryTaskRepository())
+	return NewWorklogService(repository.NewInMemoryWorklogRepository(), taskService), taskService
+}
+
+func TestTimers(t *testing.T) {
+	service, taskService := newWorklogService()
+	userID := uuid.New()
+	ctx := auth.WithUser(context.Background(), userID)
+
+	first, _ := taskService.CreateTask(ctx, newTaskRequest("Invoice"))
+	second, _ := taskService.CreateTask(ctx, newTaskRequest("Estimate"))
+
+	_, err := service.StopTimer(ctx, first.ID)
+	assert.ErrorIs(t, err, ErrNoRunningTimer)
+
+	timer, err := service.StartTimer(ctx, first.ID, models.StartTimerRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, userID, timer.UserID)
+	assert.True(t, timer.Running())
+
+	// One running timer per user, on any task
+	_, err = service.StartTimer(ctx, second.ID, models.StartTimerRequest{})
+	assert.ErrorIs(t, err, ErrTimerRunning)
+
+	// Other users can track time at the same time
+	_, err = service.StartTimer(auth.WithUser(context.Background(), uuid.New()), second.ID, models.StartTimerRequest{})
+	assert.NoError(t, err)
+
+	_, err = service.StartTimer(context.Background(), second.ID, models.StartTimerRequest{})
+	assert.ErrorIs(t, err, ErrWorklogAuthRequired)
+
+	_, err = service.StopTimer(ctx, second.ID)
+	assert.ErrorIs(t, err, ErrNoRunningTimer)
+
+	stopped, err := service.StopTimer(ctx, first.ID)
+	assert.NoError(t, err)
+	assert.False(t, stopped.Running())
+
+	_, err = service.StartTimer(ctx, second.ID, models.StartTimerRequest{})
+	assert.NoError(t, err)
+
+	total, err := service.TaskTime(ctx, second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total.Running)
+
+	// Deleting the task removes its time
+	assert.NoError(t, taskService.DeleteTask(ctx, second.ID))
+	_, err = service.StartTimer(ctx, first.ID, models.StartTimerRequest{})
+	assert.NoError(t, err)
+}
+
+func TestWorklogsAndReport(t *testing.T) {
+	service, taskService := newWorklogService()
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+
+	billing := newTaskRequest("Billing")
+	billing.Category = "Client A"
+	design := newTaskRequest("Design")
+	design.Category = "Client B"
+	billingTask, _ := taskService.CreateTask(ctx, billing)
+	designTask, _ := taskService.CreateTask(ctx, design)
+
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	at := func(hours float64) time.Time {
+		return day.Add(time.Duration(hours * float64(time.Hour)))
+	}
+
+	tests := []struct {
+		name        string
+		taskID      uuid.UUID
+		user        uuid.UUID
+		req         models.CreateWorklogRequest
+		expectedErr error
+	}{
+		{name: "Alice Billing", taskID: billingTask.ID, user: alice, req: models.CreateWorklogRequest{Start: at(9), End: at(11)}},
+		{name: "Alice Design", taskID: designTask.ID, user: alice, req: models.CreateWorklogRequest{Start: at(11), End: at(12.5)}},
+		{name: "Bob Billing Late", taskID: billingTask.ID, user: bob, req: models.CreateWorklogRequest{Start: at(23), End: at(25)}},
+		{name: "Overlapping", taskID: designTask.ID, user: alice, req: models.CreateWorklogRequest{Start: at(10), End: at(11.5)}, expectedErr: ErrWorklogOverlap},
+		{name: "End Before Start", taskID: designTask.ID, user: alice, req: models.CreateWorklogRequest{Start: at(15), End: at(14)}, expectedErr: utils.ErrWorklogEndBeforeStart},
+		{name: "In The Future", taskID: designTask.ID, user: alice, req: models.CreateWorklogRequest{Start: time.Now(), End: time.Now().Add(time.Hour)}, expectedErr: utils.ErrWorklogInFuture},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.CreateWorklog(auth.WithUser(ctx, test.user), test.taskID, test.req)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	from, to, err := ParseReportRange("2024-03-04", "2024-03-04")
+	assert.NoError(t, err)
+
+	report, err := service.Report(ctx, models.WorklogFilter{From: from, To: to}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4.5*3600), report.TotalSeconds)
+	assert.Equal(t, []models.CategoryTime{
+		{Category: "Client A", TotalSeconds: 3 * 3600},
+		{Category: "Client B", TotalSeconds: 1.5 * 3600},
+	}, report.Categories)
+	assert.Equal(t, "Billing", report.Tasks[0].Title)
+
+	report, err = service.Report(ctx, models.WorklogFilter{UserID: &bob}, "Client A")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2*3600), report.TotalSeconds)
+	assert.Len(t, report.Tasks, 1)
+
+	_, err = service.Report(ctx, models.WorklogFilter{From: to, To: from}, "")
+	assert.ErrorIs(t, err, ErrInvalidReportRange)
+
+	total, err := service.TaskTime(ctx, billingTask.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4*3600), total.TotalSeconds)
+
+	_, err = service.CreateWorklog(ctx, designTask.ID, models.CreateWorklogRequest{Start: at(20), End: at(21)})
+	assert.ErrorIs(t, err, ErrWorklogAuthRequired)
+
+	// Users can only delete their own time
+	worklogs, _ := service.ListWorklogs(ctx, designTask.ID)
+	assert.ErrorIs(t, service.DeleteWorklog(ctx, designTask.ID, worklogs[0].ID), ErrWorklogAuthRequired)
+	assert.ErrorIs(t, service.DeleteWorklog(auth.WithUser(ctx, bob), designTask.ID, worklogs[0].ID), ErrNotWorklogOwner)
+	assert.NoError(t, service.DeleteWorklog(auth.WithUser(ctx, alice), designTask.ID, worklogs[0].ID))
+	assert.ErrorIs(t, service.DeleteWorklog(ctx, designTask.ID, worklogs[0].ID), ErrWorklogNotFound)
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyChecklistItem   = errors.New("checklist item text cannot be empty")
+	ErrChecklistItemTooLong = errors.New("checklist item text cannot exceed 200 characters")
+	ErrChecklistTooLong     = errors.New("checklist cannot have more than 100 items")
+)
+
+func (v *Validator) ValidateChecklist(items []models.ChecklistItem) error {
+	if len(items) > 100 {
+		return ErrChecklistTooLong
+	}
+
+	for _, item := range items {
+		if strings.TrimSpace(item.Text) == "" {
+			return ErrEmptyChecklistItem
+		}
+
+		if len(item.Text) > 200 {
+			return ErrChecklistItemTooLong
+		}
+	}
+
+	return nil
+}
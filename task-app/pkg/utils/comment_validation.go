@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyComment   = errors.New("comment body cannot be empty")
+	ErrCommentTooLong = errors.New("comment body cannot exceed 2000 characters")
+)
+
+func (v *Validator) ValidateComment(comment *models.Comment) error {
+	if strings.TrimSpace(comment.Body) == "" {
+		return ErrEmptyComment
+	}
+
+	if len(comment.Body) > 2000 {
+		return ErrCommentTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCustomFieldKey   = errors.New("custom field key must start with a lowercase letter and contain only lowercase letters, digits and underscores, up to 30 characters")
+	ErrEmptyCustomFieldName    = errors.New("custom field name cannot be empty")
+	ErrCustomFieldNameTooLong  = errors.New("custom field name cannot exceed 50 characters")
+	ErrInvalidCustomFieldType  = errors.New("custom field type must be TEXT, NUMBER, DATE, ENUM or USER")
+	ErrMissingEnumOptions      = errors.New("enum custom field needs at least one option")
+	ErrDuplicateEnumOption     = errors.New("enum options must be unique, ignoring case")
+	ErrOptionsNotEnum          = errors.New("only enum custom fields have options")
+	ErrUnknownCustomField      = errors.New("unknown custom field")
+	ErrCustomFieldRequired     = errors.New("custom field is required")
+	ErrInvalidCustomFieldValue = errors.New("invalid custom field value")
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,29}$`)
+
+const maxCustomTextLength = 500
+
+func (v *Validator) ValidateCustomFieldDefinition(def *models.CustomFieldDefinition) error {
+	if !customFieldKeyPattern.MatchString(def.Key) {
+		return ErrInvalidCustomFieldKey
+	}
+
+	if strings.TrimSpace(def.Name) == "" {
+		return ErrEmptyCustomFieldName
+	}
+
+	if len(def.Name) > 50 {
+		return ErrCustomFieldNameTooLong
+	}
+
+	switch def.Type {
+	case models.CustomFieldText, models.CustomFieldNumber, models.CustomFieldDate, models.CustomFieldUser:
+		if len(def.Options) > 0 {
+			return ErrOptionsNotEnum
+		}
+	case models.CustomFieldEnum:
+		if len(def.Options) == 0 {
+			return ErrMissingEnumOptions
+		}
+		seen := make(map[string]bool, len(def.Options))
+		for _, option := range def.Options {
+			key := strings.ToLower(strings.TrimSpace(option))
+			if key == "" || seen[key] {
+				return ErrDuplicateEnumOption
+			}
+			seen[key] = true
+		}
+	default:
+		return ErrInvalidCustomFieldType
+	}
+
+	return nil
+}
+
+// ValidateCustomFields checks task custom field values against the definitions of the task's project and
+// returns them normalized: numbers as float64, dates as YYYY-MM-DD, enum values in the spelling of their
+// option and users as canonical UUID strings. Null values are treated as absent.
+func (v *Validator) ValidateCustomFields(values map[string]interface{}, defs []models.CustomFieldDefinition) (map[string]interface{}, error) {
+	byKey := make(map[string]models.CustomFieldDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+
+	normalized := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		def, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCustomField, key)
+		}
+		if value == nil {
+			continue
+		}
+
+		parsed, err := normalizeCustomValue(def, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidCustomFieldValue, key, err)
+		}
+		normalized[key] = parsed
+	}
+
+	for _, def := range defs {
+		if _, ok := normalized[def.Key]; def.Required && !ok {
+			return nil, fmt.Errorf("%w: %s", ErrCustomFieldRequired, def.Key)
+		}
+	}
+
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	return normalized, nil
+}
+
+func normalizeCustomValue(def models.CustomFieldDefinition, value interface{}) (interface{}, error) {
+	switch def.Type {
+	case models.CustomFieldNumber:
+		switch number := value.(type) {
+		case float64:
+			return number, nil
+		case int:
+			return float64(number), nil
+		}
+		return nil, errors.New("expected a number")
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return nil, errors.New("expected a string")
+	}
+
+	switch def.Type {
+	case models.CustomFieldText:
+		if len(text) > maxCustomTextLength {
+			return nil, fmt.Errorf("text cannot exceed %d characters", maxCustomTextLength)
+		}
+		return text, nil
+	case models.CustomFieldDate:
+		if date, err := time.Parse(models.CustomFieldDateLayout, text); err == nil {
+			return date.Format(models.CustomFieldDateLayout), nil
+		}
+		date, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			return nil, errors.New("expected a date as YYYY-MM-DD")
+		}
+		return date.Format(models.CustomFieldDateLayout), nil
+	case models.CustomFieldEnum:
+		for _, option := range def.Options {
+			if strings.EqualFold(strings.TrimSpace(option), strings.TrimSpace(text)) {
+				return option, nil
+			}
+		}
+		return nil, fmt.Errorf("expected one of %s", strings.Join(def.Options, ", "))
+	case models.CustomFieldUser:
+		id, err := uuid.Parse(text)
+		if err != nil {
+			return nil, errors.New("expected a user ID")
+		}
+		return id.String(), nil
+	}
+
+	return nil, ErrInvalidCustomFieldType
+}
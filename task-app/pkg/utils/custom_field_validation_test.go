@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"task-app/internal/models"
+)
+
+func TestValidateCustomFieldDefinition(t *testing.T) {
+	validator := NewValidator()
+
+	testCases := []struct {
+		name      string
+		def       models.CustomFieldDefinition
+		expectErr error
+	}{
+		{"Valid Text", models.CustomFieldDefinition{Key: "customer", Name: "Customer", Type: models.CustomFieldText}, nil},
+		{"Valid Enum", models.CustomFieldDefinition{Key: "severity", Name: "Severity", Type: models.CustomFieldEnum, Options: []string{"Low", "High"}}, nil},
+		{"Uppercase Key", models.CustomFieldDefinition{Key: "Customer", Name: "Customer", Type: models.CustomFieldText}, ErrInvalidCustomFieldKey},
+		{"Empty Name", models.CustomFieldDefinition{Key: "customer", Type: models.CustomFieldText}, ErrEmptyCustomFieldName},
+		{"Unknown Type", models.CustomFieldDefinition{Key: "customer", Name: "Customer", Type: "URL"}, ErrInvalidCustomFieldType},
+		{"Enum Without Options", models.CustomFieldDefinition{Key: "severity", Name: "Severity", Type: models.CustomFieldEnum}, ErrMissingEnumOptions},
+		{"Duplicate Options", models.CustomFieldDefinition{Key: "severity", Name: "Severity", Type: models.CustomFieldEnum, Options: []string{"High", "high"}}, ErrDuplicateEnumOption},
+		{"Options On Text", models.CustomFieldDefinition{Key: "customer", Name: "Customer", Type: models.CustomFieldText, Options: []string{"Acme"}}, ErrOptionsNotEnum},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validator.ValidateCustomFieldDefinition(&tc.def)
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Errorf("Expected error %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateCustomFields(t *testing.T) {
+	validator := NewValidator()
+	defs := []models.CustomFieldDefinition{
+		{Key: "customer", Type: models.CustomFieldText, Required: true},
+		{Key: "impact", Type: models.CustomFieldNumber},
+		{Key: "found_on", Type: models.CustomFieldDate},
+		{Key: "severity", Type: models.CustomFieldEnum, Options: []string{"Low", "High"}},
+		{Key: "owner", Type: models.CustomFieldUser},
+	}
+
+	testCases := []struct {
+		name      string
+		values    map[string]interface{}
+		expect    map[string]interface{}
+		expectErr error
+	}{
+		{
+			"Normalized Values",
+			map[string]interface{}{
+				"customer": "Acme",
+				"impact":   3.5,
+				"found_on": "2024-03-01T10:00:00Z",
+				"severity": "high",
+				"owner":    "7C9E6679-7425-40DE-944B-E07FC1F90AE7",
+			},
+			map[string]interface{}{
+				"customer": "Acme",
+				"impact":   3.5,
+				"found_on": "2024-03-01",
+				"severity": "High",
+				"owner":    "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+			},
+			nil,
+		},
+		{"Null Is Absent", map[string]interface{}{"customer": "Acme", "impact": nil}, map[string]interface{}{"customer": "Acme"}, nil},
+		{"Missing Required", map[string]interface{}{"impact": 1.0}, nil, ErrCustomFieldRequired},
+		{"Unknown Field", map[string]interface{}{"customer": "Acme", "browser": "Firefox"}, nil, ErrUnknownCustomField},
+		{"Number As Text", map[string]interface{}{"customer": "Acme", "impact": "3"}, nil, ErrInvalidCustomFieldValue},
+		{"Invalid Date", map[string]interface{}{"customer": "Acme", "found_on": "March 1st"}, nil, ErrInvalidCustomFieldValue},
+		{"Unknown Option", map[string]interface{}{"customer": "Acme", "severity": "Critical"}, nil, ErrInvalidCustomFieldValue},
+		{"Invalid User", map[string]interface{}{"customer": "Acme", "owner": "bob"}, nil, ErrInvalidCustomFieldValue},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := validator.ValidateCustomFields(tc.values, defs)
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Fatalf("Expected error %v, got: %v", tc.expectErr, err)
+			}
+			if tc.expectErr == nil && !reflect.DeepEqual(values, tc.expect) {
+				t.Errorf("Expected values %v, got: %v", tc.expect, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"task-app/internal/models"
+)
+
+// untrackedFields are task fields that are derived when a task is read or change on every write,
+// so they are left out of the change history
+var untrackedFields = map[string]bool{
+	"id":                   true,
+	"updated_at":           true,
+	"progress":             true,
+	"allowed_transitions":  true,
+	"checklist_completion": true,
+}
+
+// DiffTasks lists the fields that differ between two versions of a task, in declaration order.
+// A nil task has no fields, so diffing against nil lists every field that is set.
+func DiffTasks(before, after *models.Task) ([]models.FieldChange, error) {
+	beforeFields, err := trackedFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := trackedFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []models.FieldChange
+	for _, field := range models.TaskFields {
+		if untrackedFields[field] {
+			continue
+		}
+
+		oldValue, newValue := beforeFields[field], afterFields[field]
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+
+		changes = append(changes, models.FieldChange{Field: field, Before: oldValue, After: newValue})
+	}
+
+	return changes, nil
+}
+
+// ApplyChanges replays field changes onto the JSON fields of a task, setting each field to its After value.
+func ApplyChanges(fields map[string]json.RawMessage, changes []models.FieldChange) {
+	for _, change := range changes {
+		if change.After == nil {
+			delete(fields, change.Field)
+			continue
+		}
+
+		fields[change.Field] = change.After
+	}
+}
+
+func trackedFields(task *models.Task) (map[string]json.RawMessage, error) {
+	if task == nil {
+		return map[string]json.RawMessage{}, nil
+	}
+
+	return ProjectTask(task, models.TaskFields)
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+)
+
+func TestDiffTasks(t *testing.T) {
+	due := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	before := &models.Task{Title: "Deploy", DueDate: due, Priority: models.PriorityLow, Status: models.StatusToDo}
+	after := *before
+	after.Priority = models.PriorityHigh
+	after.Description = "Roll out to prod"
+	after.UpdatedAt = time.Now()
+
+	changes, err := DiffTasks(before, &after)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []models.FieldChange{
+		{Field: "description", Before: json.RawMessage(`""`), After: json.RawMessage(`"Roll out to prod"`)},
+		{Field: "priority", Before: json.RawMessage(`"LOW"`), After: json.RawMessage(`"HIGH"`)},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected %s, got: %s", expected, changes)
+	}
+
+	created, err := DiffTasks(nil, before)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	ApplyChanges(fields, created)
+	if string(fields["title"]) != `"Deploy"` || fields["id"] != nil {
+		t.Errorf("Expected created fields without id, got: %v", fields)
+	}
+
+	ApplyChanges(fields, expected)
+	if string(fields["priority"]) != `"HIGH"` {
+		t.Errorf("Expected priority HIGH after applying changes, got: %s", fields["priority"])
+	}
+}
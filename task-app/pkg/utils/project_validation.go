@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyProjectName          = errors.New("project name cannot be empty")
+	ErrInvalidProjectName        = errors.New("project name may only contain letters, spaces and hyphens, up to 50 characters")
+	ErrProjectDescriptionTooLong = errors.New("project description cannot exceed 500 characters")
+)
+
+func (v *Validator) ValidateProject(project *models.Project) error {
+	if strings.TrimSpace(project.Name) == "" {
+		return ErrEmptyProjectName
+	}
+
+	// Project names are task categories, so they follow the same rules
+	if err := v.validateCategory(project.Name); err != nil {
+		return ErrInvalidProjectName
+	}
+
+	if len(project.Description) > 500 {
+		return ErrProjectDescriptionTooLong
+	}
+
+	if project.DefaultPriority != "" {
+		if err := v.validatePriority(project.DefaultPriority); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
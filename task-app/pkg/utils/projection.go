@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"task-app/internal/models"
+)
+
+var ErrUnknownField = errors.New("unknown field")
+
+// ParseFields splits a comma-separated fields parameter and checks every name against the task fields
+func ParseFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		if !isTaskField(field) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// ProjectTask returns only the requested JSON fields of a task
+func ProjectTask(task *models.Task, fields []string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			projected[field] = value
+		}
+	}
+
+	return projected, nil
+}
+
+// ProjectTasks applies ProjectTask to every task of a list
+func ProjectTasks(tasks []models.Task, fields []string) ([]map[string]json.RawMessage, error) {
+	projected := make([]map[string]json.RawMessage, 0, len(tasks))
+
+	for i := range tasks {
+		p, err := ProjectTask(&tasks[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, p)
+	}
+
+	return projected, nil
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestParseFields(t *testing.T) {
+	testCases := []struct {
+		name         string
+		raw          string
+		expectFields int
+		expectErr    bool
+	}{
+		{"Empty", "", 0, false},
+		{"Known Fields", "id,title, status,due_date", 4, false},
+		{"Unknown Field", "id,colour", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, err := ParseFields(tc.raw)
+
+			if tc.expectErr {
+				if !errors.Is(err, ErrUnknownField) {
+					t.Errorf("Expected ErrUnknownField, got: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Did not expect an error, but got: %v", err)
+			}
+
+			if len(fields) != tc.expectFields {
+				t.Errorf("Expected %d fields, got %d", tc.expectFields, len(fields))
+			}
+		})
+	}
+}
+
+func TestProjectTask(t *testing.T) {
+	task := &models.Task{
+		ID:          uuid.New(),
+		Title:       "Projected Task",
+		Description: "A long description that mobile clients do not need",
+		DueDate:     time.Now().Add(24 * time.Hour),
+		Priority:    models.PriorityHigh,
+		Status:      models.StatusToDo,
+	}
+
+	projected, err := ProjectTask(task, []string{"id", "title"})
+	if err != nil {
+		t.Fatalf("Did not expect an error, but got: %v", err)
+	}
+
+	if len(projected) != 2 {
+		t.Errorf("Expected 2 fields, got %d", len(projected))
+	}
+
+	if _, exists := projected["description"]; exists {
+		t.Errorf("Did not expect description in projection")
+	}
+
+	if string(projected["title"]) != `"Projected Task"` {
+		t.Errorf("Expected projected title, got %s", projected["title"])
+	}
+}
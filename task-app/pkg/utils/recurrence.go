@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrInvalidFrequency    = errors.New("frequency must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	ErrInvalidInterval     = errors.New("interval must be at least 1")
+	ErrInvalidByDay        = errors.New("by_day must list weekdays as MO, TU, WE, TH, FR, SA or SU")
+	ErrByDayNotWeekly      = errors.New("by_day is only supported for WEEKLY recurrence")
+	ErrInvalidCount        = errors.New("count must not be negative")
+	ErrUntilAndCount       = errors.New("until and count cannot both be set")
+	ErrUntilBeforeStart    = errors.New("until cannot be before the first occurrence")
+	ErrInvalidTrigger      = errors.New("trigger must be completion or schedule")
+	ErrRecurrenceNoDueDate = errors.New("recurring tasks need a due date")
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func (v *Validator) ValidateRecurrence(rule *models.Recurrence) error {
+	switch rule.Frequency {
+	case models.FrequencyDaily, models.FrequencyWeekly, models.FrequencyMonthly, models.FrequencyYearly:
+	default:
+		return ErrInvalidFrequency
+	}
+
+	if rule.Interval < 1 {
+		return ErrInvalidInterval
+	}
+
+	if len(rule.ByDay) > 0 && rule.Frequency != models.FrequencyWeekly {
+		return ErrByDayNotWeekly
+	}
+	for _, day := range rule.ByDay {
+		if _, ok := weekdays[day]; !ok {
+			return ErrInvalidByDay
+		}
+	}
+
+	if rule.Count < 0 {
+		return ErrInvalidCount
+	}
+
+	if rule.Until != nil && rule.Count > 0 {
+		return ErrUntilAndCount
+	}
+
+	if rule.Start.IsZero() {
+		return ErrRecurrenceNoDueDate
+	}
+
+	if rule.Until != nil && rule.Until.Before(rule.Start) {
+		return ErrUntilBeforeStart
+	}
+
+	switch rule.Trigger {
+	case models.TriggerCompletion, models.TriggerSchedule:
+	default:
+		return ErrInvalidTrigger
+	}
+
+	return nil
+}
+
+// NextOccurrence returns the due date of occurrence number occurrence (counting the first task as 0),
+// given the due date of the occurrence before it, and false once the rule's count or until date is exhausted.
+func NextOccurrence(rule *models.Recurrence, previous time.Time, occurrence int) (time.Time, bool) {
+	if rule.Count > 0 && occurrence >= rule.Count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	switch rule.Frequency {
+	case models.FrequencyDaily:
+		next = previous.AddDate(0, 0, rule.Interval)
+	case models.FrequencyWeekly:
+		next = nextWeekly(rule, previous)
+	case models.FrequencyMonthly:
+		next = addMonthsClamped(rule.Start, occurrence*rule.Interval)
+	case models.FrequencyYearly:
+		next = addMonthsClamped(rule.Start, occurrence*rule.Interval*12)
+	default:
+		return time.Time{}, false
+	}
+
+	if rule.Until != nil && next.After(*rule.Until) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+// nextWeekly finds the next listed weekday after previous, moving on by interval weeks
+// once the days of the current week are used up. Weeks start on Monday.
+func nextWeekly(rule *models.Recurrence, previous time.Time) time.Time {
+	if len(rule.ByDay) == 0 {
+		return previous.AddDate(0, 0, 7*rule.Interval)
+	}
+
+	listed := make(map[time.Weekday]bool, len(rule.ByDay))
+	for _, day := range rule.ByDay {
+		listed[weekdays[day]] = true
+	}
+
+	for day := previous.AddDate(0, 0, 1); day.Weekday() != time.Monday; day = day.AddDate(0, 0, 1) {
+		if listed[day.Weekday()] {
+			return day
+		}
+	}
+
+	weekStart := previous.AddDate(0, 0, 7*rule.Interval-daysSinceMonday(previous))
+	for day := weekStart; ; day = day.AddDate(0, 0, 1) {
+		if listed[day.Weekday()] {
+			return day
+		}
+	}
+}
+
+func daysSinceMonday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// addMonthsClamped adds months to t, moving to the last day of the month when t's day does not exist there
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return target.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+)
+
+func TestValidateRecurrence(t *testing.T) {
+	validator := NewValidator()
+	start := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, 0, -1)
+
+	testCases := []struct {
+		name      string
+		rule      models.Recurrence
+		expectErr error
+	}{
+		{"Valid Weekly", models.Recurrence{Frequency: models.FrequencyWeekly, Interval: 1, ByDay: []string{"MO", "FR"}, Trigger: models.TriggerSchedule, Start: start}, nil},
+		{"Unknown Frequency", models.Recurrence{Frequency: "HOURLY", Interval: 1, Trigger: models.TriggerCompletion, Start: start}, ErrInvalidFrequency},
+		{"Zero Interval", models.Recurrence{Frequency: models.FrequencyDaily, Trigger: models.TriggerCompletion, Start: start}, ErrInvalidInterval},
+		{"Unknown Weekday", models.Recurrence{Frequency: models.FrequencyWeekly, Interval: 1, ByDay: []string{"XX"}, Trigger: models.TriggerCompletion, Start: start}, ErrInvalidByDay},
+		{"ByDay Not Weekly", models.Recurrence{Frequency: models.FrequencyMonthly, Interval: 1, ByDay: []string{"MO"}, Trigger: models.TriggerCompletion, Start: start}, ErrByDayNotWeekly},
+		{"Until And Count", models.Recurrence{Frequency: models.FrequencyDaily, Interval: 1, Count: 3, Until: &start, Trigger: models.TriggerCompletion, Start: start}, ErrUntilAndCount},
+		{"Until Before Start", models.Recurrence{Frequency: models.FrequencyDaily, Interval: 1, Until: &before, Trigger: models.TriggerCompletion, Start: start}, ErrUntilBeforeStart},
+		{"No Due Date", models.Recurrence{Frequency: models.FrequencyDaily, Interval: 1, Trigger: models.TriggerCompletion}, ErrRecurrenceNoDueDate},
+		{"Unknown Trigger", models.Recurrence{Frequency: models.FrequencyDaily, Interval: 1, Trigger: "manual", Start: start}, ErrInvalidTrigger},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validator.ValidateRecurrence(&tc.rule)
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Errorf("Expected error %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+	until := date(2024, time.January, 12)
+
+	testCases := []struct {
+		name   string
+		rule   models.Recurrence
+		expect []time.Time
+	}{
+		{
+			"Daily Every Other Day",
+			models.Recurrence{Frequency: models.FrequencyDaily, Interval: 2, Start: date(2024, time.February, 27)},
+			[]time.Time{date(2024, time.February, 29), date(2024, time.March, 2)},
+		},
+		{
+			"Weekly On Monday Wednesday Friday",
+			models.Recurrence{Frequency: models.FrequencyWeekly, Interval: 1, ByDay: []string{"MO", "WE", "FR"}, Start: date(2024, time.January, 3)},
+			[]time.Time{date(2024, time.January, 5), date(2024, time.January, 8), date(2024, time.January, 10), date(2024, time.January, 12)},
+		},
+		{
+			"Biweekly On Tuesday",
+			models.Recurrence{Frequency: models.FrequencyWeekly, Interval: 2, ByDay: []string{"TU"}, Start: date(2024, time.January, 2)},
+			[]time.Time{date(2024, time.January, 16), date(2024, time.January, 30)},
+		},
+		{
+			"Monthly On The 31st",
+			models.Recurrence{Frequency: models.FrequencyMonthly, Interval: 1, Start: date(2024, time.January, 31)},
+			[]time.Time{date(2024, time.February, 29), date(2024, time.March, 31), date(2024, time.April, 30), date(2024, time.May, 31)},
+		},
+		{
+			"Yearly On Leap Day",
+			models.Recurrence{Frequency: models.FrequencyYearly, Interval: 1, Start: date(2024, time.February, 29)},
+			[]time.Time{date(2025, time.February, 28), date(2026, time.February, 28), date(2027, time.February, 28), date(2028, time.February, 29)},
+		},
+		{
+			"Count Ends Series",
+			models.Recurrence{Frequency: models.FrequencyDaily, Interval: 1, Count: 3, Start: date(2024, time.January, 1)},
+			[]time.Time{date(2024, time.January, 2), date(2024, time.January, 3)},
+		},
+		{
+			"Until Ends Series",
+			models.Recurrence{Frequency: models.FrequencyWeekly, Interval: 1, Until: &until, Start: date(2024, time.January, 1)},
+			[]time.Time{date(2024, time.January, 8)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []time.Time
+			previous := tc.rule.Start
+			for occurrence := 1; occurrence <= len(tc.expect)+1; occurrence++ {
+				next, ok := NextOccurrence(&tc.rule, previous, occurrence)
+				if !ok {
+					break
+				}
+				got = append(got, next)
+				previous = next
+			}
+
+			bounded := tc.rule.Count > 0 || tc.rule.Until != nil
+			if !bounded && len(got) > 0 {
+				got = got[:len(got)-1]
+			}
+
+			if len(got) != len(tc.expect) {
+				t.Fatalf("Expected %d occurrences, got %d: %v", len(tc.expect), len(got), got)
+			}
+			for i := range got {
+				if !got[i].Equal(tc.expect[i]) {
+					t.Errorf("Occurrence %d: expected %v, got %v", i+1, tc.expect[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"errors"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrReminderTiming        = errors.New("reminder needs exactly one of at or offset_minutes")
+	ErrInvalidReminderOffset = errors.New("offset_minutes must be between 0 and 525600")
+	ErrTooManyReminders      = errors.New("task cannot have more than 10 reminders")
+)
+
+// ValidateReminder checks a new reminder for a task that already has existing reminders.
+func (v *Validator) ValidateReminder(req models.CreateReminderRequest, existing int) error {
+	if (req.At == nil) == (req.OffsetMinutes == nil) {
+		return ErrReminderTiming
+	}
+
+	// Offsets reach back at most one year before the due date
+	if req.OffsetMinutes != nil && (*req.OffsetMinutes < 0 || *req.OffsetMinutes > 525600) {
+		return ErrInvalidReminderOffset
+	}
+
+	if existing >= 10 {
+		return ErrTooManyReminders
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptySprintName             = errors.New("sprint name cannot be empty")
+	ErrSprintNameTooLong           = errors.New("sprint name cannot exceed 100 characters")
+	ErrSprintGoalTooLong           = errors.New("sprint goal cannot exceed 500 characters")
+	ErrMissingSprintStart          = errors.New("sprint start date is required")
+	ErrSprintEndBeforeStart        = errors.New("sprint end date must be after its start date")
+	ErrSprintTooLong               = errors.New("sprint cannot last longer than 8 weeks")
+	ErrEmptyMilestoneName          = errors.New("milestone name cannot be empty")
+	ErrMilestoneNameTooLong        = errors.New("milestone name cannot exceed 100 characters")
+	ErrMilestoneDescriptionTooLong = errors.New("milestone description cannot exceed 500 characters")
+	ErrMissingMilestoneDate        = errors.New("milestone due date is required")
+)
+
+const maxSprintLength = 8 * 7 * 24 * time.Hour
+
+func (v *Validator) ValidateSprint(sprint *models.Sprint) error {
+	if strings.TrimSpace(sprint.Name) == "" {
+		return ErrEmptySprintName
+	}
+
+	if len(sprint.Name) > 100 {
+		return ErrSprintNameTooLong
+	}
+
+	if len(sprint.Goal) > 500 {
+		return ErrSprintGoalTooLong
+	}
+
+	if sprint.StartDate.IsZero() {
+		return ErrMissingSprintStart
+	}
+
+	if !sprint.EndDate.After(sprint.StartDate) {
+		return ErrSprintEndBeforeStart
+	}
+
+	if sprint.EndDate.Sub(sprint.StartDate) > maxSprintLength {
+		return ErrSprintTooLong
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateMilestone(milestone *models.Milestone) error {
+	if strings.TrimSpace(milestone.Name) == "" {
+		return ErrEmptyMilestoneName
+	}
+
+	if len(milestone.Name) > 100 {
+		return ErrMilestoneNameTooLong
+	}
+
+	if len(milestone.Description) > 500 {
+		return ErrMilestoneDescriptionTooLong
+	}
+
+	if milestone.DueDate.IsZero() {
+		return ErrMissingMilestoneDate
+	}
+
+	return nil
+}
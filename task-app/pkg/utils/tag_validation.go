@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyTagName          = errors.New("tag name cannot be empty")
+	ErrTagNameTooLong        = errors.New("tag name cannot exceed 50 characters")
+	ErrInvalidTagColor       = errors.New("tag color must be a hex color such as #1f77b4")
+	ErrTagDescriptionTooLong = errors.New("tag description cannot exceed 200 characters")
+)
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func (v *Validator) ValidateTag(tag *models.Tag) error {
+	if strings.TrimSpace(tag.Name) == "" {
+		return ErrEmptyTagName
+	}
+
+	if len(tag.Name) > 50 {
+		return ErrTagNameTooLong
+	}
+
+	// Color is optional
+	if tag.Color != "" && !hexColor.MatchString(tag.Color) {
+		return ErrInvalidTagColor
+	}
+
+	if len(tag.Description) > 200 {
+		return ErrTagDescriptionTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyTemplateName          = errors.New("template name cannot be empty")
+	ErrTemplateNameTooLong        = errors.New("template name cannot exceed 100 characters")
+	ErrEmptyTemplateTitle         = errors.New("template task title cannot be empty")
+	ErrInvalidDueOffset           = errors.New("due_in must be a positive offset such as +3d, +2w or +12h")
+	ErrTooManyTemplateTasks       = errors.New("template cannot contain more than 100 tasks")
+	ErrInvalidPlaceholder         = errors.New("placeholders must look like {{name}} with a lowercase name")
+	ErrMissingTemplateParam       = errors.New("missing template parameter")
+	ErrUnknownTemplateParam       = errors.New("unknown template parameter")
+	ErrTemplateTooDeep            = errors.New("template subtasks cannot be nested more than 5 levels deep")
+	ErrTemplateDescriptionTooLong = errors.New("template description cannot exceed 500 characters")
+)
+
+const (
+	maxTemplateTasks = 100
+	maxTemplateDepth = 5
+)
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+	dueOffsetPattern   = regexp.MustCompile(`^\+?(\d+)([hdw])$`)
+)
+
+func (v *Validator) ValidateTemplate(template *models.TaskTemplate) error {
+	if strings.TrimSpace(template.Name) == "" {
+		return ErrEmptyTemplateName
+	}
+
+	if len(template.Name) > 100 {
+		return ErrTemplateNameTooLong
+	}
+
+	if len(template.Description) > 500 {
+		return ErrTemplateDescriptionTooLong
+	}
+
+	count := 0
+	return v.validateTemplateTask(&template.Task, 1, &count)
+}
+
+func (v *Validator) validateTemplateTask(task *models.TemplateTask, depth int, count *int) error {
+	*count++
+	if *count > maxTemplateTasks {
+		return ErrTooManyTemplateTasks
+	}
+
+	if depth > maxTemplateDepth {
+		return ErrTemplateTooDeep
+	}
+
+	if strings.TrimSpace(task.Title) == "" {
+		return ErrEmptyTemplateTitle
+	}
+
+	if _, err := ParseDueOffset(task.DueIn); err != nil {
+		return err
+	}
+
+	if task.Priority != "" {
+		if err := v.validatePriority(task.Priority); err != nil {
+			return err
+		}
+	}
+
+	for _, text := range templateTexts(task) {
+		// Every "{{" must open a well-formed placeholder
+		if strings.Count(text, "{{") != len(placeholderPattern.FindAllString(text, -1)) {
+			return ErrInvalidPlaceholder
+		}
+	}
+
+	for i := range task.Subtasks {
+		if err := v.validateTemplateTask(&task.Subtasks[i], depth+1, count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseDueOffset parses a positive due date offset such as "+3d", "+2w" or "+12h".
+func ParseDueOffset(value string) (time.Duration, error) {
+	match := dueOffsetPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, ErrInvalidDueOffset
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil || n <= 0 {
+		return 0, ErrInvalidDueOffset
+	}
+
+	unit := time.Hour
+	switch match[2] {
+	case "d":
+		unit = 24 * time.Hour
+	case "w":
+		unit = 7 * 24 * time.Hour
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// TemplateVariables lists the placeholder names used anywhere in a template task and its subtasks, sorted.
+func TemplateVariables(task *models.TemplateTask) []string {
+	seen := make(map[string]bool)
+	collectVariables(task, seen)
+
+	variables := make([]string, 0, len(seen))
+	for name := range seen {
+		variables = append(variables, name)
+	}
+	sort.Strings(variables)
+
+	return variables
+}
+
+func collectVariables(task *models.TemplateTask, seen map[string]bool) {
+	for _, text := range templateTexts(task) {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			seen[match[1]] = true
+		}
+	}
+
+	for i := range task.Subtasks {
+		collectVariables(&task.Subtasks[i], seen)
+	}
+}
+
+// RenderPlaceholders replaces every {{name}} in text with its parameter.
+func RenderPlaceholders(text string, params map[string]string) (string, error) {
+	var missing error
+	rendered := placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := params[name]
+		if !ok && missing == nil {
+			missing = fmt.Errorf("%w: %s", ErrMissingTemplateParam, name)
+		}
+		return value
+	})
+
+	return rendered, missing
+}
+
+// templateTexts returns the texts of a template task that may contain placeholders
+func templateTexts(task *models.TemplateTask) []string {
+	texts := []string{task.Title, task.Description, task.Category}
+	texts = append(texts, task.Checklist...)
+	for _, value := range task.CustomFields {
+		if text, ok := value.(string); ok {
+			texts = append(texts, text)
+		}
+	}
+
+	return texts
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"task-app/internal/models"
+)
+
+func TestParseDueOffset(t *testing.T) {
+	testCases := []struct {
+		value     string
+		expected  time.Duration
+		expectErr error
+	}{
+		{"+3d", 3 * 24 * time.Hour, nil},
+		{"2w", 14 * 24 * time.Hour, nil},
+		{"+12h", 12 * time.Hour, nil},
+		{"", 0, ErrInvalidDueOffset},
+		{"+0d", 0, ErrInvalidDueOffset},
+		{"-3d", 0, ErrInvalidDueOffset},
+		{"+3m", 0, ErrInvalidDueOffset},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			offset, err := ParseDueOffset(tc.value)
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Errorf("Expected error %v, got: %v", tc.expectErr, err)
+			}
+			if offset != tc.expected {
+				t.Errorf("Expected offset %v, got: %v", tc.expected, offset)
+			}
+		})
+	}
+}
+
+func TestRenderPlaceholders(t *testing.T) {
+	params := map[string]string{"customer": "Acme", "env": "prod"}
+
+	testCases := []struct {
+		name      string
+		text      string
+		expected  string
+		expectErr error
+	}{
+		{"No Placeholders", "Deploy", "Deploy", nil},
+		{"Single", "Onboard {{customer}}", "Onboard Acme", nil},
+		{"Spaces And Repeats", "{{ customer }} on {{env}} for {{customer}}", "Acme on prod for Acme", nil},
+		{"Missing", "Call {{contact}}", "", ErrMissingTemplateParam},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := RenderPlaceholders(tc.text, params)
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Errorf("Expected error %v, got: %v", tc.expectErr, err)
+			}
+			if err == nil && rendered != tc.expected {
+				t.Errorf("Expected %q, got: %q", tc.expected, rendered)
+			}
+		})
+	}
+}
+
+func TestTemplateVariables(t *testing.T) {
+	task := &models.TemplateTask{
+		Title:        "Onboard {{customer}}",
+		Checklist:    []string{"Create account for {{contact}}"},
+		CustomFields: map[string]interface{}{"customer": "{{customer}}", "impact": 3.0},
+		Subtasks:     []models.TemplateTask{{Title: "Provision {{env}}"}},
+	}
+
+	expected := []string{"contact", "customer", "env"}
+	if variables := TemplateVariables(task); !reflect.DeepEqual(variables, expected) {
+		t.Errorf("Expected %v, got: %v", expected, variables)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	validator := NewValidator()
+	deep := models.TemplateTask{Title: "Level", DueIn: "+1d"}
+	for i := 0; i < maxTemplateDepth; i++ {
+		deep = models.TemplateTask{Title: "Level", DueIn: "+1d", Subtasks: []models.TemplateTask{deep}}
+	}
+
+	testCases := []struct {
+		name      string
+		template  models.TaskTemplate
+		expectErr error
+	}{
+		{"Valid", models.TaskTemplate{Name: "Onboarding", Task: models.TemplateTask{Title: "Onboard {{customer}}", DueIn: "+3d"}}, nil},
+		{"Empty Name", models.TaskTemplate{Task: models.TemplateTask{Title: "Onboard", DueIn: "+3d"}}, ErrEmptyTemplateName},
+		{"Empty Title", models.TaskTemplate{Name: "Onboarding", Task: models.TemplateTask{DueIn: "+3d"}}, ErrEmptyTemplateTitle},
+		{"Bad Offset", models.TaskTemplate{Name: "Onboarding", Task: models.TemplateTask{Title: "Onboard", DueIn: "3 days"}}, ErrInvalidDueOffset},
+		{"Bad Placeholder", models.TaskTemplate{Name: "Onboarding", Task: models.TemplateTask{Title: "Onboard {{Customer}}", DueIn: "+3d"}}, ErrInvalidPlaceholder},
+		{"Too Deep", models.TaskTemplate{Name: "Onboarding", Task: deep}, ErrTemplateTooDeep},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validator.ValidateTemplate(&tc.template)
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Errorf("Expected error %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
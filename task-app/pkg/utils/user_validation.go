@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyUserName   = errors.New("user name cannot be empty")
+	ErrUserNameTooLong = errors.New("user name cannot exceed 100 characters")
+	ErrInvalidEmail    = errors.New("email must be a valid address such as jane@example.com")
+)
+
+func (v *Validator) ValidateUser(user *models.User) error {
+	if strings.TrimSpace(user.Name) == "" {
+		return ErrEmptyUserName
+	}
+
+	if len(user.Name) > 100 {
+		return ErrUserNameTooLong
+	}
+
+	// Only bare addresses are accepted, not "Jane <jane@example.com>"
+	address, err := mail.ParseAddress(user.Email)
+	if err != nil || address.Address != user.Email {
+		return ErrInvalidEmail
+	}
+
+	return nil
+}
@@ -17,12 +17,23 @@ var (
 	ErrDueDateInPast      = errors.New("due date cannot be in the past")
 	ErrDescriptionTooLong = errors.New("description cannot exceed 500 characters")
 	ErrInvalidCategory    = errors.New("category name is invalid")
+	ErrInvalidEstimate    = errors.New("estimates must be between 0 and 100000 minutes")
+	ErrInvalidStoryPoints = errors.New("story points must be between 0 and 100")
 )
 
-type Validator struct{}
+type Validator struct {
+	workflows *WorkflowRegistry
+}
 
 func NewValidator() *Validator {
-	return &Validator{}
+	return &Validator{
+		workflows: NewWorkflowRegistry(NewDefaultWorkflow()),
+	}
+}
+
+// Workflows returns the registry used to validate task statuses per category
+func (v *Validator) Workflows() *WorkflowRegistry {
+	return v.workflows
 }
 
 func (v *Validator) ValidateTask(task *models.Task) error {
@@ -42,7 +53,7 @@ func (v *Validator) ValidateTask(task *models.Task) error {
 		return err
 	}
 
-	if err := v.validateStatus(task.Status); err != nil {
+	if err := v.validateStatus(task.Category, task.Status); err != nil {
 		return err
 	}
 
@@ -50,6 +61,24 @@ func (v *Validator) ValidateTask(task *models.Task) error {
 		return err
 	}
 
+	if err := v.validateEstimates(task); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *Validator) validateEstimates(task *models.Task) error {
+	for _, estimate := range []*int{task.OriginalEstimate, task.RemainingEstimate} {
+		if estimate != nil && (*estimate < 0 || *estimate > 100000) {
+			return ErrInvalidEstimate
+		}
+	}
+
+	if task.StoryPoints != nil && (*task.StoryPoints < 0 || *task.StoryPoints > 100) {
+		return ErrInvalidStoryPoints
+	}
+
 	return nil
 }
 
@@ -112,15 +141,9 @@ func (v *Validator) validatePriority(priority models.Priority) error {
 	return nil
 }
 
-func (v *Validator) validateStatus(status models.Status) error {
-	validStatuses := map[models.Status]bool{
-		models.StatusToDo:       true,
-		models.StatusInProgress: true,
-		models.StatusDone:       true,
-		models.StatusBlocked:    true,
-	}
-
-	if !validStatuses[status] {
+func (v *Validator) validateStatus(category string, status models.Status) error {
+	// The category's workflow decides which statuses are valid
+	if !v.workflows.ForCategory(category).HasStatus(status) {
 		return fmt.Errorf("%w: %s", ErrInvalidStatus, status)
 	}
 
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyViewName     = errors.New("view name cannot be empty")
+	ErrViewNameTooLong   = errors.New("view name cannot exceed 100 characters")
+	ErrInvalidViewColumn = errors.New("invalid view column")
+)
+
+func (v *Validator) ValidateView(view *models.View) error {
+	if view.Name == "" {
+		return ErrEmptyViewName
+	}
+
+	if len(view.Name) > 100 {
+		return ErrViewNameTooLong
+	}
+
+	for _, column := range view.Columns {
+		if !isTaskField(column) {
+			return fmt.Errorf("%w: %s", ErrInvalidViewColumn, column)
+		}
+	}
+
+	return nil
+}
+
+// isTaskField reports whether name is one of the JSON fields of a task
+func isTaskField(name string) bool {
+	for _, field := range models.TaskFields {
+		if field == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrTransitionNotAllowed = errors.New("status transition not allowed")
+	ErrTransitionGuard      = errors.New("status transition guard failed")
+)
+
+// Guard is an extra condition a task must satisfy before it may take a transition.
+type Guard struct {
+	Name  string
+	Check func(task *models.Task) error
+}
+
+// RequireDescription is a guard that rejects tasks without a description
+var RequireDescription = Guard{
+	Name: "requires description",
+	Check: func(task *models.Task) error {
+		if strings.TrimSpace(task.Description) == "" {
+			return errors.New("task must have a description")
+		}
+		return nil
+	},
+}
+
+type transition struct {
+	from models.Status
+	to   models.Status
+}
+
+// Workflow is a state machine of allowed status transitions with optional guards.
+// Statuses are kept in order, the first one being the initial status of new work,
+// and terminal statuses count as finished. Keeping the same status is always allowed.
+type Workflow struct {
+	Name        string
+	statuses    []models.Status
+	terminal    map[models.Status]bool
+	transitions map[models.Status][]models.Status
+	guards      map[transition][]Guard
+}
+
+func NewWorkflow() *Workflow {
+	return &Workflow{
+		terminal:    make(map[models.Status]bool),
+		transitions: make(map[models.Status][]models.Status),
+		guards:      make(map[transition][]Guard),
+	}
+}
+
+// NewDefaultWorkflow returns the standard workflow: work moves forward through IN_PROGRESS,
+// any open task can be blocked or unblocked, and a DONE task can only be reopened as IN_PROGRESS.
+func NewDefaultWorkflow() *Workflow {
+	w := NewWorkflow()
+	w.Name = "default"
+	w.AddStatus(models.StatusToDo, false)
+	w.AddStatus(models.StatusInProgress, false)
+	w.AddStatus(models.StatusDone, true)
+	w.AddStatus(models.StatusBlocked, false)
+	w.Allow(models.StatusToDo, models.StatusInProgress)
+	w.Allow(models.StatusToDo, models.StatusBlocked)
+	w.Allow(models.StatusToDo, models.StatusDone)
+	w.Allow(models.StatusInProgress, models.StatusToDo)
+	w.Allow(models.StatusInProgress, models.StatusBlocked)
+	w.Allow(models.StatusInProgress, models.StatusDone)
+	w.Allow(models.StatusBlocked, models.StatusToDo)
+	w.Allow(models.StatusBlocked, models.StatusInProgress)
+	w.Allow(models.StatusDone, models.StatusInProgress)
+
+	return w
+}
+
+// AddStatus appends a status to the workflow, marking it terminal if it counts as finished.
+func (w *Workflow) AddStatus(status models.Status, terminal bool) {
+	if !w.HasStatus(status) {
+		w.statuses = append(w.statuses, status)
+	}
+	w.terminal[status] = terminal
+}
+
+// HasStatus reports whether status belongs to the workflow.
+func (w *Workflow) HasStatus(status models.Status) bool {
+	for _, existing := range w.statuses {
+		if existing == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsTerminal reports whether status counts as finished in the workflow.
+func (w *Workflow) IsTerminal(status models.Status) bool {
+	return w.terminal[status]
+}
+
+// Statuses returns the statuses of the workflow in order.
+func (w *Workflow) Statuses() []models.Status {
+	return append([]models.Status{}, w.statuses...)
+}
+
+// InitialStatus returns the first status of the workflow.
+func (w *Workflow) InitialStatus() models.Status {
+	if len(w.statuses) == 0 {
+		return ""
+	}
+
+	return w.statuses[0]
+}
+
+// Allow permits moving from one status to another, subject to the given guards.
+// Statuses not yet in the workflow are added as non-terminal.
+func (w *Workflow) Allow(from, to models.Status, guards ...Guard) {
+	for _, status := range []models.Status{from, to} {
+		if !w.HasStatus(status) {
+			w.AddStatus(status, false)
+		}
+	}
+
+	key := transition{from: from, to: to}
+	if _, exists := w.guards[key]; !exists {
+		w.transitions[from] = append(w.transitions[from], to)
+	}
+	w.guards[key] = append(w.guards[key], guards...)
+}
+
+// GuardLeaving attaches guards to every transition out of a status, e.g. "must have description to leave TODO".
+func (w *Workflow) GuardLeaving(from models.Status, guards ...Guard) {
+	for _, to := range w.transitions[from] {
+		key := transition{from: from, to: to}
+		w.guards[key] = append(w.guards[key], guards...)
+	}
+}
+
+// CheckTransition returns an error unless task may move from its current status to the given one.
+func (w *Workflow) CheckTransition(from models.Status, task *models.Task) error {
+	to := task.Status
+	if from == to {
+		return nil
+	}
+
+	guards, exists := w.guards[transition{from: from, to: to}]
+	if !exists {
+		return fmt.Errorf("%w: %s -> %s", ErrTransitionNotAllowed, from, to)
+	}
+
+	for _, guard := range guards {
+		if err := guard.Check(task); err != nil {
+			return fmt.Errorf("%w: %s -> %s: %s: %v", ErrTransitionGuard, from, to, guard.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// NextStatuses lists the statuses a task may move to from its current status, honouring guards.
+func (w *Workflow) NextStatuses(task *models.Task) []models.Status {
+	next := []models.Status{}
+
+	for _, to := range w.transitions[task.Status] {
+		candidate := *task
+		candidate.Status = to
+		if w.CheckTransition(task.Status, &candidate) == nil {
+			next = append(next, to)
+		}
+	}
+
+	return next
+}
+
+// NewWorkflowFromDefinition builds a workflow from a stored definition.
+func NewWorkflowFromDefinition(def *models.WorkflowDefinition) *Workflow {
+	w := NewWorkflow()
+	w.Name = def.Name
+
+	terminal := make(map[models.Status]bool, len(def.Terminal))
+	for _, status := range def.Terminal {
+		terminal[status] = true
+	}
+
+	for _, status := range def.Statuses {
+		w.AddStatus(status, terminal[status])
+	}
+
+	for _, t := range def.Transitions {
+		w.Allow(t.From, t.To)
+	}
+
+	return w
+}
+
+// WorkflowRegistry resolves the workflow that applies to a task category. Categories match ignoring case,
+// like project names. Categories without a binding use the default workflow.
+type WorkflowRegistry struct {
+	mu              sync.RWMutex
+	defaultWorkflow *Workflow
+	byCategory      map[string]*Workflow
+}
+
+func NewWorkflowRegistry(defaultWorkflow *Workflow) *WorkflowRegistry {
+	return &WorkflowRegistry{
+		defaultWorkflow: defaultWorkflow,
+		byCategory:      make(map[string]*Workflow),
+	}
+}
+
+// SetDefault replaces the workflow used by categories without a binding.
+func (r *WorkflowRegistry) SetDefault(workflow *Workflow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.defaultWorkflow = workflow
+}
+
+// Default returns the workflow used by categories without a binding.
+func (r *WorkflowRegistry) Default() *Workflow {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.defaultWorkflow
+}
+
+// Bind makes workflow apply to every task in category.
+func (r *WorkflowRegistry) Bind(category string, workflow *Workflow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byCategory[strings.ToLower(category)] = workflow
+}
+
+// Unbind returns category to the default workflow.
+func (r *WorkflowRegistry) Unbind(category string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.byCategory, strings.ToLower(category))
+}
+
+// ForCategory returns the workflow bound to category, or the default workflow.
+func (r *WorkflowRegistry) ForCategory(category string) *Workflow {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if workflow, exists := r.byCategory[strings.ToLower(category)]; exists {
+		return workflow
+	}
+
+	return r.defaultWorkflow
+}
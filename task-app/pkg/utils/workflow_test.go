@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"task-app/internal/models"
+)
+
+func TestDefaultWorkflow(t *testing.T) {
+	workflow := NewDefaultWorkflow()
+
+	testCases := []struct {
+		name     string
+		from     models.Status
+		to       models.Status
+		expected error
+	}{
+		{"Start Work", models.StatusToDo, models.StatusInProgress, nil},
+		{"Finish Work", models.StatusInProgress, models.StatusDone, nil},
+		{"Reopen Done Task", models.StatusDone, models.StatusInProgress, nil},
+		{"Same Status", models.StatusDone, models.StatusDone, nil},
+		{"Done Back To Todo", models.StatusDone, models.StatusToDo, ErrTransitionNotAllowed},
+		{"Done To Blocked", models.StatusDone, models.StatusBlocked, ErrTransitionNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := workflow.CheckTransition(tc.from, &models.Task{Status: tc.to})
+
+			if tc.expected == nil && err != nil {
+				t.Errorf("Did not expect an error, but got: %v", err)
+			}
+
+			if tc.expected != nil && !errors.Is(err, tc.expected) {
+				t.Errorf("Expected %v, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestWorkflowGuards(t *testing.T) {
+	workflow := NewDefaultWorkflow()
+	workflow.GuardLeaving(models.StatusToDo, RequireDescription)
+
+	task := &models.Task{Status: models.StatusToDo}
+
+	if next := workflow.NextStatuses(task); len(next) != 0 {
+		t.Errorf("Expected no allowed transitions without a description, got %v", next)
+	}
+
+	err := workflow.CheckTransition(models.StatusToDo, &models.Task{Status: models.StatusInProgress})
+	if !errors.Is(err, ErrTransitionGuard) {
+		t.Errorf("Expected ErrTransitionGuard, got: %v", err)
+	}
+
+	task.Description = "Ready to start"
+	if next := workflow.NextStatuses(task); len(next) != 3 {
+		t.Errorf("Expected 3 allowed transitions, got %v", next)
+	}
+}
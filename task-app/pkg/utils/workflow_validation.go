@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrEmptyWorkflowName       = errors.New("workflow name cannot be empty")
+	ErrWorkflowWithoutStatus   = errors.New("workflow must define at least one status")
+	ErrInvalidWorkflowStatus   = errors.New("invalid workflow status")
+	ErrDuplicateWorkflowStatus = errors.New("duplicate workflow status")
+	ErrUnknownWorkflowStatus   = errors.New("workflow references an unknown status")
+)
+
+func (v *Validator) ValidateWorkflow(def *models.WorkflowDefinition) error {
+	if def.Name == "" {
+		return ErrEmptyWorkflowName
+	}
+
+	if len(def.Statuses) == 0 {
+		return ErrWorkflowWithoutStatus
+	}
+
+	known := make(map[models.Status]bool, len(def.Statuses))
+	for _, status := range def.Statuses {
+		if !isStatusName(status) {
+			return fmt.Errorf("%w: %q", ErrInvalidWorkflowStatus, status)
+		}
+		if known[status] {
+			return fmt.Errorf("%w: %s", ErrDuplicateWorkflowStatus, status)
+		}
+		known[status] = true
+	}
+
+	for _, status := range def.Terminal {
+		if !known[status] {
+			return fmt.Errorf("%w: %s", ErrUnknownWorkflowStatus, status)
+		}
+	}
+
+	for _, t := range def.Transitions {
+		if !known[t.From] {
+			return fmt.Errorf("%w: %s", ErrUnknownWorkflowStatus, t.From)
+		}
+		if !known[t.To] {
+			return fmt.Errorf("%w: %s", ErrUnknownWorkflowStatus, t.To)
+		}
+	}
+
+	for _, category := range def.Categories {
+		if category == "" {
+			return ErrInvalidCategory
+		}
+		if err := v.validateCategory(category); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isStatusName checks that a status is written like the built-in ones, e.g. IN_REVIEW
+func isStatusName(status models.Status) bool {
+	if status == "" || len(status) > 50 {
+		return false
+	}
+
+	for _, char := range status {
+		if !unicode.IsUpper(char) && !unicode.IsDigit(char) && char != '_' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"task-app/internal/models"
+)
+
+var (
+	ErrWorklogEndBeforeStart = errors.New("worklog end must be after its start")
+	ErrWorklogInFuture       = errors.New("worklog cannot end in the future")
+	ErrWorklogNoteTooLong    = errors.New("worklog note cannot exceed 500 characters")
+)
+
+// ValidateWorklog checks a stopped or manually entered worklog.
+func (v *Validator) ValidateWorklog(worklog *models.Worklog) error {
+	if len(worklog.Note) > 500 {
+		return ErrWorklogNoteTooLong
+	}
+
+	if worklog.End == nil {
+		return nil
+	}
+
+	if !worklog.End.After(worklog.Start) {
+		return ErrWorklogEndBeforeStart
+	}
+
+	if worklog.End.After(time.Now()) {
+		return ErrWorklogInFuture
+	}
+
+	return nil
+}